@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// internalCredentials 为每次调用附加内部令牌
+type internalCredentials struct{}
+
+func (internalCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{InternalTokenKey: loadInternalToken()}, nil
+}
+
+func (internalCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// WithInternalCredentials 服务间调用的连接选项，目标服务据此识别内部调用方
+func WithInternalCredentials() grpc.DialOption {
+	loadInternalToken()
+	return grpc.WithPerRPCCredentials(internalCredentials{})
+}
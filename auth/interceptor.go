@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TokenValidator 校验访问令牌并返回所属用户ID
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, accessToken string) (string, error)
+}
+
+// ServerOptions 返回安装认证拦截器的服务端选项。
+// 拦截器只负责识别调用方，是否允许操作由各个 handler 通过 Authorize 判断
+func ServerOptions(validator TokenValidator) []grpc.ServerOption {
+	loadInternalToken()
+	i := &interceptor{validator: validator}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(i.unary),
+		grpc.ChainStreamInterceptor(i.stream),
+	}
+}
+
+type interceptor struct {
+	validator TokenValidator
+}
+
+func (i *interceptor) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := i.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (i *interceptor) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := i.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate 识别调用方：内部令牌优先，其次是 Bearer 令牌，都没有时为匿名调用
+func (i *interceptor) authenticate(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	if token := first(md, InternalTokenKey); token != "" {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(loadInternalToken())) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid internal token")
+		}
+		return NewContext(ctx, &Caller{UserID: first(md, UserIDKey), Internal: true}), nil
+	}
+
+	if header := first(md, AuthorizationKey); header != "" {
+		if i.validator == nil || !strings.HasPrefix(header, "Bearer ") {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization")
+		}
+		userID, err := i.validator.ValidateToken(ctx, strings.TrimPrefix(header, "Bearer "))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return NewContext(ctx, &Caller{UserID: userID}), nil
+	}
+
+	// 没有内部令牌时不信任 x-user-id
+	return ctx, nil
+}
+
+func first(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// authenticatedStream 替换流的上下文，使 handler 能读取调用方
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
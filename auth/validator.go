@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "snake-game/proto"
+)
+
+const (
+	// validationCacheTTL 校验结果的缓存时间，令牌被吊销后最多在这段时间内仍被接受
+	validationCacheTTL = 30 * time.Second
+	// validationCacheSize 缓存条目超过该数量时清理过期条目
+	validationCacheSize = 10000
+)
+
+type cachedValidation struct {
+	userID    string
+	expiresAt time.Time
+}
+
+// lobbyValidator 调用大厅服务校验令牌，并缓存成功的结果
+type lobbyValidator struct {
+	client pb.LobbyServiceClient
+
+	mutex sync.Mutex
+	cache map[string]cachedValidation
+}
+
+// NewLobbyValidator 连接大厅服务（LOBBY_SERVICE_ADDR，默认 localhost:50051），连接失败时返回 nil
+func NewLobbyValidator() TokenValidator {
+	address := os.Getenv("LOBBY_SERVICE_ADDR")
+	if address == "" {
+		address = "localhost:50051"
+	}
+
+	conn, err := grpc.Dial(address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		WithInternalCredentials(),
+	)
+	if err != nil {
+		return nil
+	}
+
+	return &lobbyValidator{
+		client: pb.NewLobbyServiceClient(conn),
+		cache:  make(map[string]cachedValidation),
+	}
+}
+
+func (v *lobbyValidator) ValidateToken(ctx context.Context, accessToken string) (string, error) {
+	now := time.Now()
+
+	v.mutex.Lock()
+	cached, ok := v.cache[accessToken]
+	v.mutex.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.userID, nil
+	}
+
+	resp, err := v.client.ValidateToken(ctx, &pb.ValidateTokenRequest{AccessToken: accessToken})
+	if err != nil {
+		return "", errors.New("token validation unavailable")
+	}
+	if !resp.Success {
+		return "", errors.New(resp.Message)
+	}
+
+	v.mutex.Lock()
+	if len(v.cache) >= validationCacheSize {
+		for token, entry := range v.cache {
+			if now.After(entry.expiresAt) {
+				delete(v.cache, token)
+			}
+		}
+		// 仍然没有空间时整体清空，避免缓存无限增长
+		if len(v.cache) >= validationCacheSize {
+			v.cache = make(map[string]cachedValidation)
+		}
+	}
+	v.cache[accessToken] = cachedValidation{userID: resp.UserId, expiresAt: now.Add(validationCacheTTL)}
+	v.mutex.Unlock()
+
+	return resp.UserId, nil
+}
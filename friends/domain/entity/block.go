@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// Block 用户 BlockerID 屏蔽了用户 BlockedID
+type Block struct {
+	ID        string    `bson:"_id" json:"id"` // BlockerID:BlockedID，重复屏蔽不会产生多条记录
+	BlockerID string    `bson:"blocker_id" json:"blocker_id"`
+	BlockedID string    `bson:"blocked_id" json:"blocked_id"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
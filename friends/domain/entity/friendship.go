@@ -8,4 +8,12 @@ type Friendship struct {
 	FriendID  string    `bson:"friend_id" json:"friend_id"`
 	Status    string    `bson:"status" json:"status"` // pending, accepted, blocked
 	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// OtherID 返回好友关系中另一方的用户ID
+func (f *Friendship) OtherID(userID string) string {
+	if f.UserID == userID {
+		return f.FriendID
+	}
+	return f.UserID
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// Invite 用户 FromUserID 邀请好友 ToUserID 加入房间
+type Invite struct {
+	ID         string    `bson:"_id" json:"id"` // RoomID:FromUserID:ToUserID，未过期时不能重复邀请
+	RoomID     string    `bson:"room_id" json:"room_id"`
+	RoomName   string    `bson:"room_name" json:"room_name"`
+	FromUserID string    `bson:"from_user_id" json:"from_user_id"`
+	ToUserID   string    `bson:"to_user_id" json:"to_user_id"`
+	CreatedAt  time.Time `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"snake-game/friends/domain/entity"
+)
+
+type BlockRepository interface {
+	// CreateBlock 保存屏蔽记录，已存在时不做修改
+	CreateBlock(ctx context.Context, block *entity.Block) error
+	// DeleteBlock 删除屏蔽记录，返回记录是否存在
+	DeleteBlock(ctx context.Context, blockerID, blockedID string) (bool, error)
+	// GetBlocksBetween 返回两个用户之间任一方向的屏蔽记录
+	GetBlocksBetween(ctx context.Context, userID, otherID string) ([]*entity.Block, error)
+	// GetBlocksOf 返回用户屏蔽别人以及被别人屏蔽的全部记录，最新的在前
+	GetBlocksOf(ctx context.Context, userID string) ([]*entity.Block, error)
+}
@@ -2,14 +2,23 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"snake-game/friends/domain/entity"
 )
 
 type FriendRepository interface {
 	CreateFriendship(ctx context.Context, friendship *entity.Friendship) error
 	GetFriendship(ctx context.Context, userID, friendID string) (*entity.Friendship, error)
+	// UpdateFriendshipStatus 更新 userID 发给 friendID 的记录的状态，不匹配反方向的记录
 	UpdateFriendshipStatus(ctx context.Context, userID, friendID, status string) error
 	GetFriends(ctx context.Context, userID string) ([]*entity.Friendship, error)
-	GetPendingRequests(ctx context.Context, userID string) ([]*entity.Friendship, error)
+	// GetPendingRequests 分页获取在 since 之后创建的待处理请求，最新的在前。
+	// outgoing 为 true 时返回用户发出的请求，否则返回用户收到的请求
+	GetPendingRequests(ctx context.Context, userID string, outgoing bool, since time.Time, offset, limit int64) ([]*entity.Friendship, int64, error)
+	// DeletePendingRequest 删除 userID 发给 friendID 的待处理请求，返回请求是否存在
+	DeletePendingRequest(ctx context.Context, userID, friendID string) (bool, error)
+	// DeleteExpiredRequests 删除在 before 之前创建的待处理或已拒绝的请求
+	DeleteExpiredRequests(ctx context.Context, before time.Time) (int64, error)
 	DeleteFriendship(ctx context.Context, userID, friendID string) error
 }
\ No newline at end of file
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"snake-game/friends/domain/entity"
+)
+
+type InviteRepository interface {
+	// SaveInvite 保存邀请并返回是否保存。相同的邀请在 expiredBefore 之后发出（仍未过期）时不做修改，
+	// 已过期的旧邀请被替换
+	SaveInvite(ctx context.Context, invite *entity.Invite, expiredBefore time.Time) (bool, error)
+	GetInvite(ctx context.Context, inviteID string) (*entity.Invite, error)
+	// DeleteInvite 删除邀请，返回邀请是否存在
+	DeleteInvite(ctx context.Context, inviteID string) (bool, error)
+	// DeleteInvitesBetween 删除两个用户之间任一方向的邀请
+	DeleteInvitesBetween(ctx context.Context, userID, otherID string) error
+	// GetInvitesTo 获取用户在 since 之后收到的邀请，最新的在前
+	GetInvitesTo(ctx context.Context, userID string, since time.Time) ([]*entity.Invite, error)
+	// DeleteExpiredInvites 删除在 before 之前发出的邀请
+	DeleteExpiredInvites(ctx context.Context, before time.Time) (int64, error)
+}
@@ -3,6 +3,11 @@ package grpc
 import (
 	"context"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"snake-game/auth"
+	"snake-game/friends/domain/entity"
 	"snake-game/friends/internal/usecase"
 	pb "snake-game/proto"
 )
@@ -20,6 +25,10 @@ func NewFriendsHandler(usecase *usecase.FriendsUsecase) *FriendsHandler {
 
 // AddFriend 添加好友
 func (h *FriendsHandler) AddFriend(ctx context.Context, req *pb.AddFriendRequest) (*pb.AddFriendResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.AddFriend(ctx, req.UserId, req.FriendUsername)
 	if err != nil {
 		return &pb.AddFriendResponse{
@@ -36,6 +45,10 @@ func (h *FriendsHandler) AddFriend(ctx context.Context, req *pb.AddFriendRequest
 
 // RemoveFriend 删除好友
 func (h *FriendsHandler) RemoveFriend(ctx context.Context, req *pb.RemoveFriendRequest) (*pb.RemoveFriendResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.RemoveFriend(ctx, req.UserId, req.FriendUserId)
 	if err != nil {
 		return &pb.RemoveFriendResponse{
@@ -52,6 +65,10 @@ func (h *FriendsHandler) RemoveFriend(ctx context.Context, req *pb.RemoveFriendR
 
 // GetFriends 获取好友列表
 func (h *FriendsHandler) GetFriends(ctx context.Context, req *pb.GetFriendsRequest) (*pb.GetFriendsResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
 	friendships, err := h.usecase.GetFriends(ctx, req.UserId)
 	if err != nil {
 		return &pb.GetFriendsResponse{
@@ -60,14 +77,29 @@ func (h *FriendsHandler) GetFriends(ctx context.Context, req *pb.GetFriendsReque
 		}, nil
 	}
 
+	// 好友关系可能由任一方发起，取另一方作为好友
+	friendIDs := make([]string, len(friendships))
+	for i, friendship := range friendships {
+		friendIDs[i] = friendship.OtherID(req.UserId)
+	}
+	users := h.usecase.GetUsers(ctx, friendIDs)
+	// 正在对局中的好友可以直接观战
+	playingRooms := h.usecase.GetPlayingRooms(ctx, friendIDs)
+
 	// 转换为协议缓冲区格式
 	pbFriends := make([]*pb.FriendInfo, len(friendships))
 	for i, friendship := range friendships {
+		friendID := friendIDs[i]
 		pbFriends[i] = &pb.FriendInfo{
-			UserId:   friendship.FriendID, // 需要根据实际关系调整
-			Username: "Unknown", // 需要从用户服务获取用户名
-			Online:   false,     // 需要从用户服务获取在线状态
-			Status:   friendship.Status,
+			UserId:        friendID,
+			Username:      "Unknown",
+			Status:        friendship.Status,
+			PlayingRoomId: playingRooms[friendID],
+		}
+		if user, ok := users[friendID]; ok {
+			pbFriends[i].Username = user.Username
+			pbFriends[i].Online = user.Online
+			pbFriends[i].Presence = user.Presence
 		}
 	}
 
@@ -80,6 +112,10 @@ func (h *FriendsHandler) GetFriends(ctx context.Context, req *pb.GetFriendsReque
 
 // SendFriendRequest 发送好友请求
 func (h *FriendsHandler) SendFriendRequest(ctx context.Context, req *pb.SendFriendRequestRequest) (*pb.SendFriendRequestResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.SendFriendRequest(ctx, req.UserId, req.TargetUserId)
 	if err != nil {
 		return &pb.SendFriendRequestResponse{
@@ -96,6 +132,10 @@ func (h *FriendsHandler) SendFriendRequest(ctx context.Context, req *pb.SendFrie
 
 // RespondFriendRequest 回应好友请求
 func (h *FriendsHandler) RespondFriendRequest(ctx context.Context, req *pb.RespondFriendRequestRequest) (*pb.RespondFriendRequestResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.RespondFriendRequest(ctx, req.UserId, req.RequestUserId, req.Accepted)
 	if err != nil {
 		return &pb.RespondFriendRequestResponse{
@@ -113,4 +153,322 @@ func (h *FriendsHandler) RespondFriendRequest(ctx context.Context, req *pb.Respo
 		Success: true,
 		Message: "Friend request " + status,
 	}, nil
+}
+
+// ListFriendRequests 获取好友请求列表
+func (h *FriendsHandler) ListFriendRequests(ctx context.Context, req *pb.ListFriendRequestsRequest) (*pb.ListFriendRequestsResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	requests, total, err := h.usecase.ListFriendRequests(ctx, req.UserId, req.Direction, int(req.Page), int(req.PageSize))
+	if err != nil {
+		return &pb.ListFriendRequestsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	userIDs := make([]string, len(requests))
+	for i, request := range requests {
+		userIDs[i] = request.OtherID(req.UserId)
+	}
+	users := h.usecase.GetUsers(ctx, userIDs)
+
+	pbRequests := make([]*pb.FriendRequestInfo, len(requests))
+	for i, request := range requests {
+		pbRequests[i] = &pb.FriendRequestInfo{
+			UserId:    userIDs[i],
+			Username:  "Unknown",
+			CreatedAt: request.CreatedAt.Unix(),
+			ExpiresAt: h.usecase.RequestExpiresAt(request).Unix(),
+		}
+		if user, ok := users[userIDs[i]]; ok {
+			pbRequests[i].Username = user.Username
+		}
+	}
+
+	return &pb.ListFriendRequestsResponse{
+		Success:  true,
+		Message:  "Friend requests retrieved successfully",
+		Requests: pbRequests,
+		Total:    int32(total),
+	}, nil
+}
+
+// CancelFriendRequest 撤回好友请求
+func (h *FriendsHandler) CancelFriendRequest(ctx context.Context, req *pb.CancelFriendRequestRequest) (*pb.CancelFriendRequestResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	err := h.usecase.CancelFriendRequest(ctx, req.UserId, req.TargetUserId)
+	if err != nil {
+		return &pb.CancelFriendRequestResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.CancelFriendRequestResponse{
+		Success: true,
+		Message: "Friend request cancelled",
+	}, nil
+}
+
+// BlockUser 屏蔽用户
+func (h *FriendsHandler) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (*pb.BlockUserResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	err := h.usecase.BlockUser(ctx, req.UserId, req.TargetUserId)
+	if err != nil {
+		return &pb.BlockUserResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.BlockUserResponse{
+		Success: true,
+		Message: "User blocked",
+	}, nil
+}
+
+// UnblockUser 取消屏蔽
+func (h *FriendsHandler) UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (*pb.UnblockUserResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	err := h.usecase.UnblockUser(ctx, req.UserId, req.TargetUserId)
+	if err != nil {
+		return &pb.UnblockUserResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.UnblockUserResponse{
+		Success: true,
+		Message: "User unblocked",
+	}, nil
+}
+
+// ListBlocked 获取屏蔽列表
+func (h *FriendsHandler) ListBlocked(ctx context.Context, req *pb.ListBlockedRequest) (*pb.ListBlockedResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	blocks, err := h.usecase.ListBlocked(ctx, req.UserId)
+	if err != nil {
+		return &pb.ListBlockedResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	userIDs := make([]string, len(blocks))
+	for i, block := range blocks {
+		userIDs[i] = block.BlockedID
+	}
+	users := h.usecase.GetUsers(ctx, userIDs)
+
+	pbUsers := make([]*pb.FriendInfo, len(blocks))
+	for i, userID := range userIDs {
+		pbUsers[i] = &pb.FriendInfo{
+			UserId:   userID,
+			Username: "Unknown",
+			Status:   "blocked",
+		}
+		if user, ok := users[userID]; ok {
+			pbUsers[i].Username = user.Username
+		}
+	}
+
+	return &pb.ListBlockedResponse{
+		Success: true,
+		Message: "Blocked users retrieved successfully",
+		Users:   pbUsers,
+	}, nil
+}
+
+// GetBlockRelations 供房间、匹配等服务查询屏蔽关系。不对用户开放，避免泄露谁屏蔽了自己
+func (h *FriendsHandler) GetBlockRelations(ctx context.Context, req *pb.GetBlockRelationsRequest) (*pb.GetBlockRelationsResponse, error) {
+	if err := auth.RequireInternal(ctx); err != nil {
+		return nil, err
+	}
+
+	blocked, blockedBy, err := h.usecase.GetBlockRelations(ctx, req.UserId)
+	if err != nil {
+		return &pb.GetBlockRelationsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetBlockRelationsResponse{
+		Success:          true,
+		Message:          "Block relations retrieved successfully",
+		BlockedUserIds:   blocked,
+		BlockedByUserIds: blockedBy,
+	}, nil
+}
+
+// WatchFriendPresence 推送好友的在线状态变化
+func (h *FriendsHandler) WatchFriendPresence(req *pb.WatchFriendPresenceRequest, stream pb.FriendsService_WatchFriendPresenceServer) error {
+	if err := auth.Authorize(stream.Context(), req.UserId); err != nil {
+		return err
+	}
+
+	presences, err := h.usecase.WatchFriendPresence(stream.Context(), req.UserId)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	for {
+		presence, err := presences.Recv()
+		if err != nil {
+			if stream.Context().Err() != nil {
+				return nil
+			}
+			return status.Error(codes.Unavailable, "presence stream interrupted")
+		}
+		// 订阅后被删除或屏蔽的好友不再推送
+		if !h.usecase.IsFriend(stream.Context(), req.UserId, presence.UserId) {
+			continue
+		}
+		if err := stream.Send(presence); err != nil {
+			return err
+		}
+	}
+}
+
+// InviteToRoom 邀请好友加入房间
+func (h *FriendsHandler) InviteToRoom(ctx context.Context, req *pb.InviteToRoomRequest) (*pb.InviteToRoomResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	invite, err := h.usecase.InviteToRoom(ctx, req.UserId, req.TargetUserId, req.RoomId)
+	if err != nil {
+		return &pb.InviteToRoomResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.InviteToRoomResponse{
+		Success:  true,
+		Message:  "Invite sent successfully",
+		InviteId: invite.ID,
+	}, nil
+}
+
+// ListInvites 获取收到的房间邀请
+func (h *FriendsHandler) ListInvites(ctx context.Context, req *pb.ListInvitesRequest) (*pb.ListInvitesResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	invites, err := h.usecase.ListInvites(ctx, req.UserId)
+	if err != nil {
+		return &pb.ListInvitesResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ListInvitesResponse{
+		Success: true,
+		Message: "Invites retrieved successfully",
+		Invites: h.toPbInvites(ctx, invites),
+	}, nil
+}
+
+// RespondInvite 回应房间邀请
+func (h *FriendsHandler) RespondInvite(ctx context.Context, req *pb.RespondInviteRequest) (*pb.RespondInviteResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	roomID, err := h.usecase.RespondInvite(ctx, req.UserId, req.InviteId, req.Accepted)
+	if err != nil {
+		return &pb.RespondInviteResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	message := "Invite declined"
+	if req.Accepted {
+		message = "Joined room successfully"
+	}
+	return &pb.RespondInviteResponse{
+		Success: true,
+		Message: message,
+		RoomId:  roomID,
+	}, nil
+}
+
+// WatchInvites 推送收到的房间邀请
+func (h *FriendsHandler) WatchInvites(req *pb.WatchInvitesRequest, stream pb.FriendsService_WatchInvitesServer) error {
+	if err := auth.Authorize(stream.Context(), req.UserId); err != nil {
+		return err
+	}
+
+	pending, invites, cancel, err := h.usecase.WatchInvites(stream.Context(), req.UserId)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer cancel()
+
+	for _, invite := range h.toPbInvites(stream.Context(), pending) {
+		if err := stream.Send(invite); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case invite, ok := <-invites:
+			if !ok {
+				// 订阅者落后过多，客户端重新订阅后会收到全部未过期邀请
+				return status.Error(codes.Unavailable, "invite stream interrupted")
+			}
+			if err := stream.Send(h.toPbInvites(stream.Context(), []*entity.Invite{invite})[0]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toPbInvites 转换邀请实体，附带邀请人的用户名
+func (h *FriendsHandler) toPbInvites(ctx context.Context, invites []*entity.Invite) []*pb.RoomInvite {
+	userIDs := make([]string, len(invites))
+	for i, invite := range invites {
+		userIDs[i] = invite.FromUserID
+	}
+	users := h.usecase.GetUsers(ctx, userIDs)
+
+	pbInvites := make([]*pb.RoomInvite, len(invites))
+	for i, invite := range invites {
+		pbInvites[i] = &pb.RoomInvite{
+			InviteId:     invite.ID,
+			RoomId:       invite.RoomID,
+			RoomName:     invite.RoomName,
+			FromUserId:   invite.FromUserID,
+			FromUsername: "Unknown",
+			CreatedAt:    invite.CreatedAt.Unix(),
+			ExpiresAt:    h.usecase.InviteExpiresAt(invite).Unix(),
+		}
+		if user, ok := users[invite.FromUserID]; ok {
+			pbInvites[i].FromUsername = user.Username
+		}
+	}
+	return pbInvites
 }
\ No newline at end of file
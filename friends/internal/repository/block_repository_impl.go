@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"snake-game/friends/domain/entity"
+	"snake-game/mongodb"
+)
+
+type blockRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewBlockRepository() *blockRepositoryImpl {
+	return &blockRepositoryImpl{
+		collection: mongodb.DB.Collection(mongodb.BlockCollection),
+	}
+}
+
+func (r *blockRepositoryImpl) CreateBlock(ctx context.Context, block *entity.Block) error {
+	block.ID = block.BlockerID + ":" + block.BlockedID
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": block.ID},
+		bson.M{"$setOnInsert": block},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *blockRepositoryImpl) DeleteBlock(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": blockerID + ":" + blockedID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (r *blockRepositoryImpl) GetBlocksBetween(ctx context.Context, userID, otherID string) ([]*entity.Block, error) {
+	return r.find(ctx, bson.M{
+		"_id": bson.M{"$in": []string{userID + ":" + otherID, otherID + ":" + userID}},
+	})
+}
+
+func (r *blockRepositoryImpl) GetBlocksOf(ctx context.Context, userID string) ([]*entity.Block, error) {
+	return r.find(ctx, bson.M{
+		"$or": []bson.M{
+			{"blocker_id": userID},
+			{"blocked_id": userID},
+		},
+	})
+}
+
+func (r *blockRepositoryImpl) find(ctx context.Context, filter bson.M) ([]*entity.Block, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var blocks []*entity.Block
+	if err := cursor.All(ctx, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
@@ -2,10 +2,11 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"snake-game/friends/domain/entity"
 	"snake-game/mongodb"
 )
@@ -26,20 +27,11 @@ func (r *friendRepositoryImpl) CreateFriendship(ctx context.Context, friendship
 }
 
 func (r *friendRepositoryImpl) GetFriendship(ctx context.Context, userID, friendID string) (*entity.Friendship, error) {
-	userObjectID, err := primitive.ObjectIDFromHex(userID)
-	if err != nil {
-		return nil, err
-	}
-	friendObjectID, err := primitive.ObjectIDFromHex(friendID)
-	if err != nil {
-		return nil, err
-	}
-
 	var friendship entity.Friendship
-	err = r.collection.FindOne(ctx, bson.M{
+	err := r.collection.FindOne(ctx, bson.M{
 		"$or": []bson.M{
-			{"user_id": userObjectID, "friend_id": friendObjectID},
-			{"user_id": friendObjectID, "friend_id": userObjectID},
+			{"user_id": userID, "friend_id": friendID},
+			{"user_id": friendID, "friend_id": userID},
 		},
 	}).Decode(&friendship)
 	if err != nil {
@@ -49,39 +41,24 @@ func (r *friendRepositoryImpl) GetFriendship(ctx context.Context, userID, friend
 }
 
 func (r *friendRepositoryImpl) UpdateFriendshipStatus(ctx context.Context, userID, friendID, status string) error {
-	userObjectID, err := primitive.ObjectIDFromHex(userID)
-	if err != nil {
-		return err
-	}
-	friendObjectID, err := primitive.ObjectIDFromHex(friendID)
-	if err != nil {
-		return err
-	}
-
 	update := bson.M{
 		"$set": bson.M{
 			"status": status,
 		},
 	}
-	_, err = r.collection.UpdateOne(ctx, bson.M{
-		"$or": []bson.M{
-			{"user_id": userObjectID, "friend_id": friendObjectID},
-			{"user_id": friendObjectID, "friend_id": userObjectID},
-		},
+	// 只更新 userID 发给 friendID 的记录，避免发送方替对方处理请求
+	_, err := r.collection.UpdateOne(ctx, bson.M{
+		"user_id":   userID,
+		"friend_id": friendID,
 	}, update)
 	return err
 }
 
 func (r *friendRepositoryImpl) GetFriends(ctx context.Context, userID string) ([]*entity.Friendship, error) {
-	userObjectID, err := primitive.ObjectIDFromHex(userID)
-	if err != nil {
-		return nil, err
-	}
-
 	cursor, err := r.collection.Find(ctx, bson.M{
 		"$or": []bson.M{
-			{"user_id": userObjectID, "status": "accepted"},
-			{"friend_id": userObjectID, "status": "accepted"},
+			{"user_id": userID, "status": "accepted"},
+			{"friend_id": userID, "status": "accepted"},
 		},
 	})
 	if err != nil {
@@ -90,50 +67,76 @@ func (r *friendRepositoryImpl) GetFriends(ctx context.Context, userID string) ([
 	defer cursor.Close(ctx)
 
 	var friendships []*entity.Friendship
-	if err = cursor.All(ctx, &friendships); err != nil {
+	if err := cursor.All(ctx, &friendships); err != nil {
 		return nil, err
 	}
 
 	return friendships, nil
 }
 
-func (r *friendRepositoryImpl) GetPendingRequests(ctx context.Context, userID string) ([]*entity.Friendship, error) {
-	userObjectID, err := primitive.ObjectIDFromHex(userID)
+func (r *friendRepositoryImpl) GetPendingRequests(ctx context.Context, userID string, outgoing bool, since time.Time, offset, limit int64) ([]*entity.Friendship, int64, error) {
+	field := "friend_id"
+	if outgoing {
+		field = "user_id"
+	}
+	filter := bson.M{
+		field:        userID,
+		"status":     "pending",
+		"created_at": bson.M{"$gt": since},
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	cursor, err := r.collection.Find(ctx, bson.M{
-		"friend_id": userObjectID,
-		"status":    "pending",
-	})
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	findOptions.SetSkip(offset)
+	findOptions.SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer cursor.Close(ctx)
 
 	var friendships []*entity.Friendship
-	if err = cursor.All(ctx, &friendships); err != nil {
-		return nil, err
+	if err := cursor.All(ctx, &friendships); err != nil {
+		return nil, 0, err
 	}
 
-	return friendships, nil
+	return friendships, total, nil
 }
 
-func (r *friendRepositoryImpl) DeleteFriendship(ctx context.Context, userID, friendID string) error {
-	userObjectID, err := primitive.ObjectIDFromHex(userID)
+func (r *friendRepositoryImpl) DeletePendingRequest(ctx context.Context, userID, friendID string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{
+		"user_id":   userID,
+		"friend_id": friendID,
+		"status":    "pending",
+	})
 	if err != nil {
-		return err
+		return false, err
 	}
-	friendObjectID, err := primitive.ObjectIDFromHex(friendID)
+	return result.DeletedCount > 0, nil
+}
+
+func (r *friendRepositoryImpl) DeleteExpiredRequests(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{
+		"status":     bson.M{"$in": []string{"pending", "rejected"}},
+		"created_at": bson.M{"$lte": before},
+	})
 	if err != nil {
-		return err
+		return 0, err
 	}
+	return result.DeletedCount, nil
+}
 
-	_, err = r.collection.DeleteMany(ctx, bson.M{
+func (r *friendRepositoryImpl) DeleteFriendship(ctx context.Context, userID, friendID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{
 		"$or": []bson.M{
-			{"user_id": userObjectID, "friend_id": friendObjectID},
-			{"user_id": friendObjectID, "friend_id": userObjectID},
+			{"user_id": userID, "friend_id": friendID},
+			{"user_id": friendID, "friend_id": userID},
 		},
 	})
 	return err
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"snake-game/friends/domain/entity"
+	"snake-game/mongodb"
+)
+
+type inviteRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewInviteRepository() *inviteRepositoryImpl {
+	return &inviteRepositoryImpl{
+		collection: mongodb.DB.Collection(mongodb.InviteCollection),
+	}
+}
+
+func (r *inviteRepositoryImpl) SaveInvite(ctx context.Context, invite *entity.Invite, expiredBefore time.Time) (bool, error) {
+	invite.ID = invite.RoomID + ":" + invite.FromUserID + ":" + invite.ToUserID
+	// 只匹配已过期的旧邀请；未过期的邀请存在时 upsert 插入同一 _id 失败，保证并发邀请也只保存一次
+	_, err := r.collection.ReplaceOne(ctx,
+		bson.M{"_id": invite.ID, "created_at": bson.M{"$lte": expiredBefore}},
+		invite,
+		options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *inviteRepositoryImpl) GetInvite(ctx context.Context, inviteID string) (*entity.Invite, error) {
+	var invite entity.Invite
+	err := r.collection.FindOne(ctx, bson.M{"_id": inviteID}).Decode(&invite)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+func (r *inviteRepositoryImpl) DeleteInvite(ctx context.Context, inviteID string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": inviteID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (r *inviteRepositoryImpl) DeleteInvitesBetween(ctx context.Context, userID, otherID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{
+		"$or": []bson.M{
+			{"from_user_id": userID, "to_user_id": otherID},
+			{"from_user_id": otherID, "to_user_id": userID},
+		},
+	})
+	return err
+}
+
+func (r *inviteRepositoryImpl) GetInvitesTo(ctx context.Context, userID string, since time.Time) ([]*entity.Invite, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"to_user_id": userID,
+		"created_at": bson.M{"$gt": since},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var invites []*entity.Invite
+	if err := cursor.All(ctx, &invites); err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+func (r *inviteRepositoryImpl) DeleteExpiredInvites(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{
+		"created_at": bson.M{"$lte": before},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"snake-game/friends/domain/entity"
+)
+
+// BlockUser 屏蔽用户。双方的好友关系、待处理的好友请求和房间邀请一并删除
+func (uc *FriendsUsecase) BlockUser(ctx context.Context, userID, targetUserID string) error {
+	if targetUserID == "" {
+		return errors.New("missing target user id")
+	}
+	if targetUserID == userID {
+		return errors.New("cannot block yourself")
+	}
+
+	err := uc.blockRepo.CreateBlock(ctx, &entity.Block{
+		BlockerID: userID,
+		BlockedID: targetUserID,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Printf("Failed to block %s for %s: %v", targetUserID, userID, err)
+		return errors.New("failed to block user")
+	}
+
+	if err := uc.repo.DeleteFriendship(ctx, userID, targetUserID); err != nil {
+		log.Printf("Failed to remove friendship between %s and %s: %v", userID, targetUserID, err)
+	}
+	if err := uc.inviteRepo.DeleteInvitesBetween(ctx, userID, targetUserID); err != nil {
+		log.Printf("Failed to remove invites between %s and %s: %v", userID, targetUserID, err)
+	}
+	return nil
+}
+
+// UnblockUser 取消屏蔽，不会恢复之前的好友关系
+func (uc *FriendsUsecase) UnblockUser(ctx context.Context, userID, targetUserID string) error {
+	deleted, err := uc.blockRepo.DeleteBlock(ctx, userID, targetUserID)
+	if err != nil {
+		return errors.New("failed to unblock user")
+	}
+	if !deleted {
+		return errors.New("user is not blocked")
+	}
+	return nil
+}
+
+// ListBlocked 返回用户屏蔽的人，最近屏蔽的在前
+func (uc *FriendsUsecase) ListBlocked(ctx context.Context, userID string) ([]*entity.Block, error) {
+	blocks, err := uc.blockRepo.GetBlocksOf(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to list blocked users")
+	}
+
+	blocked := make([]*entity.Block, 0, len(blocks))
+	for _, block := range blocks {
+		if block.BlockerID == userID {
+			blocked = append(blocked, block)
+		}
+	}
+	return blocked, nil
+}
+
+// GetBlockRelations 返回用户屏蔽的人和屏蔽了该用户的人
+func (uc *FriendsUsecase) GetBlockRelations(ctx context.Context, userID string) ([]string, []string, error) {
+	blocks, err := uc.blockRepo.GetBlocksOf(ctx, userID)
+	if err != nil {
+		return nil, nil, errors.New("failed to get block relations")
+	}
+
+	blocked, blockedBy := make([]string, 0), make([]string, 0)
+	for _, block := range blocks {
+		if block.BlockerID == userID {
+			blocked = append(blocked, block.BlockedID)
+		} else {
+			blockedBy = append(blockedBy, block.BlockerID)
+		}
+	}
+	return blocked, blockedBy, nil
+}
+
+// checkNotBlocked 任一方屏蔽了对方时拒绝好友请求。被对方屏蔽时不透露屏蔽关系
+func (uc *FriendsUsecase) checkNotBlocked(ctx context.Context, userID, targetUserID string) error {
+	blocks, err := uc.blockRepo.GetBlocksBetween(ctx, userID, targetUserID)
+	if err != nil {
+		return errors.New("failed to send friend request")
+	}
+	for _, block := range blocks {
+		if block.BlockerID == userID {
+			return errors.New("unblock this user first")
+		}
+	}
+	if len(blocks) > 0 {
+		return errors.New("cannot send friend request to this user")
+	}
+	return nil
+}
@@ -3,46 +3,97 @@ package usecase
 import (
 	"context"
 	"errors"
+	"log"
 	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"snake-game/auth"
 	"snake-game/friends/domain/entity"
 	"snake-game/friends/domain/repository"
+	pb "snake-game/proto"
+)
+
+const (
+	// defaultRequestPageSize 未指定每页条数时返回的好友请求数
+	defaultRequestPageSize = 20
+	maxRequestPageSize     = 100
+	// expiredRequestPurgeInterval 清理过期好友请求和房间邀请的间隔
+	expiredRequestPurgeInterval = time.Hour
 )
 
+// 好友请求列表的方向
+const (
+	RequestsIncoming = "incoming"
+	RequestsOutgoing = "outgoing"
+)
+
+// FriendsConfig 好友服务配置
+type FriendsConfig struct {
+	RequestTTL time.Duration // 好友请求的有效期，过期后无法再回应
+	InviteTTL  time.Duration // 房间邀请的有效期
+}
+
 type FriendsUsecase struct {
-	repo repository.FriendRepository
+	repo        repository.FriendRepository
+	blockRepo   repository.BlockRepository
+	inviteRepo  repository.InviteRepository
+	gameClient  pb.GameServiceClient
+	lobbyClient pb.LobbyServiceClient
+	roomClient  pb.RoomServiceClient
+	invites     *inviteBroadcaster // 房间邀请的实时推送
+	config      FriendsConfig
 }
 
-func NewFriendsUsecase(repo repository.FriendRepository) *FriendsUsecase {
-	return &FriendsUsecase{
-		repo: repo,
+func NewFriendsUsecase(repo repository.FriendRepository, blockRepo repository.BlockRepository, inviteRepo repository.InviteRepository, config FriendsConfig) *FriendsUsecase {
+	// 连接到游戏服务，用于查询好友正在进行的对局
+	conn, err := grpc.Dial("localhost:50055", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
+	if err != nil {
+		log.Printf("Failed to connect to game service: %v", err)
+		return nil
 	}
-}
 
-func (uc *FriendsUsecase) AddFriend(ctx context.Context, userID, friendUsername string) error {
-	// 这里需要通过用户服务查找用户名对应的ID
-	// 为简化，假设我们知道目标用户的ID
-	friendID := friendUsername // 实际应用中需要通过用户名查找ID
+	// 连接到大厅服务，用于查询用户名和在线状态
+	lobbyConn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
+	if err != nil {
+		log.Printf("Failed to connect to lobby service: %v", err)
+		return nil
+	}
 
-	// 检查是否已经是好友
-	friendship, err := uc.repo.GetFriendship(ctx, userID, friendID)
-	if err == nil && friendship != nil {
-		if friendship.Status == "accepted" {
-			return errors.New("already friends")
-		} else if friendship.Status == "pending" {
-			return errors.New("friend request already sent")
-		}
+	// 连接到房间服务，用于校验邀请的房间并代为加入
+	roomConn, err := grpc.Dial("localhost:50053", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
+	if err != nil {
+		log.Printf("Failed to connect to room service: %v", err)
+		return nil
 	}
 
-	// 创建好友请求
-	friendship = &entity.Friendship{
-		UserID:    userID,
-		FriendID:  friendID,
-		Status:    "pending",
-		CreatedAt: time.Now(),
+	uc := &FriendsUsecase{
+		repo:        repo,
+		blockRepo:   blockRepo,
+		inviteRepo:  inviteRepo,
+		gameClient:  pb.NewGameServiceClient(conn),
+		lobbyClient: pb.NewLobbyServiceClient(lobbyConn),
+		roomClient:  pb.NewRoomServiceClient(roomConn),
+		invites:     newInviteBroadcaster(),
+		config:      config,
 	}
+	go uc.purgeExpiredRequests()
+	return uc
+}
 
-	return uc.repo.CreateFriendship(ctx, friendship)
+// AddFriend 按用户名向对方发送好友请求
+func (uc *FriendsUsecase) AddFriend(ctx context.Context, userID, friendUsername string) error {
+	resp, err := uc.lobbyClient.FindUserByUsername(ctx, &pb.FindUserByUsernameRequest{Username: friendUsername})
+	if err != nil {
+		log.Printf("Failed to find user %s: %v", friendUsername, err)
+		return errors.New("failed to find user")
+	}
+	if !resp.Success {
+		return errors.New(resp.Message)
+	}
+
+	return uc.SendFriendRequest(ctx, userID, resp.User.Id)
 }
 
 func (uc *FriendsUsecase) RemoveFriend(ctx context.Context, userID, friendUserID string) error {
@@ -53,9 +104,94 @@ func (uc *FriendsUsecase) GetFriends(ctx context.Context, userID string) ([]*ent
 	return uc.repo.GetFriends(ctx, userID)
 }
 
+// GetUsers 通过大厅服务批量查询用户资料，键为用户ID。
+// 查询失败时返回空结果，不影响好友列表
+func (uc *FriendsUsecase) GetUsers(ctx context.Context, userIDs []string) map[string]*pb.User {
+	users := make(map[string]*pb.User)
+	if len(userIDs) == 0 {
+		return users
+	}
+
+	resp, err := uc.lobbyClient.GetUsersByIds(ctx, &pb.GetUsersByIdsRequest{UserIds: userIDs})
+	if err == nil && !resp.Success {
+		err = errors.New(resp.Message)
+	}
+	if err != nil {
+		log.Printf("Failed to get users of friends: %v", err)
+		return users
+	}
+
+	for _, user := range resp.Users {
+		users[user.Id] = user
+	}
+	return users
+}
+
+// IsFriend 判断两个用户是否是已接受的好友。屏蔽会解除好友关系，因此好友之间不存在屏蔽
+func (uc *FriendsUsecase) IsFriend(ctx context.Context, userID, otherID string) bool {
+	friendship, err := uc.repo.GetFriendship(ctx, userID, otherID)
+	return err == nil && friendship != nil && friendship.Status == "accepted"
+}
+
+// WatchFriendPresence 订阅好友的在线状态，先收到全部好友的当前状态，之后收到状态变化。
+// 订阅期间新增的好友需要重新订阅才能收到；已删除或屏蔽的好友由调用方用 IsFriend 过滤
+func (uc *FriendsUsecase) WatchFriendPresence(ctx context.Context, userID string) (pb.LobbyService_WatchPresenceClient, error) {
+	friendships, err := uc.repo.GetFriends(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to get friends")
+	}
+
+	friendIDs := make([]string, len(friendships))
+	for i, friendship := range friendships {
+		friendIDs[i] = friendship.OtherID(userID)
+	}
+	return uc.lobbyClient.WatchPresence(ctx, &pb.WatchPresenceRequest{UserIds: friendIDs})
+}
+
+// GetPlayingRooms 返回正在对局中的好友及其房间ID，查询失败时返回空结果，不影响好友列表
+func (uc *FriendsUsecase) GetPlayingRooms(ctx context.Context, friendIDs []string) map[string]string {
+	rooms := make(map[string]string)
+	if len(friendIDs) == 0 {
+		return rooms
+	}
+
+	resp, err := uc.gameClient.GetActiveGames(ctx, &pb.GetActiveGamesRequest{PlayerIds: friendIDs})
+	if err == nil && !resp.Success {
+		err = errors.New(resp.Message)
+	}
+	if err != nil {
+		log.Printf("Failed to get active games of friends: %v", err)
+		return rooms
+	}
+
+	for _, game := range resp.Games {
+		rooms[game.PlayerId] = game.RoomId
+	}
+	return rooms
+}
+
 func (uc *FriendsUsecase) SendFriendRequest(ctx context.Context, userID, targetUserID string) error {
+	if targetUserID == userID {
+		return errors.New("cannot add yourself as a friend")
+	}
+	if err := uc.checkNotBlocked(ctx, userID, targetUserID); err != nil {
+		return err
+	}
+
 	// 检查是否已经是好友或已有请求
 	friendship, err := uc.repo.GetFriendship(ctx, userID, targetUserID)
+	if err == nil && friendship != nil && friendship.Status == "rejected" &&
+		friendship.UserID == userID && time.Now().Before(uc.RequestExpiresAt(friendship)) {
+		// 被拒绝的一方要等原请求的有效期过后才能再次发送，避免反复骚扰；拒绝的一方可以随时发起
+		return errors.New("friend request was rejected")
+	}
+	if err == nil && friendship != nil && (uc.isExpired(friendship) || friendship.Status == "rejected") {
+		// 过期或被拒绝的请求视为不存在，删除后重新发起，避免两人之间留下多条记录
+		if err := uc.repo.DeleteFriendship(ctx, userID, targetUserID); err != nil {
+			return errors.New("failed to send friend request")
+		}
+		friendship = nil
+	}
 	if err == nil && friendship != nil {
 		if friendship.Status == "accepted" {
 			return errors.New("already friends")
@@ -77,13 +213,17 @@ func (uc *FriendsUsecase) SendFriendRequest(ctx context.Context, userID, targetU
 
 func (uc *FriendsUsecase) RespondFriendRequest(ctx context.Context, userID, requestUserID string, accepted bool) error {
 	friendship, err := uc.repo.GetFriendship(ctx, requestUserID, userID)
-	if err != nil || friendship == nil {
+	// GetFriendship 不区分方向，只有对方发给用户的请求才能由用户处理
+	if err != nil || friendship == nil || friendship.UserID != requestUserID || friendship.FriendID != userID {
 		return errors.New("friend request not found")
 	}
 
 	if friendship.Status != "pending" {
 		return errors.New("friend request already processed")
 	}
+	if uc.isExpired(friendship) {
+		return errors.New("friend request expired")
+	}
 
 	status := "rejected"
 	if accepted {
@@ -91,4 +231,75 @@ func (uc *FriendsUsecase) RespondFriendRequest(ctx context.Context, userID, requ
 	}
 
 	return uc.repo.UpdateFriendshipStatus(ctx, requestUserID, userID, status)
+}
+
+// ListFriendRequests 分页获取用户收到或发出的未过期好友请求，page 从 1 开始
+func (uc *FriendsUsecase) ListFriendRequests(ctx context.Context, userID, direction string, page, pageSize int) ([]*entity.Friendship, int64, error) {
+	if direction == "" {
+		direction = RequestsIncoming
+	}
+	if direction != RequestsIncoming && direction != RequestsOutgoing {
+		return nil, 0, errors.New("invalid direction")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultRequestPageSize
+	}
+	if pageSize > maxRequestPageSize {
+		pageSize = maxRequestPageSize
+	}
+
+	requests, total, err := uc.repo.GetPendingRequests(ctx, userID, direction == RequestsOutgoing,
+		time.Now().Add(-uc.config.RequestTTL), int64((page-1)*pageSize), int64(pageSize))
+	if err != nil {
+		log.Printf("Failed to list friend requests of %s: %v", userID, err)
+		return nil, 0, errors.New("failed to list friend requests")
+	}
+	return requests, total, nil
+}
+
+// CancelFriendRequest 撤回用户发出的待处理好友请求
+func (uc *FriendsUsecase) CancelFriendRequest(ctx context.Context, userID, targetUserID string) error {
+	deleted, err := uc.repo.DeletePendingRequest(ctx, userID, targetUserID)
+	if err != nil {
+		return errors.New("failed to cancel friend request")
+	}
+	if !deleted {
+		return errors.New("friend request not found")
+	}
+	return nil
+}
+
+// RequestExpiresAt 好友请求的过期时间
+func (uc *FriendsUsecase) RequestExpiresAt(friendship *entity.Friendship) time.Time {
+	return friendship.CreatedAt.Add(uc.config.RequestTTL)
+}
+
+// isExpired 待处理的请求超过有效期后失效
+func (uc *FriendsUsecase) isExpired(friendship *entity.Friendship) bool {
+	return friendship.Status == "pending" && !time.Now().Before(uc.RequestExpiresAt(friendship))
+}
+
+// purgeExpiredRequests 定期删除过期或已过冷却期的被拒绝好友请求，以及过期的房间邀请。查询时已经过滤掉过期记录，这里只回收存储
+func (uc *FriendsUsecase) purgeExpiredRequests() {
+	ticker := time.NewTicker(expiredRequestPurgeInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := uc.repo.DeleteExpiredRequests(context.Background(), time.Now().Add(-uc.config.RequestTTL))
+		if err != nil {
+			log.Printf("Failed to purge expired friend requests: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Purged %d expired friend requests", deleted)
+		}
+
+		deleted, err = uc.inviteRepo.DeleteExpiredInvites(context.Background(), time.Now().Add(-uc.config.InviteTTL))
+		if err != nil {
+			log.Printf("Failed to purge expired invites: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Purged %d expired invites", deleted)
+		}
+	}
 }
\ No newline at end of file
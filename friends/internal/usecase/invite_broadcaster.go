@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"sync"
+
+	"snake-game/friends/domain/entity"
+)
+
+// 每个订阅者的邀请缓冲区大小
+const inviteBufferSize = 16
+
+// inviteSubscriber 接收某个用户新邀请的订阅者
+type inviteSubscriber struct {
+	invites chan *entity.Invite
+	userID  string
+}
+
+// inviteBroadcaster 按被邀请人向订阅者扇出新邀请，发布不会被慢消费者阻塞
+type inviteBroadcaster struct {
+	users map[string]map[*inviteSubscriber]struct{}
+	mutex sync.Mutex
+}
+
+func newInviteBroadcaster() *inviteBroadcaster {
+	return &inviteBroadcaster{
+		users: make(map[string]map[*inviteSubscriber]struct{}),
+	}
+}
+
+// subscribe 注册订阅者
+func (b *inviteBroadcaster) subscribe(userID string) *inviteSubscriber {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	sub := &inviteSubscriber{
+		invites: make(chan *entity.Invite, inviteBufferSize),
+		userID:  userID,
+	}
+	if b.users[userID] == nil {
+		b.users[userID] = make(map[*inviteSubscriber]struct{})
+	}
+	b.users[userID][sub] = struct{}{}
+	return sub
+}
+
+// unsubscribe 移除订阅者并关闭其通道，可重复调用
+func (b *inviteBroadcaster) unsubscribe(sub *inviteSubscriber) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.removeLocked(sub)
+}
+
+// publish 投递新邀请；订阅者缓冲区已满说明其已严重落后，直接断开让其重新订阅
+func (b *inviteBroadcaster) publish(invite *entity.Invite) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for sub := range b.users[invite.ToUserID] {
+		copied := *invite
+		select {
+		case sub.invites <- &copied:
+		default:
+			b.removeLocked(sub)
+		}
+	}
+}
+
+func (b *inviteBroadcaster) removeLocked(sub *inviteSubscriber) {
+	subs, exists := b.users[sub.userID]
+	if !exists {
+		return
+	}
+	if _, exists := subs[sub]; !exists {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.users, sub.userID)
+	}
+	close(sub.invites)
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"snake-game/friends/domain/entity"
+	pb "snake-game/proto"
+)
+
+// InviteToRoom 邀请好友加入用户所在的房间，房间必须处于等待状态且未满。
+// 同一邀请未过期时直接返回已有的邀请，不会再次通知对方
+func (uc *FriendsUsecase) InviteToRoom(ctx context.Context, userID, targetUserID, roomID string) (*entity.Invite, error) {
+	if targetUserID == "" {
+		return nil, errors.New("missing target user id")
+	}
+	if roomID == "" {
+		return nil, errors.New("missing room id")
+	}
+	if targetUserID == userID {
+		return nil, errors.New("cannot invite yourself")
+	}
+
+	// 屏蔽会解除好友关系，因此只需确认双方是好友
+	friendship, err := uc.repo.GetFriendship(ctx, userID, targetUserID)
+	if err != nil || friendship == nil || friendship.Status != "accepted" {
+		return nil, errors.New("can only invite friends")
+	}
+
+	resp, err := uc.roomClient.GetRoom(ctx, &pb.GetRoomRequest{RoomId: roomID})
+	if err != nil {
+		log.Printf("Failed to get room %s: %v", roomID, err)
+		return nil, errors.New("failed to get room")
+	}
+	if !resp.Success {
+		return nil, errors.New(resp.Message)
+	}
+
+	room := resp.Room
+	if !containsUser(room.Players, userID) {
+		return nil, errors.New("you are not in this room")
+	}
+	if containsUser(room.Players, targetUserID) {
+		return nil, errors.New("user already in room")
+	}
+	if room.Status != "waiting" {
+		return nil, errors.New("room is not waiting for players")
+	}
+	if len(room.Players) >= int(room.MaxPlayers) {
+		return nil, errors.New("room is full")
+	}
+
+	invite := &entity.Invite{
+		RoomID:     room.RoomId,
+		RoomName:   room.Name,
+		FromUserID: userID,
+		ToUserID:   targetUserID,
+		CreatedAt:  time.Now(),
+	}
+	saved, err := uc.inviteRepo.SaveInvite(ctx, invite, time.Now().Add(-uc.config.InviteTTL))
+	if err != nil {
+		log.Printf("Failed to save invite from %s to %s: %v", userID, targetUserID, err)
+		return nil, errors.New("failed to send invite")
+	}
+	if !saved {
+		existing, err := uc.inviteRepo.GetInvite(ctx, invite.ID)
+		if err != nil {
+			return nil, errors.New("invite already sent")
+		}
+		return existing, nil
+	}
+
+	uc.invites.publish(invite)
+	return invite, nil
+}
+
+// ListInvites 返回用户收到的未过期邀请，最新的在前
+func (uc *FriendsUsecase) ListInvites(ctx context.Context, userID string) ([]*entity.Invite, error) {
+	invites, err := uc.inviteRepo.GetInvitesTo(ctx, userID, time.Now().Add(-uc.config.InviteTTL))
+	if err != nil {
+		log.Printf("Failed to list invites of %s: %v", userID, err)
+		return nil, errors.New("failed to list invites")
+	}
+	return invites, nil
+}
+
+// RespondInvite 回应邀请，接受时代为加入房间并返回房间ID。
+// 无论结果如何邀请都会被删除：房间已满、已开始或已解散时邀请也随之失效
+func (uc *FriendsUsecase) RespondInvite(ctx context.Context, userID, inviteID string, accepted bool) (string, error) {
+	invite, err := uc.inviteRepo.GetInvite(ctx, inviteID)
+	if err != nil || invite == nil || invite.ToUserID != userID {
+		return "", errors.New("invite not found")
+	}
+
+	if uc.isInviteExpired(invite) || !accepted {
+		if _, err := uc.inviteRepo.DeleteInvite(ctx, inviteID); err != nil {
+			log.Printf("Failed to delete invite %s: %v", inviteID, err)
+			return "", errors.New("failed to respond to invite")
+		}
+		if accepted {
+			return "", errors.New("invite expired")
+		}
+		return "", nil
+	}
+
+	// 内部调用不代表具体用户，房间服务允许代为加入
+	resp, err := uc.roomClient.JoinRoom(ctx, &pb.JoinRoomRequest{
+		RoomId: invite.RoomID,
+		UserId: userID,
+	})
+	if err != nil {
+		log.Printf("Failed to join room %s for %s: %v", invite.RoomID, userID, err)
+		return "", errors.New("failed to join room")
+	}
+
+	if _, err := uc.inviteRepo.DeleteInvite(ctx, inviteID); err != nil {
+		log.Printf("Failed to delete invite %s: %v", inviteID, err)
+	}
+	if !resp.Success {
+		return "", errors.New(resp.Message)
+	}
+	return invite.RoomID, nil
+}
+
+// WatchInvites 订阅用户收到的邀请，返回订阅时未过期的邀请和之后的新邀请。
+// 先订阅再查询，两者可能重复，客户端按邀请ID去重。返回的取消函数必须在订阅方退出时调用
+func (uc *FriendsUsecase) WatchInvites(ctx context.Context, userID string) ([]*entity.Invite, <-chan *entity.Invite, func(), error) {
+	sub := uc.invites.subscribe(userID)
+	cancel := func() {
+		uc.invites.unsubscribe(sub)
+	}
+
+	pending, err := uc.ListInvites(ctx, userID)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, err
+	}
+	return pending, sub.invites, cancel, nil
+}
+
+// InviteExpiresAt 邀请的过期时间
+func (uc *FriendsUsecase) InviteExpiresAt(invite *entity.Invite) time.Time {
+	return invite.CreatedAt.Add(uc.config.InviteTTL)
+}
+
+// isInviteExpired 邀请超过有效期后失效
+func (uc *FriendsUsecase) isInviteExpired(invite *entity.Invite) bool {
+	return !time.Now().Before(uc.InviteExpiresAt(invite))
+}
+
+func containsUser(userIDs []string, userID string) bool {
+	for _, id := range userIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -4,11 +4,15 @@ import (
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
+	"snake-game/auth"
 	grpc_handler "snake-game/friends/internal/delivery/grpc"
 	"snake-game/friends/internal/repository"
 	"snake-game/friends/internal/usecase"
+	"snake-game/healthcheck"
 	"snake-game/mongodb"
 	pb "snake-game/proto"
 )
@@ -29,9 +33,34 @@ func main() {
 
 	// 初始化仓库层
 	friendRepo := repository.NewFriendRepository()
+	blockRepo := repository.NewBlockRepository()
+	inviteRepo := repository.NewInviteRepository()
+
+	// 好友请求有效期（小时）
+	requestTTL := 7 * 24 * time.Hour
+	if value := os.Getenv("FRIEND_REQUEST_TTL_HOURS"); value != "" {
+		hours, err := strconv.Atoi(value)
+		if err != nil || hours <= 0 {
+			log.Fatalf("Invalid FRIEND_REQUEST_TTL_HOURS: %s", value)
+		}
+		requestTTL = time.Duration(hours) * time.Hour
+	}
+
+	// 房间邀请有效期（分钟）
+	inviteTTL := 10 * time.Minute
+	if value := os.Getenv("ROOM_INVITE_TTL_MINUTES"); value != "" {
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes <= 0 {
+			log.Fatalf("Invalid ROOM_INVITE_TTL_MINUTES: %s", value)
+		}
+		inviteTTL = time.Duration(minutes) * time.Minute
+	}
 
 	// 初始化业务逻辑层
-	friendsUsecase := usecase.NewFriendsUsecase(friendRepo)
+	friendsUsecase := usecase.NewFriendsUsecase(friendRepo, blockRepo, inviteRepo, usecase.FriendsConfig{
+		RequestTTL: requestTTL,
+		InviteTTL:  inviteTTL,
+	})
 
 	// 初始化通信层
 	friendsHandler := grpc_handler.NewFriendsHandler(friendsUsecase)
@@ -42,9 +71,13 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	// 识别调用方，handler 据此校验操作权限
+	s := grpc.NewServer(auth.ServerOptions(auth.NewLobbyValidator())...)
 	pb.RegisterFriendsServiceServer(s, friendsHandler)
 
+	// 标准健康检查，网关据此判断服务是否可用
+	healthcheck.Register(s, "friends_service.FriendsService", mongodb.Ping)
+
 	log.Println("Friends service is running on :50056")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
@@ -1,5 +1,6 @@
 import React, { useEffect, useState } from 'react';
 import { gameService } from '../utils/api';
+import { RoomSocket } from '../utils/socket';
 
 interface Position {
   x: number;
@@ -14,35 +15,129 @@ interface GameSnake {
   score: number;
 }
 
-interface GameState {
+export interface GameState {
   snakes: GameSnake[];
   foods: Position[];
   walls: Position[];
   status: string;
+  boardWidth: number;
+  boardHeight: number;
+  spectatorCount: number;
 }
 
+// 服务端未返回棋盘尺寸时的默认值
+const DEFAULT_BOARD_SIZE = 20;
+
 interface GameBoardProps {
   roomId: string;
   playerId: string;
+  socket: RoomSocket;
   onGameOver: (winnerId: string) => void;
 }
 
-const GameBoard: React.FC<GameBoardProps> = ({ roomId, playerId, onGameOver }) => {
+// 零值字段在 JSON 中会被省略，统一补全坐标
+const normalizePosition = (p?: Partial<Position>): Position => ({ x: p?.x || 0, y: p?.y || 0 });
+
+export const normalizeState = (data: any): GameState => ({
+  snakes: (data.snakes || []).map((snake: any) => ({
+    ...snake,
+    score: snake.score || 0,
+    segments: (snake.segments || []).map((segment: any) => ({ position: normalizePosition(segment.position) })),
+  })),
+  foods: (data.foods || []).map(normalizePosition),
+  walls: (data.walls || []).map(normalizePosition),
+  status: data.status || '',
+  boardWidth: data.options?.boardWidth || DEFAULT_BOARD_SIZE,
+  boardHeight: data.options?.boardHeight || DEFAULT_BOARD_SIZE,
+  spectatorCount: data.spectatorCount || 0,
+});
+
+// 棋盘网格，玩家与观战共用
+export const BoardGrid: React.FC<{ gameState: GameState | null }> = ({ gameState }) => {
+  // 渲染游戏单元格
+  const renderCell = (x: number, y: number) => {
+    if (!gameState) return <div className="cell" key={`${x}-${y}`} />;
+
+    // 检查是否是墙
+    const isWall = gameState.walls.some(wall => wall.x === x && wall.y === y);
+    if (isWall) {
+      return <div className="cell wall" key={`${x}-${y}`} />;
+    }
+
+    // 检查是否是食物
+    const isFood = gameState.foods.some(food => food.x === x && food.y === y);
+    if (isFood) {
+      return <div className="cell food" key={`${x}-${y}`} />;
+    }
+
+    // 检查是否是蛇的身体
+    let snakeColor = '';
+    for (const snake of gameState.snakes) {
+      for (const segment of snake.segments) {
+        if (segment.position.x === x && segment.position.y === y) {
+          snakeColor = snake.color;
+          break;
+        }
+      }
+      if (snakeColor) break;
+    }
+
+    if (snakeColor) {
+      return <div className={`cell snake`} style={{ backgroundColor: snakeColor }} key={`${x}-${y}`} />;
+    }
+
+    return <div className="cell" key={`${x}-${y}`} />;
+  };
+
+  // 创建游戏板
+  const createGameBoard = () => {
+    const board = [];
+    const width = gameState?.boardWidth || DEFAULT_BOARD_SIZE;
+    const height = gameState?.boardHeight || DEFAULT_BOARD_SIZE;
+    for (let y = 0; y < height; y++) {
+      for (let x = 0; x < width; x++) {
+        board.push(renderCell(x, y));
+      }
+    }
+    return board;
+  };
+
+  return (
+    <div
+      className="game-board"
+      style={{
+        gridTemplateColumns: `repeat(${gameState?.boardWidth || DEFAULT_BOARD_SIZE}, 20px)`,
+        gridTemplateRows: `repeat(${gameState?.boardHeight || DEFAULT_BOARD_SIZE}, 20px)`,
+      }}
+    >
+      {createGameBoard()}
+    </div>
+  );
+};
+
+const GameBoard: React.FC<GameBoardProps> = ({ roomId, playerId, socket, onGameOver }) => {
   const [gameState, setGameState] = useState<GameState | null>(null);
   const [direction, setDirection] = useState<string>('RIGHT');
   const [connected, setConnected] = useState(false);
 
-  // 初始化游戏
+  // 初始化游戏，状态更新由服务器通过 WebSocket 推送
   useEffect(() => {
+    const unsubscribe = socket.on((frame) => {
+      if (frame.type !== 'game_update' || frame.roomId !== roomId) return;
+
+      setGameState(normalizeState(frame.data));
+      if (frame.data.type === 'game_over') {
+        onGameOver(frame.data.winnerPlayerId || '');
+      }
+    });
+
     const initializeGame = async () => {
       try {
         const response = await gameService.joinGame(roomId, playerId);
         if (response.success) {
-          setGameState(response);
           setConnected(true);
-          
-          // 开始监听游戏状态更新
-          startGameLoop();
+          // 重新订阅以立即收到包含自己的完整状态
+          socket.resubscribe();
         } else {
           console.error('Failed to join game:', response.message);
         }
@@ -55,30 +150,11 @@ const GameBoard: React.FC<GameBoardProps> = ({ roomId, playerId, onGameOver }) =
 
     // 清理函数
     return () => {
+      unsubscribe();
       // 离开游戏
       gameService.leaveGame(roomId, playerId).catch(console.error);
     };
-  }, [roomId, playerId]);
-
-  // 游戏循环
-  const startGameLoop = () => {
-    const gameLoop = setInterval(async () => {
-      try {
-        const response = await gameService.getGameState(roomId);
-        if (response.success) {
-          setGameState(response);
-          
-          // 检查游戏是否结束
-          if (response.status === 'finished') {
-            clearInterval(gameLoop);
-            onGameOver(response.winner_player_id || '');
-          }
-        }
-      } catch (error) {
-        console.error('Error getting game state:', error);
-      }
-    }, 100); // 每100ms更新一次
-  };
+  }, [roomId, playerId, socket]);
 
   // 处理键盘事件
   useEffect(() => {
@@ -103,60 +179,14 @@ const GameBoard: React.FC<GameBoardProps> = ({ roomId, playerId, onGameOver }) =
 
       if (newDirection !== direction) {
         setDirection(newDirection);
-        // 发送移动指令到服务器
-        gameService.move(roomId, playerId, newDirection).catch(console.error);
+        // 发送方向输入，服务器在下一帧结算
+        socket.move(newDirection);
       }
     };
 
     window.addEventListener('keydown', handleKeyDown);
     return () => window.removeEventListener('keydown', handleKeyDown);
-  }, [direction, connected, roomId, playerId]);
-
-  // 渲染游戏单元格
-  const renderCell = (x: number, y: number) => {
-    if (!gameState) return <div className="cell" key={`${x}-${y}`} />;
-
-    // 检查是否是墙
-    const isWall = gameState.walls.some(wall => wall.x === x && wall.y === y);
-    if (isWall) {
-      return <div className="cell wall" key={`${x}-${y}`} />;
-    }
-
-    // 检查是否是食物
-    const isFood = gameState.foods.some(food => food.x === x && food.y === y);
-    if (isFood) {
-      return <div className="cell food" key={`${x}-${y}`} />;
-    }
-
-    // 检查是否是蛇的身体
-    let snakeColor = '';
-    for (const snake of gameState.snakes) {
-      for (const segment of snake.segments) {
-        if (segment.position.x === x && segment.position.y === y) {
-          snakeColor = snake.color;
-          break;
-        }
-      }
-      if (snakeColor) break;
-    }
-
-    if (snakeColor) {
-      return <div className={`cell snake`} style={{ backgroundColor: snakeColor }} key={`${x}-${y}`} />;
-    }
-
-    return <div className="cell" key={`${x}-${y}`} />;
-  };
-
-  // 创建游戏板
-  const createGameBoard = () => {
-    const board = [];
-    for (let y = 0; y < 30; y++) {
-      for (let x = 0; x < 30; x++) {
-        board.push(renderCell(x, y));
-      }
-    }
-    return board;
-  };
+  }, [direction, connected, socket]);
 
   return (
     <div className="game-container">
@@ -169,13 +199,12 @@ const GameBoard: React.FC<GameBoardProps> = ({ roomId, playerId, onGameOver }) =
             <p>你的分数: {
               gameState.snakes.find(s => s.playerId === playerId)?.score || 0
             }</p>
+            <p>观战人数: {gameState.spectatorCount}</p>
           </div>
         )}
       </div>
       
-      <div className="game-board">
-        {createGameBoard()}
-      </div>
+      <BoardGrid gameState={gameState} />
       
       <div className="controls">
         <p>使用方向键控制蛇的移动</p>
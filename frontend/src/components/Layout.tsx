@@ -1,11 +1,34 @@
-import React from 'react';
+import React, { useEffect } from 'react';
 import Header from './Header';
+import { lobbyService } from '../utils/api';
+
+// 心跳间隔，需小于服务端的在线状态过期时间（60 秒）
+const HEARTBEAT_INTERVAL = 20000;
 
 interface LayoutProps {
   children: React.ReactNode;
 }
 
 const Layout: React.FC<LayoutProps> = ({ children }) => {
+  // 登录后定时发送心跳，页面切到后台时上报为空闲
+  useEffect(() => {
+    const sendHeartbeat = () => {
+      const userId = localStorage.getItem('userId');
+      if (!userId) return;
+      lobbyService.heartbeat(userId, document.hidden).catch((error) => {
+        console.error('Error sending heartbeat:', error);
+      });
+    };
+
+    sendHeartbeat();
+    const timer = setInterval(sendHeartbeat, HEARTBEAT_INTERVAL);
+    document.addEventListener('visibilitychange', sendHeartbeat);
+    return () => {
+      clearInterval(timer);
+      document.removeEventListener('visibilitychange', sendHeartbeat);
+    };
+  }, []);
+
   return (
     <div style={{ minHeight: '100vh', display: 'flex', flexDirection: 'column' }}>
       <Header />
@@ -0,0 +1,134 @@
+import React, { useState, useEffect } from 'react';
+import { useRouter } from 'next/router';
+import { gameService } from '../utils/api';
+import Head from 'next/head';
+
+const PAGE_SIZE = 20;
+
+const History = () => {
+  const [userId, setUserId] = useState<string>('');
+  const [matches, setMatches] = useState<any[]>([]);
+  const [total, setTotal] = useState(0);
+  const [page, setPage] = useState(1);
+  const [loading, setLoading] = useState(true);
+  const router = useRouter();
+
+  useEffect(() => {
+    const storedUserId = localStorage.getItem('userId');
+    if (!storedUserId) {
+      router.push('/login');
+      return;
+    }
+    setUserId(storedUserId);
+  }, []);
+
+  useEffect(() => {
+    if (userId) {
+      loadHistory(userId, page);
+    }
+  }, [userId, page]);
+
+  const loadHistory = async (id: string, pageNumber: number) => {
+    try {
+      setLoading(true);
+      const response = await gameService.getMatchHistory(id, pageNumber, PAGE_SIZE);
+      if (response.success) {
+        setMatches(response.matches || []);
+        setTotal(response.total || 0);
+      }
+    } catch (error) {
+      console.error('Error loading match history:', error);
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const formatDuration = (ms: number) => {
+    const seconds = Math.round((ms || 0) / 1000);
+    return `${Math.floor(seconds / 60)}分${seconds % 60}秒`;
+  };
+
+  const totalPages = Math.max(1, Math.ceil(total / PAGE_SIZE));
+
+  return (
+    <div style={{ padding: '20px', maxWidth: '800px', margin: '0 auto' }}>
+      <Head>
+        <title>历史对局 - 贪吃蛇游戏</title>
+      </Head>
+
+      <header style={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center', marginBottom: '30px' }}>
+        <h1>📜 历史对局</h1>
+        <button className="btn" onClick={() => router.push('/')}>返回首页</button>
+      </header>
+
+      {loading ? (
+        <div style={{ textAlign: 'center', padding: '40px' }}>
+          <p>加载中...</p>
+        </div>
+      ) : (
+        <div>
+          <table style={{ width: '100%', borderCollapse: 'collapse', backgroundColor: '#1a1a1a', borderRadius: '8px', overflow: 'hidden' }}>
+            <thead>
+              <tr style={{ backgroundColor: '#222' }}>
+                <th style={{ padding: '12px', textAlign: 'left', borderBottom: '1px solid #333' }}>时间</th>
+                <th style={{ padding: '12px', textAlign: 'left', borderBottom: '1px solid #333' }}>名次</th>
+                <th style={{ padding: '12px', textAlign: 'left', borderBottom: '1px solid #333' }}>得分</th>
+                <th style={{ padding: '12px', textAlign: 'left', borderBottom: '1px solid #333' }}>人数</th>
+                <th style={{ padding: '12px', textAlign: 'left', borderBottom: '1px solid #333' }}>时长</th>
+              </tr>
+            </thead>
+            <tbody>
+              {matches.length > 0 ? (
+                matches.map((match) => {
+                  const players = match.players || [];
+                  const self = players.find((player: any) => player.playerId === userId) || {};
+                  return (
+                    <tr key={match.matchId}>
+                      <td style={{ padding: '12px', borderBottom: '1px solid #333' }}>
+                        {new Date(Number(match.endedAt) * 1000).toLocaleString()}
+                      </td>
+                      <td style={{ padding: '12px', borderBottom: '1px solid #333' }}>
+                        {match.winnerPlayerId === userId ? '🏆 ' : ''}{self.placement || '-'}
+                      </td>
+                      <td style={{ padding: '12px', borderBottom: '1px solid #333' }}>{self.score || 0}</td>
+                      <td style={{ padding: '12px', borderBottom: '1px solid #333' }}>{players.length}</td>
+                      <td style={{ padding: '12px', borderBottom: '1px solid #333' }}>{formatDuration(Number(match.durationMs))}</td>
+                    </tr>
+                  );
+                })
+              ) : (
+                <tr>
+                  <td colSpan={5} style={{ padding: '20px', textAlign: 'center', color: '#777' }}>
+                    暂无对局记录
+                  </td>
+                </tr>
+              )}
+            </tbody>
+          </table>
+
+          <div style={{ marginTop: '30px', textAlign: 'center' }}>
+            <button
+              className="btn"
+              onClick={() => setPage(page - 1)}
+              disabled={page <= 1}
+              style={{ marginRight: '10px' }}
+            >
+              上一页
+            </button>
+            <span>{page} / {totalPages}</span>
+            <button
+              className="btn"
+              onClick={() => setPage(page + 1)}
+              disabled={page >= totalPages}
+              style={{ marginLeft: '10px' }}
+            >
+              下一页
+            </button>
+          </div>
+        </div>
+      )}
+    </div>
+  );
+};
+
+export default History;
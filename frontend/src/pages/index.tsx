@@ -1,22 +1,46 @@
-import React, { useState, useEffect } from 'react';
+import React, { useState, useEffect, useRef } from 'react';
 import { useRouter } from 'next/router';
 import { 
   matchingService, 
   leaderboardService, 
   friendsService, 
-  lobbyService 
+  lobbyService,
+  clearTokens
 } from '../utils/api';
 import Head from 'next/head';
 import Header from '../components/Header';
 
+// 在线状态的显示文字
+const presenceLabel = (status: string) => {
+  switch (status) {
+    case 'idle':
+      return '离开';
+    case 'in_queue':
+      return '匹配中';
+    case 'in_room':
+      return '房间中';
+    case 'in_game':
+      return '游戏中';
+    case 'offline':
+      return '离线';
+    default:
+      return '在线';
+  }
+};
+
 const Home = () => {
   const [user, setUser] = useState<any>(null);
   const [onlinePlayers, setOnlinePlayers] = useState<any[]>([]);
   const [waitingPlayers, setWaitingPlayers] = useState<number>(0);
   const [leaderboard, setLeaderboard] = useState<any[]>([]);
   const [friends, setFriends] = useState<any[]>([]);
+  const [invites, setInvites] = useState<any[]>([]);
   const [searchUsername, setSearchUsername] = useState('');
   const [matchingStatus, setMatchingStatus] = useState<string>('');
+  const [matching, setMatching] = useState(false);
+  const matchSourceRef = useRef<EventSource | null>(null);
+  const presenceSourceRef = useRef<EventSource | null>(null);
+  const inviteSourceRef = useRef<EventSource | null>(null);
   const router = useRouter();
 
   // 检查用户登录状态
@@ -35,8 +59,19 @@ const Home = () => {
     loadUserData(userId);
     loadLeaderboard();
     loadFriends(userId);
+    watchFriendPresence();
+    watchInvites();
   }, []);
 
+  // 离开页面时关闭匹配事件流（排队会在一段时间后自动过期）
+  useEffect(() => () => matchSourceRef.current?.close(), []);
+
+  // 离开页面时关闭好友状态事件流
+  useEffect(() => () => presenceSourceRef.current?.close(), []);
+
+  // 离开页面时关闭房间邀请事件流
+  useEffect(() => () => inviteSourceRef.current?.close(), []);
+
   // 加载用户数据
   const loadUserData = async (userId: string) => {
     try {
@@ -53,7 +88,13 @@ const Home = () => {
       // 获取用户资料
       const profileResp = await lobbyService.getUserProfile(userId);
       if (profileResp.success) {
-        setUser((prev: any) => ({ ...prev, ...profileResp.user, score: profileResp.score }));
+        setUser((prev: any) => ({ ...prev, ...profileResp.user, score: profileResp.score, gamesWon: profileResp.gamesWon }));
+      }
+
+      // 获取对战评分
+      const ratingResp = await leaderboardService.getRating(userId);
+      if (ratingResp.success) {
+        setUser((prev: any) => ({ ...prev, rating: ratingResp.rating, gamesPlayed: ratingResp.gamesPlayed }));
       }
     } catch (error) {
       console.error('Error loading user data:', error);
@@ -84,31 +125,108 @@ const Home = () => {
     }
   };
 
-  // 开始匹配
-  const startMatching = async () => {
+  // 订阅好友在线状态，收到变化时更新好友列表
+  const watchFriendPresence = () => {
+    const source = friendsService.watchPresence();
+    presenceSourceRef.current = source;
+
+    source.addEventListener('presence', (e) => {
+      const presence = JSON.parse((e as MessageEvent).data);
+      setFriends((prev) =>
+        prev.map((friend) =>
+          friend.userId === presence.userId
+            ? {
+                ...friend,
+                presence,
+                online: presence.status !== 'offline',
+                playingRoomId: presence.status === 'in_game' ? presence.roomId : '',
+              }
+            : friend
+        )
+      );
+    });
+  };
+
+  // 订阅房间邀请，连接时会先收到未过期的邀请，按邀请ID去重
+  const watchInvites = () => {
+    const source = friendsService.watchInvites();
+    inviteSourceRef.current = source;
+
+    source.addEventListener('invite', (e) => {
+      const invite = JSON.parse((e as MessageEvent).data);
+      setInvites((prev) => [invite, ...prev.filter((item) => item.inviteId !== invite.inviteId)]);
+    });
+  };
+
+  // 回应房间邀请，接受后进入房间
+  const respondInvite = async (inviteId: string, accepted: boolean) => {
     if (!user) return;
 
+    setInvites((prev) => prev.filter((item) => item.inviteId !== inviteId));
     try {
-      setMatchingStatus('正在寻找对手...');
-      const response = await matchingService.findMatch(
-        user.id, 
-        user.username, 
-        user.score || 0
-      );
-      
-      if (response.success) {
-        if (response.room_id) {
-          // 匹配成功，跳转到游戏房间
-          router.push(`/room/${response.room_id}`);
-        } else {
-          setMatchingStatus(response.message);
-        }
-      } else {
-        setMatchingStatus(response.message || '匹配失败');
+      const response = await friendsService.respondInvite(user.id, inviteId, accepted);
+      if (!response.success) {
+        alert(response.message || '回应邀请失败');
+      } else if (accepted) {
+        router.push(`/room/${response.roomId}`);
       }
     } catch (error) {
+      console.error('Error responding to invite:', error);
+      alert('回应邀请失败');
+    }
+  };
+
+  // 开始匹配：通过事件流接收排队进度和匹配结果
+  const startMatching = () => {
+    if (!user || matchSourceRef.current) return;
+
+    setMatching(true);
+    setMatchingStatus('正在寻找对手...');
+
+    const source = matchingService.watchMatch();
+    matchSourceRef.current = source;
+
+    const stopWatching = () => {
+      source.close();
+      matchSourceRef.current = null;
+      setMatching(false);
+    };
+
+    source.addEventListener('queued', (event) => {
+      const data = JSON.parse((event as MessageEvent).data);
+      const estimate = data.estimatedWaitSeconds;
+      setMatchingStatus(
+        `排队中：第 ${data.queuePosition || 1} 位，共 ${data.waitingPlayers || 1} 人` +
+        (estimate >= 0 ? `，预计 ${estimate || 0} 秒` : '')
+      );
+    });
+
+    source.addEventListener('match_found', (event) => {
+      const data = JSON.parse((event as MessageEvent).data);
+      stopWatching();
+      // 匹配成功，跳转到游戏房间
+      router.push(`/room/${data.roomId}`);
+    });
+
+    source.addEventListener('match_cancelled', () => {
+      stopWatching();
+      setMatchingStatus('已取消匹配');
+    });
+
+    source.addEventListener('match_error', () => {
+      stopWatching();
       setMatchingStatus('匹配失败，请重试');
-      console.error('Error matching:', error);
+    });
+  };
+
+  // 取消匹配，服务端会推送 match_cancelled 并结束事件流
+  const cancelMatching = async () => {
+    if (!user) return;
+
+    try {
+      await matchingService.cancelMatch(user.id);
+    } catch (error) {
+      console.error('Error cancelling match:', error);
     }
   };
 
@@ -127,10 +245,18 @@ const Home = () => {
   };
 
   // 退出登录
-  const handleLogout = () => {
+  const handleLogout = async () => {
+    const userId = localStorage.getItem('userId');
+    if (userId) {
+      try {
+        await lobbyService.logout(userId);
+      } catch (error) {
+        console.error('Error logging out:', error);
+      }
+    }
+    clearTokens();
     localStorage.removeItem('userId');
     localStorage.removeItem('username');
-    localStorage.removeItem('token');
     router.push('/login');
   };
 
@@ -154,8 +280,9 @@ const Home = () => {
           <h2>个人信息</h2>
           <p><strong>用户名:</strong> {user?.username}</p>
           <p><strong>积分:</strong> {user?.score || 0}</p>
-          <p><strong>胜场:</strong> {user?.games_won || 0}</p>
-          <p><strong>总局数:</strong> {user?.games_played || 0}</p>
+          <p><strong>评分:</strong> {user?.rating ?? 1500} ({user?.gamesPlayed || 0} 场对战)</p>
+          <p><strong>胜场:</strong> {user?.gamesWon || 0}</p>
+          <p><strong>总局数:</strong> {user?.gamesPlayed || 0}</p>
         </div>
 
         {/* 匹配区域 */}
@@ -163,13 +290,23 @@ const Home = () => {
           <h2>匹配游戏</h2>
           <p>当前等待玩家: {waitingPlayers}</p>
           <div style={{ marginTop: '15px' }}>
-            <button 
-              className="btn btn-success" 
-              onClick={startMatching}
-              style={{ width: '100%', marginBottom: '10px' }}
-            >
-              开始匹配
-            </button>
+            {matching ? (
+              <button 
+                className="btn" 
+                onClick={cancelMatching}
+                style={{ width: '100%', marginBottom: '10px' }}
+              >
+                取消匹配
+              </button>
+            ) : (
+              <button 
+                className="btn btn-success" 
+                onClick={startMatching}
+                style={{ width: '100%', marginBottom: '10px' }}
+              >
+                开始匹配
+              </button>
+            )}
             {matchingStatus && (
               <p style={{ color: 'orange', fontSize: '14px' }}>{matchingStatus}</p>
             )}
@@ -186,6 +323,31 @@ const Home = () => {
         {/* 好友列表 */}
         <div style={{ backgroundColor: '#1a1a1a', padding: '20px', borderRadius: '8px', border: '1px solid #333' }}>
           <h2>好友</h2>
+          {/* 房间邀请，过期的不再显示 */}
+          {invites
+            .filter((invite) => Number(invite.expiresAt) * 1000 > Date.now())
+            .map((invite) => (
+              <div
+                key={invite.inviteId}
+                style={{ padding: '8px', marginBottom: '10px', border: '1px solid #4CAF50', borderRadius: '4px' }}
+              >
+                <span>{invite.fromUsername} 邀请你加入房间 {invite.roomName || invite.roomId}</span>
+                <button
+                  className="btn btn-success"
+                  onClick={() => respondInvite(invite.inviteId, true)}
+                  style={{ marginLeft: '10px' }}
+                >
+                  加入
+                </button>
+                <button
+                  className="btn"
+                  onClick={() => respondInvite(invite.inviteId, false)}
+                  style={{ marginLeft: '10px' }}
+                >
+                  忽略
+                </button>
+              </div>
+            ))}
           <div style={{ marginBottom: '15px' }}>
             <input
               type="text"
@@ -211,6 +373,20 @@ const Home = () => {
                   <span style={{ color: friend.online ? '#4CAF50' : '#aaa' }}>
                     {friend.username} {friend.online ? '🟢' : '🔴'}
                   </span>
+                  {friend.presence && (
+                    <span style={{ color: '#777', marginLeft: '8px' }}>
+                      {presenceLabel(friend.presence.status)}
+                    </span>
+                  )}
+                  {friend.playingRoomId && (
+                    <button
+                      className="btn"
+                      onClick={() => router.push(`/spectate/${friend.playingRoomId}`)}
+                      style={{ marginLeft: '10px' }}
+                    >
+                      观战
+                    </button>
+                  )}
                 </div>
               ))
             ) : (
@@ -248,7 +424,7 @@ const Home = () => {
                   style={{ 
                     padding: '8px', 
                     borderBottom: '1px solid #333',
-                    backgroundColor: entry.user_id === user?.id ? '#333' : 'transparent'
+                    backgroundColor: entry.userId === user?.id ? '#333' : 'transparent'
                   }}
                 >
                   <span>
@@ -271,6 +447,13 @@ const Home = () => {
         >
           查看完整排行榜
         </button>
+        <button 
+          className="btn" 
+          onClick={() => router.push('/history')}
+          style={{ marginRight: '10px' }}
+        >
+          历史对局
+        </button>
         <button 
           className="btn" 
           onClick={() => router.push('/profile')}
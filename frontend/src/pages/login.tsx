@@ -1,6 +1,6 @@
 import React, { useState } from 'react';
 import { useRouter } from 'next/router';
-import { lobbyService } from '../utils/api';
+import { lobbyService, saveTokens } from '../utils/api';
 import Head from 'next/head';
 
 const Login = () => {
@@ -34,7 +34,7 @@ const Login = () => {
           // 保存用户信息到本地存储
           localStorage.setItem('userId', response.userId);
           localStorage.setItem('username', response.username);
-          localStorage.setItem('token', btoa(`${username}:${password}`)); // 简单的认证令牌
+          saveTokens(response.accessToken, response.refreshToken);
 
           // 跳转到主页
           router.push('/');
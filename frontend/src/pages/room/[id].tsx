@@ -1,6 +1,7 @@
 import React, { useState, useEffect, useRef } from 'react';
 import { useRouter } from 'next/router';
-import { roomService, gameService } from '../../utils/api';
+import { roomService, friendsService } from '../../utils/api';
+import { RoomSocket } from '../../utils/socket';
 import GameBoard from '../../components/GameBoard';
 import Head from 'next/head';
 
@@ -12,13 +13,17 @@ const RoomPage = () => {
   const [players, setPlayers] = useState<any[]>([]);
   const [roomInfo, setRoomInfo] = useState<any>(null);
   const [gameStarted, setGameStarted] = useState(false);
+  const [gameFinished, setGameFinished] = useState(false);
   const [showGame, setShowGame] = useState(false);
+  const [socket, setSocket] = useState<RoomSocket | null>(null);
+  const [friends, setFriends] = useState<any[]>([]);
+  const [showInvite, setShowInvite] = useState(false);
   const messagesEndRef = useRef<HTMLDivElement>(null);
 
   const userId = typeof window !== 'undefined' ? localStorage.getItem('userId') : null;
   const username = typeof window !== 'undefined' ? localStorage.getItem('username') : null;
 
-  // 加载房间信息和消息
+  // 加载历史消息，并通过 WebSocket 接收新消息
   useEffect(() => {
     if (!roomId || !userId) return;
 
@@ -36,10 +41,22 @@ const RoomPage = () => {
 
     loadRoomData();
 
-    // 设置定时器刷新消息
-    const msgInterval = setInterval(loadRoomData, 2000);
+    const roomSocket = new RoomSocket(roomId as string);
+    const unsubscribe = roomSocket.on((frame) => {
+      if (frame.type === 'chat_message') {
+        setMessages((prev) => [...prev, frame.data]);
+      } else if (frame.type === 'game_update' && frame.data?.status === 'playing') {
+        setGameStarted(true);
+        setGameFinished(false);
+      }
+    });
+    setSocket(roomSocket);
 
-    return () => clearInterval(msgInterval);
+    return () => {
+      unsubscribe();
+      roomSocket.close();
+      setSocket(null);
+    };
   }, [roomId, userId]);
 
   // 滚动到底部
@@ -51,25 +68,12 @@ const RoomPage = () => {
     messagesEndRef.current?.scrollIntoView({ behavior: 'smooth' });
   };
 
-  // 发送消息
-  const sendMessage = async () => {
-    if (!newMessage.trim() || !roomId || !userId) return;
+  // 发送消息，新消息会通过 WebSocket 推送回来
+  const sendMessage = () => {
+    if (!newMessage.trim() || !socket) return;
 
-    try {
-      const response = await roomService.sendMessage(
-        roomId as string,
-        userId,
-        newMessage,
-        'text'
-      );
-
-      if (response.success) {
-        setNewMessage('');
-        // 消息会通过定时器获取到
-      }
-    } catch (error) {
-      console.error('Error sending message:', error);
-    }
+    socket.chat(newMessage);
+    setNewMessage('');
   };
 
   // 开始游戏
@@ -81,6 +85,7 @@ const RoomPage = () => {
       if (response.success) {
         setGameStarted(true);
         setShowGame(true);
+        socket?.resubscribe();
       } else {
         alert(response.message || '开始游戏失败');
       }
@@ -90,18 +95,68 @@ const RoomPage = () => {
     }
   };
 
+  // 展开邀请列表时加载在线好友
+  const toggleInvite = async () => {
+    if (showInvite || !userId) {
+      setShowInvite(false);
+      return;
+    }
+
+    setShowInvite(true);
+    try {
+      const response = await friendsService.getFriends(userId);
+      if (response.success) {
+        setFriends((response.friends || []).filter((friend: any) => friend.online));
+      }
+    } catch (error) {
+      console.error('Error loading friends:', error);
+    }
+  };
+
+  // 邀请好友加入房间，好友会实时收到邀请
+  const inviteFriend = async (friendId: string) => {
+    if (!roomId || !userId) return;
+
+    try {
+      const response = await friendsService.inviteToRoom(userId, friendId, roomId as string);
+      alert(response.success ? '邀请已发送' : response.message || '邀请失败');
+    } catch (error) {
+      console.error('Error inviting friend:', error);
+      alert('邀请失败');
+    }
+  };
+
   // 处理游戏结束
   const handleGameOver = (winnerId: string) => {
     setGameStarted(false);
+    setGameFinished(true);
     setShowGame(false);
-    alert(`游戏结束！获胜者: ${winnerId}`);
+    alert(winnerId ? `游戏结束！获胜者: ${winnerId}` : '游戏结束！平局');
+  };
+
+  // 再来一局：房间回到等待状态
+  const rematch = async () => {
+    if (!roomId || !userId) return;
+
+    try {
+      const response = await roomService.rematch(roomId as string, userId);
+      if (response.success) {
+        setGameFinished(false);
+      } else {
+        alert(response.message || '再来一局失败');
+      }
+    } catch (error) {
+      console.error('Error requesting rematch:', error);
+      alert('再来一局失败');
+    }
   };
 
-  if (showGame && typeof roomId === 'string' && userId) {
+  if (showGame && typeof roomId === 'string' && userId && socket) {
     return (
       <GameBoard 
         roomId={roomId} 
         playerId={userId} 
+        socket={socket}
         onGameOver={handleGameOver} 
       />
     );
@@ -123,10 +178,22 @@ const RoomPage = () => {
         <div>
           {!gameStarted ? (
             <div style={{ textAlign: 'center', padding: '40px 0' }}>
-              <h2>等待其他玩家加入...</h2>
+              <h2>{gameFinished ? '本局已结束' : '等待其他玩家加入...'}</h2>
               <p>房间 ID: {roomId}</p>
               
-              {userId && (
+              {userId && gameFinished && (
+                <div style={{ marginTop: '30px' }}>
+                  <button 
+                    className="btn btn-success" 
+                    onClick={rematch}
+                    style={{ fontSize: '18px', padding: '12px 24px' }}
+                  >
+                    再来一局
+                  </button>
+                </div>
+              )}
+
+              {userId && !gameFinished && (
                 <div style={{ marginTop: '30px' }}>
                   <button 
                     className="btn btn-success" 
@@ -164,9 +231,9 @@ const RoomPage = () => {
                     key={index} 
                     className={`chat-message ${msg.type === 'system' ? 'system' : ''}`}
                   >
-                    <strong>{msg.sender_username || '系统'}:</strong> {msg.content}
+                    <strong>{msg.senderUsername || '系统'}:</strong> {msg.content}
                     <small style={{ float: 'right', opacity: 0.7 }}>
-                      {new Date(msg.created_at * 1000).toLocaleTimeString()}
+                      {new Date(Number(msg.createdAt) * 1000).toLocaleTimeString()}
                     </small>
                   </div>
                 ))
@@ -206,7 +273,27 @@ const RoomPage = () => {
 
           <div style={{ marginTop: '30px' }}>
             <h4>操作</h4>
-            <button className="btn" style={{ width: '100%', marginBottom: '10px' }}>邀请好友</button>
+            <button className="btn" onClick={toggleInvite} style={{ width: '100%', marginBottom: '10px' }}>邀请好友</button>
+            {showInvite && (
+              <div style={{ marginBottom: '10px' }}>
+                {friends.length > 0 ? (
+                  friends.map((friend) => (
+                    <div key={friend.userId} style={{ padding: '8px', borderBottom: '1px solid #333' }}>
+                      <span>{friend.username}</span>
+                      <button
+                        className="btn"
+                        onClick={() => inviteFriend(friend.userId)}
+                        style={{ marginLeft: '10px' }}
+                      >
+                        邀请
+                      </button>
+                    </div>
+                  ))
+                ) : (
+                  <p style={{ color: '#777', fontStyle: 'italic' }}>暂无在线好友</p>
+                )}
+              </div>
+            )}
             <button className="btn btn-danger" style={{ width: '100%' }}>离开房间</button>
           </div>
         </div>
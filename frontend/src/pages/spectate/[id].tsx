@@ -0,0 +1,83 @@
+import React, { useState, useEffect } from 'react';
+import { useRouter } from 'next/router';
+import { gameService } from '../../utils/api';
+import { BoardGrid, GameState, normalizeState } from '../../components/GameBoard';
+import Head from 'next/head';
+
+// 观战事件流推送的更新类型
+const UPDATE_EVENTS = ['game_state', 'snake_moved', 'food_eaten', 'game_over'];
+
+const SpectatePage = () => {
+  const router = useRouter();
+  const { id: roomId } = router.query;
+  const [gameState, setGameState] = useState<GameState | null>(null);
+  const [winnerId, setWinnerId] = useState<string | null>(null);
+  const [error, setError] = useState('');
+
+  useEffect(() => {
+    if (!roomId) return;
+
+    const userId = localStorage.getItem('userId');
+    if (!userId) {
+      router.push('/login');
+      return;
+    }
+
+    const source = gameService.spectateGame(roomId as string);
+    const handleUpdate = (event: MessageEvent) => {
+      const data = JSON.parse(event.data);
+      setGameState(normalizeState(data));
+      if (data.type === 'game_over') {
+        setWinnerId(data.winnerPlayerId || '');
+        source.close();
+      }
+    };
+    UPDATE_EVENTS.forEach((type) => source.addEventListener(type, handleUpdate as EventListener));
+    source.addEventListener('spectate_error', ((event: MessageEvent) => {
+      setError(JSON.parse(event.data).message || '观战连接中断');
+      source.close();
+    }) as EventListener);
+
+    return () => source.close();
+  }, [roomId]);
+
+  return (
+    <div style={{ padding: '20px', maxWidth: '800px', margin: '0 auto' }}>
+      <Head>
+        <title>观战 - 贪吃蛇游戏</title>
+      </Head>
+
+      <header style={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center', marginBottom: '30px' }}>
+        <h1>👀 观战</h1>
+        <button className="btn" onClick={() => router.push('/')}>返回首页</button>
+      </header>
+
+      {error ? (
+        <p style={{ color: '#f44336' }}>{error}</p>
+      ) : (
+        <div className="game-container">
+          <div className="game-status">
+            <p>房间: {roomId}</p>
+            <p>状态: {gameState?.status || '连接中（观战画面有延迟）...'}</p>
+            {gameState && <p>观战人数: {gameState.spectatorCount}</p>}
+            {winnerId !== null && <p>{winnerId ? `获胜者: ${winnerId}` : '平局'}</p>}
+          </div>
+
+          <BoardGrid gameState={gameState} />
+
+          {gameState && (
+            <div style={{ marginTop: '20px' }}>
+              {gameState.snakes.map((snake) => (
+                <p key={snake.playerId} style={{ color: snake.color }}>
+                  {snake.playerId}: {snake.score} 分
+                </p>
+              ))}
+            </div>
+          )}
+        </div>
+      )}
+    </div>
+  );
+};
+
+export default SpectatePage;
@@ -8,6 +8,57 @@ const API_CONFIG = {
   GATEWAY_URL: process.env.NEXT_PUBLIC_GATEWAY_URL || '/api',
 };
 
+// 保存登录或刷新后签发的令牌
+export const saveTokens = (accessToken: string, refreshToken: string) => {
+  localStorage.setItem('token', accessToken);
+  localStorage.setItem('refreshToken', refreshToken);
+};
+
+// 清除本地保存的令牌
+export const clearTokens = () => {
+  localStorage.removeItem('token');
+  localStorage.removeItem('refreshToken');
+};
+
+// EventSource 和 WebSocket 无法设置请求头，访问令牌通过 token 查询参数传递
+export const withToken = (params: Record<string, string>) => {
+  const search = new URLSearchParams(params);
+  const token = localStorage.getItem('token');
+  if (token) {
+    search.set('token', token);
+  }
+  return search;
+};
+
+// 同一时间只发起一次刷新，并发的 401 请求共用结果
+let refreshing: Promise<string | null> | null = null;
+
+const refreshAccessToken = (baseURL: string): Promise<string | null> => {
+  if (!refreshing) {
+    refreshing = (async () => {
+      const refreshToken = localStorage.getItem('refreshToken');
+      if (!refreshToken) {
+        return null;
+      }
+      try {
+        const response = await axios.post(`${baseURL}/auth/refreshToken`, { refreshToken });
+        if (!response.data.success) {
+          clearTokens();
+          return null;
+        }
+        saveTokens(response.data.accessToken, response.data.refreshToken);
+        return response.data.accessToken as string;
+      } catch (error) {
+        clearTokens();
+        return null;
+      } finally {
+        refreshing = null;
+      }
+    })();
+  }
+  return refreshing;
+};
+
 // Axios 实例
 const createAxiosInstance = (baseURL: string) => {
   const instance = axios.create({
@@ -33,12 +84,26 @@ const createAxiosInstance = (baseURL: string) => {
     }
   );
 
-  // 响应拦截器
+  // 响应拦截器：访问令牌过期时用刷新令牌换取新令牌并重试一次
   instance.interceptors.response.use(
     (response) => {
       return response;
     },
-    (error) => {
+    async (error) => {
+      const original = error.config;
+      if (
+        error.response?.status === 401 &&
+        original &&
+        !original._retried &&
+        original.url !== '/auth/refreshToken'
+      ) {
+        original._retried = true;
+        const token = await refreshAccessToken(baseURL);
+        if (token) {
+          original.headers.Authorization = `Bearer ${token}`;
+          return instance(original);
+        }
+      }
       console.error('API Error:', error);
       return Promise.reject(error);
     }
@@ -99,18 +164,43 @@ export const lobbyService = {
       return response.data;
     } catch (error) {
       throw error;
+    } finally {
+      clearTokens();
+    }
+  },
+
+  // 心跳：维持在线状态，idle 表示页面处于后台
+  heartbeat: async (userId: string, idle: boolean) => {
+    try {
+      const response = await gatewayApi.post('/auth/heartbeat', {
+        userId,
+        idle,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 使用刷新令牌换取新的访问令牌
+  refreshToken: async (refreshToken: string) => {
+    try {
+      const response = await gatewayApi.post('/auth/refreshToken', {
+        refreshToken,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
     }
   },
 };
 
 export const matchingService = {
   // 寻找匹配
-  findMatch: async (playerId: string, username: string, rating: number) => {
+  findMatch: async (playerId: string) => {
     try {
       const response = await gatewayApi.post('/match/findMatch', {
         playerId,
-        username,
-        rating,
       });
       return response.data;
     } catch (error) {
@@ -118,6 +208,12 @@ export const matchingService = {
     }
   },
 
+  // 订阅匹配进度（Server-Sent Events），事件类型: queued, match_found, match_cancelled, match_error
+  watchMatch: () => {
+    const params = withToken({});
+    return new EventSource(`${API_CONFIG.GATEWAY_URL}/match/watch?${params.toString()}`);
+  },
+
   // 取消匹配
   cancelMatch: async (playerId: string) => {
     try {
@@ -151,14 +247,27 @@ export const matchingService = {
   },
 };
 
+// 创建房间时可选的游戏配置，未提供的字段使用服务端默认值
+export interface GameOptions {
+  boardWidth?: number;
+  boardHeight?: number;
+  foodCount?: number;
+  tickIntervalMs?: number;
+  wallMode?: 'solid' | 'wrap';
+  wallEnabled?: boolean;
+  maxScore?: number;
+  timeLimitSeconds?: number;
+}
+
 export const roomService = {
   // 创建房间
-  createRoom: async (userId: string, roomName: string, maxPlayers: number) => {
+  createRoom: async (userId: string, roomName: string, maxPlayers: number, options?: GameOptions) => {
     try {
       const response = await gatewayApi.post('/room/createRoom', {
         userId,
         roomName,
         maxPlayers,
+        options,
       });
       return response.data;
     } catch (error) {
@@ -232,6 +341,19 @@ export const roomService = {
       throw error;
     }
   },
+
+  // 对局结束后再来一局（仅房主）
+  rematch: async (roomId: string, userId: string) => {
+    try {
+      const response = await gatewayApi.post('/room/rematch', {
+        roomId,
+        userId,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
 };
 
 export const leaderboardService = {
@@ -248,13 +370,11 @@ export const leaderboardService = {
     }
   },
 
-  // 更新分数
-  updateScore: async (userId: string, score: number, gameWon: boolean) => {
+  // 获取用户排名
+  getUserRank: async (userId: string) => {
     try {
-      const response = await gatewayApi.post('/leaderboard/updateScore', {
+      const response = await gatewayApi.post('/leaderboard/getUserRank', {
         userId,
-        score,
-        gameWon,
       });
       return response.data;
     } catch (error) {
@@ -262,10 +382,10 @@ export const leaderboardService = {
     }
   },
 
-  // 获取用户排名
-  getUserRank: async (userId: string) => {
+  // 获取用户评分
+  getRating: async (userId: string) => {
     try {
-      const response = await gatewayApi.post('/leaderboard/getUserRank', {
+      const response = await gatewayApi.post('/leaderboard/getRating', {
         userId,
       });
       return response.data;
@@ -316,17 +436,55 @@ export const gameService = {
     }
   },
 
-  // 获取游戏状态
-  getGameState: async (roomId: string) => {
+  // 获取历史对局（page 从 1 开始）
+  getMatchHistory: async (userId: string, page: number = 1, pageSize: number = 20) => {
     try {
-      const response = await gatewayApi.post('/game/getGameState', {
-        roomId,
+      const response = await gatewayApi.post('/game/getMatchHistory', {
+        userId,
+        page,
+        pageSize,
       });
       return response.data;
     } catch (error) {
       throw error;
     }
   },
+
+  // 获取单局对局记录
+  getMatch: async (matchId: string) => {
+    try {
+      const response = await gatewayApi.post('/game/getMatch', {
+        matchId,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 获取对局回放数据
+  getReplay: async (matchId: string) => {
+    try {
+      const response = await gatewayApi.post('/game/getReplay', {
+        matchId,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 观战（Server-Sent Events），画面有延迟；事件类型与实时对局相同: game_state, snake_moved, food_eaten, game_over, spectate_error
+  spectateGame: (roomId: string) => {
+    const params = withToken({ roomId });
+    return new EventSource(`${API_CONFIG.GATEWAY_URL}/game/spectate?${params.toString()}`);
+  },
+
+  // 播放对局回放（Server-Sent Events），事件类型与实时对局相同: game_state, snake_moved, food_eaten, game_over, replay_error
+  watchReplay: (matchId: string, speed: number = 1) => {
+    const params = withToken({ matchId, speed: String(speed) });
+    return new EventSource(`${API_CONFIG.GATEWAY_URL}/game/replay?${params.toString()}`);
+  },
 };
 
 export const friendsService = {
@@ -394,4 +552,122 @@ export const friendsService = {
       throw error;
     }
   },
+
+  // 获取好友请求列表，direction 为 incoming（收到的）或 outgoing（发出的）
+  listFriendRequests: async (userId: string, direction: 'incoming' | 'outgoing' = 'incoming', page: number = 1, pageSize: number = 20) => {
+    try {
+      const response = await gatewayApi.post('/friends/listFriendRequests', {
+        userId,
+        direction,
+        page,
+        pageSize,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 撤回发出的好友请求
+  cancelFriendRequest: async (userId: string, targetUserId: string) => {
+    try {
+      const response = await gatewayApi.post('/friends/cancelFriendRequest', {
+        userId,
+        targetUserId,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 屏蔽用户，同时解除好友关系
+  blockUser: async (userId: string, targetUserId: string) => {
+    try {
+      const response = await gatewayApi.post('/friends/blockUser', {
+        userId,
+        targetUserId,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 取消屏蔽
+  unblockUser: async (userId: string, targetUserId: string) => {
+    try {
+      const response = await gatewayApi.post('/friends/unblockUser', {
+        userId,
+        targetUserId,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 获取屏蔽列表
+  listBlocked: async (userId: string) => {
+    try {
+      const response = await gatewayApi.post('/friends/listBlocked', {
+        userId,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 邀请好友加入房间
+  inviteToRoom: async (userId: string, targetUserId: string, roomId: string) => {
+    try {
+      const response = await gatewayApi.post('/friends/inviteToRoom', {
+        userId,
+        targetUserId,
+        roomId,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 获取收到的房间邀请
+  listInvites: async (userId: string) => {
+    try {
+      const response = await gatewayApi.post('/friends/listInvites', {
+        userId,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 回应房间邀请，接受后已加入房间，返回 roomId
+  respondInvite: async (userId: string, inviteId: string, accepted: boolean) => {
+    try {
+      const response = await gatewayApi.post('/friends/respondInvite', {
+        userId,
+        inviteId,
+        accepted,
+      });
+      return response.data;
+    } catch (error) {
+      throw error;
+    }
+  },
+
+  // 订阅房间邀请（Server-Sent Events），事件类型: invite, invite_error
+  watchInvites: () => {
+    const params = withToken({});
+    return new EventSource(`${API_CONFIG.GATEWAY_URL}/friends/invites?${params.toString()}`);
+  },
+
+  // 订阅好友在线状态（Server-Sent Events），事件类型: presence, presence_error
+  watchPresence: () => {
+    const params = withToken({});
+    return new EventSource(`${API_CONFIG.GATEWAY_URL}/friends/presence?${params.toString()}`);
+  },
 };
\ No newline at end of file
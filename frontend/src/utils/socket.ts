@@ -0,0 +1,101 @@
+// WebSocket 实时通道：房间的游戏更新、聊天消息与方向输入
+
+export interface SocketFrame {
+  type: string; // subscribed, unsubscribed, game_update, chat_message, error, pong
+  roomId?: string;
+  message?: string;
+  data?: any;
+}
+
+type FrameHandler = (frame: SocketFrame) => void;
+
+// 断线重连的最大间隔
+const MAX_RECONNECT_DELAY = 10000;
+
+// 根据网关地址构造 WebSocket 地址（http -> ws, https -> wss）
+const buildSocketUrl = () => {
+  const base = process.env.NEXT_PUBLIC_GATEWAY_URL || '/api';
+  const url = new URL(base, window.location.href);
+  url.protocol = url.protocol === 'https:' ? 'wss:' : 'ws:';
+  url.pathname = `${url.pathname.replace(/\/$/, '')}/ws`;
+  const token = localStorage.getItem('token');
+  if (token) {
+    url.searchParams.set('token', token);
+  }
+  return url.toString();
+};
+
+export class RoomSocket {
+  private socket: WebSocket | null = null;
+  private handlers = new Set<FrameHandler>();
+  private reconnectDelay = 1000;
+  private reconnectTimer: ReturnType<typeof setTimeout> | null = null;
+  private closed = false;
+
+  constructor(private roomId: string) {
+    this.connect();
+  }
+
+  // 注册帧处理函数，返回取消注册的函数
+  on(handler: FrameHandler) {
+    this.handlers.add(handler);
+    return () => {
+      this.handlers.delete(handler);
+    };
+  }
+
+  // 发送方向输入
+  move(direction: string) {
+    this.send({ type: 'move', direction });
+  }
+
+  // 发送聊天消息
+  chat(content: string) {
+    this.send({ type: 'chat', content });
+  }
+
+  // 重新订阅房间（例如新一局游戏开始时）
+  resubscribe() {
+    this.send({ type: 'subscribe', roomId: this.roomId });
+  }
+
+  close() {
+    this.closed = true;
+    if (this.reconnectTimer) {
+      clearTimeout(this.reconnectTimer);
+    }
+    this.socket?.close();
+  }
+
+  private connect() {
+    const socket = new WebSocket(buildSocketUrl());
+    this.socket = socket;
+
+    socket.onopen = () => {
+      this.reconnectDelay = 1000;
+      this.resubscribe();
+    };
+
+    socket.onmessage = (event) => {
+      try {
+        const frame: SocketFrame = JSON.parse(event.data);
+        this.handlers.forEach((handler) => handler(frame));
+      } catch (error) {
+        console.error('Invalid socket frame:', error);
+      }
+    };
+
+    socket.onclose = () => {
+      if (this.closed) return;
+      // 指数退避重连
+      this.reconnectTimer = setTimeout(() => this.connect(), this.reconnectDelay);
+      this.reconnectDelay = Math.min(this.reconnectDelay * 2, MAX_RECONNECT_DELAY);
+    };
+  }
+
+  private send(frame: Record<string, unknown>) {
+    if (this.socket?.readyState === WebSocket.OPEN) {
+      this.socket.send(JSON.stringify(frame));
+    }
+  }
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// GameRecord 已结束对局的记录
+type GameRecord struct {
+	ID        string         `json:"id"` // 对局ID
+	RoomID    string         `json:"room_id"`
+	Players   []PlayerResult `json:"players"`   // 按名次排序
+	WinnerID  string         `json:"winner_id"` // 平局为空
+	Duration  time.Duration  `json:"duration"`
+	StartedAt time.Time      `json:"started_at"`
+	EndedAt   time.Time      `json:"ended_at"`
+}
+
+// PlayerResult 玩家在一局中的最终成绩
+type PlayerResult struct {
+	PlayerID  string `json:"player_id"`
+	Score     int    `json:"score"`
+	Placement int    `json:"placement"` // 1 为第一名，并列名次相同
+	Length    int    `json:"length"`
+}
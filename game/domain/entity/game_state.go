@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"math/rand"
+	"sort"
 	"sync"
 	"time"
 )
@@ -12,10 +14,37 @@ type GameState struct {
 	Foods        []Position             `json:"foods"`
 	Walls        []Position             `json:"walls"`
 	Status       string                 `json:"status"`  // waiting, playing, paused, finished
+	Tick         int64                  `json:"tick"`    // 已模拟的帧数
+	WinnerID     string                 `json:"winner_id"` // 最后存活的玩家，平局为空
+	PlayerCount  int                    `json:"player_count"` // 本局放置过的蛇数，中途离开的玩家仍计入，据此区分单人和多人规则
+	RosterOnly   bool                   `json:"roster_only"` // 按名单开局，名单外的玩家不能加入
+	Options      GameOptions            `json:"options"`
+	StartedAt    time.Time              `json:"started_at"`
 	UpdatedAt    time.Time              `json:"updated_at"`
+	Seed         int64                  `json:"seed"`    // 随机种子，开局时确定
+	Replay       *Replay                `json:"-" bson:"-"` // 开局后的回放记录，未开局为空
+	rng          *rand.Rand             // 对局内所有随机数的来源，保证相同种子可以复现
 	mutex        sync.RWMutex           // 内部同步锁
 }
 
+// 边界模式
+const (
+	WallModeSolid = "solid" // 撞到边界死亡
+	WallModeWrap  = "wrap"  // 从对边穿出
+)
+
+// GameOptions 对局配置，由房间创建时指定
+type GameOptions struct {
+	BoardWidth   int32         `json:"board_width"`
+	BoardHeight  int32         `json:"board_height"`
+	FoodCount    int           `json:"food_count"`    // 场上保持的食物数量
+	TickInterval time.Duration `json:"tick_interval"` // 模拟循环的帧间隔
+	WallMode     string        `json:"wall_mode"`     // solid, wrap
+	WallEnabled  bool          `json:"wall_enabled"`  // 是否在场内生成障碍墙
+	MaxScore     int           `json:"max_score"`     // 达到该分数立即获胜，0 表示不限制
+	TimeLimit    time.Duration `json:"time_limit"`    // 对局时长上限，0 表示不限制
+}
+
 type GameSnake struct {
 	PlayerID string        `json:"player_id"`
 	Segments []SnakeSegment `json:"segments"`
@@ -24,6 +53,10 @@ type GameSnake struct {
 	Score    int           `json:"score"`
 	Alive    bool          `json:"alive"`
 	Direction Direction    `json:"direction"`
+	NextDirection Direction `json:"next_direction"` // 玩家最近一次输入，下一帧生效
+	EliminatedAt  int64     `json:"eliminated_at"`  // 被淘汰时的帧数，存活为 0
+	Left          bool      `json:"left"`           // 对局中途离开，按淘汰处理但仍计入对局记录
+	Forfeited     bool      `json:"forfeited"`      // 存活时离开，视为认输，名次排在最后
 }
 
 type SnakeSegment struct {
@@ -43,4 +76,127 @@ const (
 	Direction_DOWN  Direction = 2
 	Direction_LEFT  Direction = 3
 	Direction_RIGHT Direction = 4
-)
\ No newline at end of file
+)
+
+// IsOpposite 判断两个方向是否相反（蛇不能直接掉头）
+func (d Direction) IsOpposite(other Direction) bool {
+	switch d {
+	case Direction_UP:
+		return other == Direction_DOWN
+	case Direction_DOWN:
+		return other == Direction_UP
+	case Direction_LEFT:
+		return other == Direction_RIGHT
+	case Direction_RIGHT:
+		return other == Direction_LEFT
+	}
+	return false
+}
+
+// Lock 获取写锁，模拟循环修改状态前调用
+func (g *GameState) Lock() {
+	g.mutex.Lock()
+}
+
+// Unlock 释放写锁
+func (g *GameState) Unlock() {
+	g.mutex.Unlock()
+}
+
+// Reseed 用指定种子重置随机数生成器，相同的种子、初始状态和输入会产生相同的对局
+func (g *GameState) Reseed(seed int64) {
+	g.Seed = seed
+	g.rng = rand.New(rand.NewSource(seed))
+}
+
+// Rand 返回对局的随机数生成器，尚未设置种子时使用当前时间，调用方需持有锁
+func (g *GameState) Rand() *rand.Rand {
+	if g.rng == nil {
+		g.Reseed(time.Now().UnixNano())
+	}
+	return g.rng
+}
+
+// Clone 在读锁保护下深拷贝游戏状态，供外部安全读取
+func (g *GameState) Clone() *GameState {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	return g.Copy()
+}
+
+// Copy 深拷贝游戏状态（不含随机数生成器和回放记录），调用方需持有锁
+func (g *GameState) Copy() *GameState {
+	clone := &GameState{
+		ID:          g.ID,
+		RoomID:      g.RoomID,
+		Snakes:      make(map[string]*GameSnake, len(g.Snakes)),
+		Foods:       append([]Position(nil), g.Foods...),
+		Walls:       append([]Position(nil), g.Walls...),
+		Status:      g.Status,
+		Tick:        g.Tick,
+		WinnerID:    g.WinnerID,
+		PlayerCount: g.PlayerCount,
+		RosterOnly:  g.RosterOnly,
+		Options:     g.Options,
+		StartedAt:   g.StartedAt,
+		UpdatedAt:   g.UpdatedAt,
+		Seed:        g.Seed,
+	}
+	for playerID, snake := range g.Snakes {
+		snakeCopy := *snake
+		snakeCopy.Segments = append([]SnakeSegment(nil), snake.Segments...)
+		clone.Snakes[playerID] = &snakeCopy
+	}
+	return clone
+}
+
+// GameUpdate 每帧推送给订阅者的游戏更新
+type GameUpdate struct {
+	Type              string       `json:"type"` // game_state, snake_moved, food_eaten, game_over
+	Tick              int64        `json:"tick"`
+	Snakes            []*GameSnake `json:"snakes"`
+	Foods             []Position   `json:"foods"`
+	Walls             []Position   `json:"walls"`
+	EliminatedPlayers []string     `json:"eliminated_players"`
+	WinnerPlayerID    string       `json:"winner_player_id"`
+	Status            string       `json:"status"`
+	Options           GameOptions  `json:"options"`
+	SpectatorCount    int          `json:"spectator_count"`
+}
+
+// NewUpdate 根据当前状态生成一份独立的更新快照，调用方需持有锁
+func (g *GameState) NewUpdate(updateType string, eliminated []string) *GameUpdate {
+	playerIDs := make([]string, 0, len(g.Snakes))
+	for playerID := range g.Snakes {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+
+	snakes := make([]*GameSnake, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		snakeCopy := *g.Snakes[playerID]
+		snakeCopy.Segments = append([]SnakeSegment(nil), snakeCopy.Segments...)
+		snakes = append(snakes, &snakeCopy)
+	}
+
+	return &GameUpdate{
+		Type:              updateType,
+		Tick:              g.Tick,
+		Snakes:            snakes,
+		Foods:             append([]Position(nil), g.Foods...),
+		Walls:             append([]Position(nil), g.Walls...),
+		EliminatedPlayers: eliminated,
+		WinnerPlayerID:    g.WinnerID,
+		Status:            g.Status,
+		Options:           g.Options,
+	}
+}
+
+// Snapshot 在读锁保护下生成当前状态的完整快照
+func (g *GameState) Snapshot() *GameUpdate {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	return g.NewUpdate("game_state", nil)
+}
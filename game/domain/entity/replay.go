@@ -0,0 +1,27 @@
+package entity
+
+// 回放中记录的输入类型
+const (
+	InputMove  = "move"  // 改变方向
+	InputJoin  = "join"  // 对局中途加入
+	InputLeave = "leave" // 对局中途离开
+)
+
+// InputEvent 影响模拟结果的一次玩家操作，在第 Tick 帧结算之后、下一帧结算之前生效
+type InputEvent struct {
+	Tick      int64     `json:"tick" bson:"tick"`
+	Type      string    `json:"type" bson:"type"`
+	PlayerID  string    `json:"player_id" bson:"player_id"`
+	Direction Direction `json:"direction" bson:"direction"`
+}
+
+// Replay 对局回放：开局状态、随机种子和按帧记录的输入，重新模拟即可还原整局
+type Replay struct {
+	MatchID    string       `json:"match_id" bson:"_id"`
+	RoomID     string       `json:"room_id" bson:"room_id"`
+	Seed       int64        `json:"seed" bson:"seed"`
+	HeadOnRule string       `json:"head_on_rule" bson:"head_on_rule"`
+	Initial    *GameState   `json:"initial" bson:"initial"`
+	Inputs     []InputEvent `json:"inputs" bson:"inputs"`
+	FinalTick  int64        `json:"final_tick" bson:"final_tick"`
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"snake-game/game/domain/entity"
+)
+
+type GameRecordRepository interface {
+	SaveRecord(ctx context.Context, record *entity.GameRecord) error
+	// GetRecord 记录不存在时返回 nil
+	GetRecord(ctx context.Context, matchID string) (*entity.GameRecord, error)
+	// GetRecordsByPlayer 按结束时间倒序分页返回玩家参与的对局及总数
+	GetRecordsByPlayer(ctx context.Context, playerID string, offset, limit int64) ([]*entity.GameRecord, int64, error)
+	SaveReplay(ctx context.Context, replay *entity.Replay) error
+	// GetReplay 回放不存在时返回 nil
+	GetReplay(ctx context.Context, matchID string) (*entity.Replay, error)
+}
@@ -10,4 +10,5 @@ type GameRepository interface {
 	GetGame(ctx context.Context, roomID string) (*entity.GameState, error)
 	UpdateGame(ctx context.Context, gameState *entity.GameState) error
 	DeleteGame(ctx context.Context, roomID string) error
+	ListGames(ctx context.Context) ([]*entity.GameState, error)
 }
\ No newline at end of file
@@ -2,7 +2,12 @@ package grpc
 
 import (
 	"context"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"snake-game/auth"
 	"snake-game/game/domain/entity"
 	"snake-game/game/internal/usecase"
 	pb "snake-game/proto"
@@ -19,8 +24,32 @@ func NewGameHandler(usecase *usecase.GameUsecase) *GameHandler {
 	}
 }
 
+// CreateGame 按房间配置和玩家名单创建游戏
+func (h *GameHandler) CreateGame(ctx context.Context, req *pb.CreateGameRequest) (*pb.CreateGameResponse, error) {
+	if err := auth.RequireInternal(ctx); err != nil {
+		return nil, err
+	}
+
+	err := h.usecase.CreateGame(ctx, req.RoomId, toEntityGameOptions(req.Options), req.PlayerIds)
+	if err != nil {
+		return &pb.CreateGameResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.CreateGameResponse{
+		Success: true,
+		Message: "Game created successfully",
+	}, nil
+}
+
 // JoinGame 加入游戏
 func (h *GameHandler) JoinGame(ctx context.Context, req *pb.JoinGameRequest) (*pb.JoinGameResponse, error) {
+	if err := auth.Authorize(ctx, req.PlayerId); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.JoinGame(ctx, req.RoomId, req.PlayerId)
 	if err != nil {
 		return &pb.JoinGameResponse{
@@ -29,14 +58,35 @@ func (h *GameHandler) JoinGame(ctx context.Context, req *pb.JoinGameRequest) (*p
 		}, nil
 	}
 
+	// 返回加入后的棋盘，客户端不必等待第一条推送即可开始渲染
+	gameState, err := h.usecase.GetGameState(ctx, req.RoomId)
+	if err != nil || gameState == nil {
+		return &pb.JoinGameResponse{
+			Success: false,
+			Message: "Game not found",
+		}, nil
+	}
+
+	pbSnakes := make([]*pb.GameSnake, 0, len(gameState.Snakes))
+	for _, snake := range gameState.Snakes {
+		pbSnakes = append(pbSnakes, toPbSnake(snake))
+	}
+
 	return &pb.JoinGameResponse{
-		Success: true,
-		Message: "Joined game successfully",
+		Success:       true,
+		Message:       "Joined game successfully",
+		InitialSnakes: pbSnakes,
+		Foods:         toPbPositions(gameState.Foods),
+		Walls:         toPbPositions(gameState.Walls),
 	}, nil
 }
 
 // LeaveGame 离开游戏
 func (h *GameHandler) LeaveGame(ctx context.Context, req *pb.LeaveGameRequest) (*pb.LeaveGameResponse, error) {
+	if err := auth.Authorize(ctx, req.PlayerId); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.LeaveGame(ctx, req.RoomId, req.PlayerId)
 	if err != nil {
 		return &pb.LeaveGameResponse{
@@ -53,6 +103,10 @@ func (h *GameHandler) LeaveGame(ctx context.Context, req *pb.LeaveGameRequest) (
 
 // Move 移动指令
 func (h *GameHandler) Move(ctx context.Context, req *pb.MoveRequest) (*pb.MoveResponse, error) {
+	if err := auth.Authorize(ctx, req.PlayerId); err != nil {
+		return nil, err
+	}
+
 	var direction entity.Direction
 	switch req.Direction {
 	case pb.Direction_UP:
@@ -81,8 +135,27 @@ func (h *GameHandler) Move(ctx context.Context, req *pb.MoveRequest) (*pb.MoveRe
 	}, nil
 }
 
-// GetGameState 获取游戏状态
+// GetGameState 获取游戏状态。实时状态只返回给对局中的玩家和内部服务，
+// 其他人拿到与观战流相同的延迟画面
 func (h *GameHandler) GetGameState(ctx context.Context, req *pb.GetGameStateRequest) (*pb.GetGameStateResponse, error) {
+	callerID, err := auth.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if callerID != "" {
+		isPlayer, err := h.usecase.IsPlayer(ctx, req.RoomId, callerID)
+		if err != nil {
+			return &pb.GetGameStateResponse{
+				Success: false,
+				Message: err.Error(),
+			}, nil
+		}
+		if !isPlayer {
+			return h.spectatorGameState(ctx, req.RoomId)
+		}
+	}
+
 	gameState, err := h.usecase.GetGameState(ctx, req.RoomId)
 	if err != nil {
 		return &pb.GetGameStateResponse{
@@ -101,54 +174,340 @@ func (h *GameHandler) GetGameState(ctx context.Context, req *pb.GetGameStateRequ
 	// 转换内部实体到协议缓冲区消息
 	pbSnakes := make([]*pb.GameSnake, 0, len(gameState.Snakes))
 	for _, snake := range gameState.Snakes {
-		pbSegments := make([]*pb.SnakeSegment, len(snake.Segments))
-		for i, segment := range snake.Segments {
-			pbSegments[i] = &pb.SnakeSegment{
-				Position: &pb.Position{
-					X: segment.Position.X,
-					Y: segment.Position.Y,
-				},
+		pbSnakes = append(pbSnakes, toPbSnake(snake))
+	}
+	pbFoods := toPbPositions(gameState.Foods)
+	pbWalls := toPbPositions(gameState.Walls)
+
+	return &pb.GetGameStateResponse{
+		Success: true,
+		Message: "Game state retrieved successfully",
+		Snakes:  pbSnakes,
+		Foods:   pbFoods,
+		Walls:   pbWalls,
+		Status:  gameState.Status,
+		Options: toPbGameOptions(gameState.Options),
+		SpectatorCount: int32(h.usecase.SpectatorCount(req.RoomId)),
+	}, nil
+}
+
+// spectatorGameState 返回非玩家可见的延迟游戏状态
+func (h *GameHandler) spectatorGameState(ctx context.Context, roomID string) (*pb.GetGameStateResponse, error) {
+	update, err := h.usecase.SpectatorState(ctx, roomID)
+	if err != nil {
+		return &pb.GetGameStateResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	pbUpdate := toPbGameUpdate(update)
+	return &pb.GetGameStateResponse{
+		Success:        true,
+		Message:        "Game state retrieved successfully",
+		Snakes:         pbUpdate.Snakes,
+		Foods:          pbUpdate.Foods,
+		Walls:          pbUpdate.Walls,
+		Status:         pbUpdate.Status,
+		Options:        pbUpdate.Options,
+		SpectatorCount: int32(h.usecase.SpectatorCount(roomID)),
+	}, nil
+}
+
+// SubscribeGameUpdates 订阅游戏状态更新。实时更新只推送给对局中的玩家，
+// 其他人通过 SpectateGame 观看延迟后的画面
+func (h *GameHandler) SubscribeGameUpdates(req *pb.SubscribeGameUpdatesRequest, stream pb.GameService_SubscribeGameUpdatesServer) error {
+	if err := auth.Authorize(stream.Context(), req.PlayerId); err != nil {
+		return err
+	}
+
+	isPlayer, err := h.usecase.IsPlayer(stream.Context(), req.RoomId, req.PlayerId)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if !isPlayer {
+		return status.Error(codes.PermissionDenied, "only players can subscribe to live updates")
+	}
+
+	updates, cancel, err := h.usecase.SubscribeGameUpdates(stream.Context(), req.RoomId)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				// 游戏结束或被删除
+				return nil
+			}
+			if err := stream.Send(toPbGameUpdate(update)); err != nil {
+				return err
 			}
 		}
+	}
+}
 
-		pbSnakes = append(pbSnakes, &pb.GameSnake{
-			PlayerId:  snake.PlayerID,
-			Segments:  pbSegments,
-			Color:     snake.Color,
-			Length:    int32(snake.Length),
-			Score:     int32(snake.Score),
-		})
+// SpectateGame 以观战者身份订阅游戏更新
+func (h *GameHandler) SpectateGame(req *pb.SpectateGameRequest, stream pb.GameService_SpectateGameServer) error {
+	if err := auth.Authorize(stream.Context(), req.SpectatorId); err != nil {
+		return err
+	}
+
+	if req.SpectatorId == "" {
+		return status.Error(codes.InvalidArgument, "missing spectator id")
+	}
+
+	updates, cancel, err := h.usecase.SpectateGame(stream.Context(), req.RoomId, req.SpectatorId)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
 	}
+	defer cancel()
 
-	pbFoods := make([]*pb.Position, len(gameState.Foods))
-	for i, food := range gameState.Foods {
-		pbFoods[i] = &pb.Position{
-			X: food.X,
-			Y: food.Y,
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(toPbGameUpdate(update)); err != nil {
+				return err
+			}
 		}
 	}
+}
 
-	pbWalls := make([]*pb.Position, len(gameState.Walls))
-	for i, wall := range gameState.Walls {
-		pbWalls[i] = &pb.Position{
-			X: wall.X,
-			Y: wall.Y,
+// GetActiveGames 查询玩家当前所在的进行中对局。仅供好友服务等内部服务调用，避免泄露任意用户所在的房间
+func (h *GameHandler) GetActiveGames(ctx context.Context, req *pb.GetActiveGamesRequest) (*pb.GetActiveGamesResponse, error) {
+	if err := auth.RequireInternal(ctx); err != nil {
+		return nil, err
+	}
+
+	active, err := h.usecase.GetActiveGames(ctx, req.PlayerIds)
+	if err != nil {
+		return &pb.GetActiveGamesResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	games := make([]*pb.ActiveGame, 0, len(active))
+	for _, playerID := range req.PlayerIds {
+		roomID, exists := active[playerID]
+		if !exists {
+			continue
 		}
+		games = append(games, &pb.ActiveGame{
+			PlayerId:       playerID,
+			RoomId:         roomID,
+			SpectatorCount: int32(h.usecase.SpectatorCount(roomID)),
+		})
 	}
 
-	return &pb.GetGameStateResponse{
+	return &pb.GetActiveGamesResponse{
 		Success: true,
-		Message: "Game state retrieved successfully",
-		Snakes:  pbSnakes,
-		Foods:   pbFoods,
-		Walls:   pbWalls,
-		Status:  gameState.Status,
+		Message: "Active games retrieved successfully",
+		Games:   games,
 	}, nil
 }
 
-// SubscribeGameUpdates 订阅游戏状态更新
-func (h *GameHandler) SubscribeGameUpdates(req *pb.SubscribeGameUpdatesRequest, stream pb.GameService_SubscribeGameUpdatesServer) error {
-	// 这里应该实现 WebSocket 或流式更新逻辑
-	// 为简化，暂时返回未实现
+// GetMatchHistory 获取玩家的历史对局
+func (h *GameHandler) GetMatchHistory(ctx context.Context, req *pb.GetMatchHistoryRequest) (*pb.GetMatchHistoryResponse, error) {
+	records, total, err := h.usecase.GetMatchHistory(ctx, req.UserId, int(req.Page), int(req.PageSize))
+	if err != nil {
+		return &pb.GetMatchHistoryResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	pbMatches := make([]*pb.MatchRecord, len(records))
+	for i, record := range records {
+		pbMatches[i] = toPbMatchRecord(record)
+	}
+
+	return &pb.GetMatchHistoryResponse{
+		Success: true,
+		Message: "Match history retrieved successfully",
+		Matches: pbMatches,
+		Total:   int32(total),
+	}, nil
+}
+
+// GetMatch 获取单局对局记录
+func (h *GameHandler) GetMatch(ctx context.Context, req *pb.GetMatchRequest) (*pb.GetMatchResponse, error) {
+	record, err := h.usecase.GetMatch(ctx, req.MatchId)
+	if err != nil {
+		return &pb.GetMatchResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetMatchResponse{
+		Success: true,
+		Message: "Match retrieved successfully",
+		Match:   toPbMatchRecord(record),
+	}, nil
+}
+
+// GetReplay 获取对局回放数据
+func (h *GameHandler) GetReplay(ctx context.Context, req *pb.GetReplayRequest) (*pb.GetReplayResponse, error) {
+	replay, err := h.usecase.GetReplay(ctx, req.MatchId)
+	if err != nil {
+		return &pb.GetReplayResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	inputs := make([]*pb.ReplayInput, len(replay.Inputs))
+	for i, input := range replay.Inputs {
+		inputs[i] = &pb.ReplayInput{
+			Tick:      input.Tick,
+			Type:      input.Type,
+			PlayerId:  input.PlayerID,
+			Direction: pb.Direction(input.Direction),
+		}
+	}
+
+	return &pb.GetReplayResponse{
+		Success: true,
+		Message: "Replay retrieved successfully",
+		Replay: &pb.Replay{
+			MatchId:      replay.MatchID,
+			RoomId:       replay.RoomID,
+			Seed:         replay.Seed,
+			HeadOnRule:   replay.HeadOnRule,
+			InitialState: toPbGameUpdate(replay.Initial.NewUpdate("game_state", nil)),
+			Inputs:       inputs,
+			FinalTick:    replay.FinalTick,
+		},
+	}, nil
+}
+
+// StreamReplay 推送对局回放帧
+func (h *GameHandler) StreamReplay(req *pb.StreamReplayRequest, stream pb.GameService_StreamReplayServer) error {
+	frames, err := h.usecase.StreamReplay(stream.Context(), req.MatchId, req.Speed)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	for frame := range frames {
+		if err := stream.Send(toPbGameUpdate(frame)); err != nil {
+			return err
+		}
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+// toPbGameUpdate 转换游戏更新
+func toPbGameUpdate(update *entity.GameUpdate) *pb.GameUpdate {
+	pbSnakes := make([]*pb.GameSnake, len(update.Snakes))
+	for i, snake := range update.Snakes {
+		pbSnakes[i] = toPbSnake(snake)
+	}
+
+	return &pb.GameUpdate{
+		Type:              update.Type,
+		Snakes:            pbSnakes,
+		Foods:             toPbPositions(update.Foods),
+		Walls:             toPbPositions(update.Walls),
+		EliminatedPlayers: update.EliminatedPlayers,
+		WinnerPlayerId:    update.WinnerPlayerID,
+		Status:            update.Status,
+		Options:           toPbGameOptions(update.Options),
+		Tick:              update.Tick,
+		SpectatorCount:    int32(update.SpectatorCount),
+	}
+}
+
+// toPbSnake 转换蛇实体
+func toPbSnake(snake *entity.GameSnake) *pb.GameSnake {
+	pbSegments := make([]*pb.SnakeSegment, len(snake.Segments))
+	for i, segment := range snake.Segments {
+		pbSegments[i] = &pb.SnakeSegment{
+			Position: &pb.Position{
+				X: segment.Position.X,
+				Y: segment.Position.Y,
+			},
+		}
+	}
+
+	return &pb.GameSnake{
+		PlayerId: snake.PlayerID,
+		Segments: pbSegments,
+		Color:    snake.Color,
+		Length:   int32(snake.Length),
+		Score:    int32(snake.Score),
+		Alive:    snake.Alive,
+	}
+}
+
+// toPbPositions 转换坐标列表
+func toPbPositions(positions []entity.Position) []*pb.Position {
+	pbPositions := make([]*pb.Position, len(positions))
+	for i, position := range positions {
+		pbPositions[i] = &pb.Position{
+			X: position.X,
+			Y: position.Y,
+		}
+	}
+	return pbPositions
+}
+
+
+// toEntityGameOptions 转换游戏选项，未设置的字段保持零值由业务层填充默认值
+func toEntityGameOptions(options *pb.GameOptions) entity.GameOptions {
+	return entity.GameOptions{
+		BoardWidth:   options.GetBoardWidth(),
+		BoardHeight:  options.GetBoardHeight(),
+		FoodCount:    int(options.GetFoodCount()),
+		TickInterval: time.Duration(options.GetTickIntervalMs()) * time.Millisecond,
+		WallMode:     options.GetWallMode(),
+		WallEnabled:  options.GetWallEnabled(),
+		MaxScore:     int(options.GetMaxScore()),
+		TimeLimit:    time.Duration(options.GetTimeLimitSeconds()) * time.Second,
+	}
+}
+
+// toPbGameOptions 转换游戏选项
+func toPbGameOptions(options entity.GameOptions) *pb.GameOptions {
+	return &pb.GameOptions{
+		BoardWidth:       options.BoardWidth,
+		BoardHeight:      options.BoardHeight,
+		FoodCount:        int32(options.FoodCount),
+		TickIntervalMs:   int32(options.TickInterval / time.Millisecond),
+		WallMode:         options.WallMode,
+		WallEnabled:      options.WallEnabled,
+		MaxScore:         int32(options.MaxScore),
+		TimeLimitSeconds: int32(options.TimeLimit / time.Second),
+	}
+}
+
+// toPbMatchRecord 转换对局记录
+func toPbMatchRecord(record *entity.GameRecord) *pb.MatchRecord {
+	players := make([]*pb.MatchPlayerResult, len(record.Players))
+	for i, player := range record.Players {
+		players[i] = &pb.MatchPlayerResult{
+			PlayerId:  player.PlayerID,
+			Score:     int32(player.Score),
+			Placement: int32(player.Placement),
+			Length:    int32(player.Length),
+		}
+	}
+	return &pb.MatchRecord{
+		MatchId:        record.ID,
+		RoomId:         record.RoomID,
+		Players:        players,
+		WinnerPlayerId: record.WinnerID,
+		DurationMs:     record.Duration.Milliseconds(),
+		StartedAt:      record.StartedAt.Unix(),
+		EndedAt:        record.EndedAt.Unix(),
+	}
+}
@@ -52,4 +52,15 @@ func (r *gameMemoryRepository) DeleteGame(ctx context.Context, roomID string) er
 	
 	delete(r.games, roomID)
 	return nil
+}
+
+func (r *gameMemoryRepository) ListGames(ctx context.Context) ([]*entity.GameState, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	games := make([]*entity.GameState, 0, len(r.games))
+	for _, gameState := range r.games {
+		games = append(games, gameState)
+	}
+	return games, nil
 }
\ No newline at end of file
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"snake-game/game/domain/entity"
+	"snake-game/mongodb"
+)
+
+type gameRecordRepositoryImpl struct {
+	collection *mongo.Collection
+	replays    *mongo.Collection
+}
+
+func NewGameRecordRepository() *gameRecordRepositoryImpl {
+	return &gameRecordRepositoryImpl{
+		collection: mongodb.DB.Collection(mongodb.GameRecordCollection),
+		replays:    mongodb.DB.Collection(mongodb.ReplayCollection),
+	}
+}
+
+func (r *gameRecordRepositoryImpl) SaveRecord(ctx context.Context, record *entity.GameRecord) error {
+	// 重复写入同一对局时覆盖，保证结算重试是幂等的
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": record.ID}, toRecordModel(record), options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *gameRecordRepositoryImpl) GetRecord(ctx context.Context, matchID string) (*entity.GameRecord, error) {
+	var model mongodb.GameRecord
+	err := r.collection.FindOne(ctx, bson.M{"_id": matchID}).Decode(&model)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toRecordEntity(&model), nil
+}
+
+func (r *gameRecordRepositoryImpl) GetRecordsByPlayer(ctx context.Context, playerID string, offset, limit int64) ([]*entity.GameRecord, int64, error) {
+	filter := bson.M{"players.player_id": playerID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	findOptions.SetSkip(offset)
+	findOptions.SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []*mongodb.GameRecord
+	if err = cursor.All(ctx, &models); err != nil {
+		return nil, 0, err
+	}
+
+	records := make([]*entity.GameRecord, len(models))
+	for i, model := range models {
+		records[i] = toRecordEntity(model)
+	}
+	return records, total, nil
+}
+
+func (r *gameRecordRepositoryImpl) SaveReplay(ctx context.Context, replay *entity.Replay) error {
+	_, err := r.replays.ReplaceOne(ctx, bson.M{"_id": replay.MatchID}, replay, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *gameRecordRepositoryImpl) GetReplay(ctx context.Context, matchID string) (*entity.Replay, error) {
+	var replay entity.Replay
+	err := r.replays.FindOne(ctx, bson.M{"_id": matchID}).Decode(&replay)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &replay, nil
+}
+
+func toRecordModel(record *entity.GameRecord) *mongodb.GameRecord {
+	players := make([]mongodb.PlayerGameResult, len(record.Players))
+	scores := make(map[string]int, len(record.Players))
+	for i, player := range record.Players {
+		players[i] = mongodb.PlayerGameResult{
+			PlayerID: player.PlayerID,
+			Score:    player.Score,
+			Rank:     player.Placement,
+			Length:   player.Length,
+		}
+		scores[player.PlayerID] = player.Score
+	}
+
+	return &mongodb.GameRecord{
+		ID:        record.ID,
+		RoomID:    record.RoomID,
+		Players:   players,
+		WinnerID:  record.WinnerID,
+		Scores:    scores,
+		GameTime:  record.Duration,
+		StartedAt: record.StartedAt,
+		CreatedAt: record.EndedAt,
+	}
+}
+
+func toRecordEntity(model *mongodb.GameRecord) *entity.GameRecord {
+	players := make([]entity.PlayerResult, len(model.Players))
+	for i, player := range model.Players {
+		players[i] = entity.PlayerResult{
+			PlayerID:  player.PlayerID,
+			Score:     player.Score,
+			Placement: player.Rank,
+			Length:    player.Length,
+		}
+	}
+
+	return &entity.GameRecord{
+		ID:        model.ID,
+		RoomID:    model.RoomID,
+		Players:   players,
+		WinnerID:  model.WinnerID,
+		Duration:  model.GameTime,
+		StartedAt: model.StartedAt,
+		EndedAt:   model.CreatedAt,
+	}
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"snake-game/game/domain/entity"
+)
+
+// 每个订阅者的缓冲区大小，消费过慢时丢弃最旧的更新
+const subscriberBufferSize = 16
+
+// subscriber 单个订阅者，更新通过带缓冲的通道投递
+type subscriber struct {
+	updates   chan *entity.GameUpdate
+	spectator bool // 观战者，计入房间的观战人数
+}
+
+// timedUpdate 带发布时间的更新
+type timedUpdate struct {
+	update      *entity.GameUpdate
+	publishedAt time.Time
+}
+
+// broadcaster 按房间向订阅者扇出游戏更新，发布永不阻塞模拟循环。
+// 同时保留最近 delay 内发布的更新，用于给非玩家提供延迟后的画面
+type broadcaster struct {
+	rooms   map[string]map[*subscriber]struct{}
+	history map[string][]timedUpdate
+	delay   time.Duration
+	mutex   sync.Mutex
+}
+
+func newBroadcaster(delay time.Duration) *broadcaster {
+	return &broadcaster{
+		rooms:   make(map[string]map[*subscriber]struct{}),
+		history: make(map[string][]timedUpdate),
+		delay:   delay,
+	}
+}
+
+// subscribe 注册房间订阅者。seed 不为空时在持有锁的情况下生成并投递首条更新，
+// 参数为包含该订阅者在内的观战人数；这样首条更新与 closeRoom 互斥，不会投递到已关闭的通道。
+// seed 中不能再调用 broadcaster 的方法
+func (b *broadcaster) subscribe(roomID string, spectator bool, seed func(spectators int) *entity.GameUpdate) *subscriber {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	sub := &subscriber{
+		updates:   make(chan *entity.GameUpdate, subscriberBufferSize),
+		spectator: spectator,
+	}
+	if b.rooms[roomID] == nil {
+		b.rooms[roomID] = make(map[*subscriber]struct{})
+	}
+	b.rooms[roomID][sub] = struct{}{}
+	if seed != nil {
+		deliver(sub, seed(b.spectatorCountLocked(roomID)))
+	}
+	return sub
+}
+
+// unsubscribe 移除订阅者并关闭其通道，可重复调用
+func (b *broadcaster) unsubscribe(roomID string, sub *subscriber) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	subs, exists := b.rooms[roomID]
+	if !exists {
+		return
+	}
+	if _, exists := subs[sub]; !exists {
+		return
+	}
+	delete(subs, sub)
+	close(sub.updates)
+	if len(subs) == 0 {
+		delete(b.rooms, roomID)
+	}
+}
+
+// spectatorCount 返回房间当前的观战人数
+func (b *broadcaster) spectatorCount(roomID string) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.spectatorCountLocked(roomID)
+}
+
+func (b *broadcaster) spectatorCountLocked(roomID string) int {
+	count := 0
+	for sub := range b.rooms[roomID] {
+		if sub.spectator {
+			count++
+		}
+	}
+	return count
+}
+
+// publish 向房间所有订阅者投递更新
+func (b *broadcaster) publish(roomID string, update *entity.GameUpdate) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for sub := range b.rooms[roomID] {
+		deliver(sub, update)
+	}
+	b.record(roomID, update, time.Now())
+}
+
+// record 保存发布的更新，只保留延迟窗口内的更新和窗口外最新的一条，调用方需持有锁
+func (b *broadcaster) record(roomID string, update *entity.GameUpdate, now time.Time) {
+	history := append(b.history[roomID], timedUpdate{update: update, publishedAt: now})
+	cutoff := now.Add(-b.delay)
+	expired := 0
+	for expired+1 < len(history) && !history[expired+1].publishedAt.After(cutoff) {
+		expired++
+	}
+	b.history[roomID] = history[expired:]
+}
+
+// delayed 返回至少 delay 之前发布的最新一条更新，还没有足够早的更新时返回 nil
+func (b *broadcaster) delayed(roomID string) *entity.GameUpdate {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// 窗口在上次发布后可能已经前移，不能只看第一条
+	cutoff := time.Now().Add(-b.delay)
+	var latest *entity.GameUpdate
+	for _, entry := range b.history[roomID] {
+		if entry.publishedAt.After(cutoff) {
+			break
+		}
+		latest = entry.update
+	}
+	return latest
+}
+
+// closeRoom 关闭房间的所有订阅（游戏结束或被删除时调用）
+func (b *broadcaster) closeRoom(roomID string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for sub := range b.rooms[roomID] {
+		close(sub.updates)
+	}
+	delete(b.rooms, roomID)
+	delete(b.history, roomID)
+}
+
+// deliver 非阻塞投递；缓冲区已满时丢弃最旧的一条，保证订阅者总能拿到最新状态
+func deliver(sub *subscriber, update *entity.GameUpdate) {
+	select {
+	case sub.updates <- update:
+		return
+	default:
+	}
+
+	select {
+	case <-sub.updates:
+	default:
+	}
+
+	select {
+	case sub.updates <- update:
+	default:
+	}
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"sort"
+
+	"snake-game/game/domain/entity"
+)
+
+// resolveCollisions 基于本帧移动前的状态同时判定所有蛇的死亡，返回被淘汰的玩家ID（已排序）。
+// growing 标记本帧会吃到食物的蛇，这些蛇的尾巴不会让出格子。
+func resolveCollisions(game *entity.GameState, playerIDs []string, newHeads map[string]entity.Position, growing map[string]bool, headOnRule string) []string {
+	dead := make(map[string]bool)
+
+	// 移动后仍被身体占据的格子（不含新蛇头）
+	occupied := make(map[entity.Position]bool)
+	for _, playerID := range playerIDs {
+		segments := game.Snakes[playerID].Segments
+		if !growing[playerID] {
+			segments = segments[:len(segments)-1]
+		}
+		for _, segment := range segments {
+			occupied[segment.Position] = true
+		}
+	}
+
+	walls := make(map[entity.Position]bool, len(game.Walls))
+	for _, wall := range game.Walls {
+		walls[wall] = true
+	}
+
+	for _, playerID := range playerIDs {
+		head := newHeads[playerID]
+		switch {
+		case head.X < 0 || head.X >= game.Options.BoardWidth || head.Y < 0 || head.Y >= game.Options.BoardHeight:
+			// 撞到边界
+			dead[playerID] = true
+		case walls[head]:
+			// 撞墙
+			dead[playerID] = true
+		case occupied[head]:
+			// 咬到自己或撞到其他蛇的身体
+			dead[playerID] = true
+		}
+	}
+
+	// 蛇头相撞：多条蛇同时进入同一格
+	heads := make(map[entity.Position][]string)
+	for _, playerID := range playerIDs {
+		heads[newHeads[playerID]] = append(heads[newHeads[playerID]], playerID)
+	}
+	groups := make([][]string, 0)
+	for _, colliding := range heads {
+		if len(colliding) > 1 {
+			groups = append(groups, colliding)
+		}
+	}
+
+	// 蛇头互换位置（迎面穿过）同样视为蛇头相撞
+	for i, a := range playerIDs {
+		for _, b := range playerIDs[i+1:] {
+			if newHeads[a] == game.Snakes[b].Segments[0].Position &&
+				newHeads[b] == game.Snakes[a].Segments[0].Position {
+				groups = append(groups, []string{a, b})
+			}
+		}
+	}
+
+	for _, colliding := range groups {
+		survivor := ""
+		if headOnRule == HeadOnLongerSurvives {
+			survivor = longestSnake(game, colliding)
+		}
+		for _, playerID := range colliding {
+			if playerID != survivor {
+				dead[playerID] = true
+			}
+		}
+	}
+
+	eliminated := make([]string, 0, len(dead))
+	for playerID := range dead {
+		eliminated = append(eliminated, playerID)
+	}
+	sort.Strings(eliminated)
+	return eliminated
+}
+
+// longestSnake 返回最长的蛇，最长的不止一条时返回空（全部死亡）
+func longestSnake(game *entity.GameState, playerIDs []string) string {
+	longest := ""
+	maxLength := -1
+	tie := false
+	for _, playerID := range playerIDs {
+		length := game.Snakes[playerID].Length
+		if length > maxLength {
+			longest = playerID
+			maxLength = length
+			tie = false
+		} else if length == maxLength {
+			tie = true
+		}
+	}
+	if tie {
+		return ""
+	}
+	return longest
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"snake-game/game/domain/entity"
+)
+
+// testSnake 按从蛇头到蛇尾的顺序创建一条存活的蛇
+func testSnake(playerID string, direction entity.Direction, cells ...entity.Position) *entity.GameSnake {
+	segments := make([]entity.SnakeSegment, len(cells))
+	for i, cell := range cells {
+		segments[i] = entity.SnakeSegment{Position: cell}
+	}
+	return &entity.GameSnake{
+		PlayerID:  playerID,
+		Segments:  segments,
+		Length:    len(cells),
+		Alive:     true,
+		Direction: direction,
+	}
+}
+
+// testGame 创建 10x10 的棋盘，放入给定的蛇
+func testGame(snakes ...*entity.GameSnake) *entity.GameState {
+	game := &entity.GameState{
+		RoomID: "room",
+		Snakes: make(map[string]*entity.GameSnake, len(snakes)),
+		Options: entity.GameOptions{
+			BoardWidth:   10,
+			BoardHeight:  10,
+			TickInterval: 100 * time.Millisecond,
+			WallMode:     entity.WallModeSolid,
+		},
+		Status:      "playing",
+		PlayerCount: len(snakes),
+	}
+	for _, snake := range snakes {
+		game.Snakes[snake.PlayerID] = snake
+	}
+	game.Reseed(1)
+	return game
+}
+
+func pos(x, y int32) entity.Position {
+	return entity.Position{X: x, Y: y}
+}
+
+func TestResolveCollisions(t *testing.T) {
+	tests := []struct {
+		name       string
+		snakes     []*entity.GameSnake
+		walls      []entity.Position
+		wallMode   string
+		growing    map[string]bool
+		headOnRule string
+		want       []string
+	}{
+		{
+			name:   "free move",
+			snakes: []*entity.GameSnake{testSnake("a", entity.Direction_RIGHT, pos(5, 5), pos(4, 5))},
+			want:   []string{},
+		},
+		{
+			name:   "board edge",
+			snakes: []*entity.GameSnake{testSnake("a", entity.Direction_LEFT, pos(0, 5))},
+			want:   []string{"a"},
+		},
+		{
+			name:     "wrap mode passes the edge",
+			snakes:   []*entity.GameSnake{testSnake("a", entity.Direction_LEFT, pos(0, 5))},
+			wallMode: entity.WallModeWrap,
+			want:     []string{},
+		},
+		{
+			name:   "obstacle wall",
+			snakes: []*entity.GameSnake{testSnake("a", entity.Direction_RIGHT, pos(5, 5))},
+			walls:  []entity.Position{pos(6, 5)},
+			want:   []string{"a"},
+		},
+		{
+			name: "bites itself",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_UP, pos(5, 5), pos(6, 5), pos(6, 4), pos(5, 4), pos(4, 4)),
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "follows its own tail",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_UP, pos(5, 5), pos(6, 5), pos(6, 4), pos(5, 4)),
+			},
+			want: []string{},
+		},
+		{
+			name: "tail stays while growing",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_UP, pos(5, 5), pos(6, 5), pos(6, 4), pos(5, 4)),
+			},
+			growing: map[string]bool{"a": true},
+			want:    []string{"a"},
+		},
+		{
+			name: "hits another body",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_RIGHT, pos(3, 5)),
+				testSnake("b", entity.Direction_UP, pos(4, 4), pos(4, 5), pos(4, 6)),
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "head-on into the same cell, both die",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_RIGHT, pos(3, 5), pos(2, 5), pos(1, 5)),
+				testSnake("b", entity.Direction_LEFT, pos(5, 5)),
+			},
+			headOnRule: HeadOnBothDie,
+			want:       []string{"a", "b"},
+		},
+		{
+			name: "head-on into the same cell, longer survives",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_RIGHT, pos(3, 5), pos(2, 5), pos(1, 5)),
+				testSnake("b", entity.Direction_LEFT, pos(5, 5)),
+			},
+			headOnRule: HeadOnLongerSurvives,
+			want:       []string{"b"},
+		},
+		{
+			name: "head-on with equal length, both die",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_RIGHT, pos(3, 5), pos(2, 5)),
+				testSnake("b", entity.Direction_LEFT, pos(5, 5), pos(6, 5)),
+			},
+			headOnRule: HeadOnLongerSurvives,
+			want:       []string{"a", "b"},
+		},
+		{
+			name: "heads swap places",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_RIGHT, pos(4, 5)),
+				testSnake("b", entity.Direction_LEFT, pos(5, 5)),
+			},
+			headOnRule: HeadOnBothDie,
+			want:       []string{"a", "b"},
+		},
+		{
+			name: "heads swap places, longer survives",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_RIGHT, pos(4, 5), pos(3, 5), pos(2, 5)),
+				testSnake("b", entity.Direction_LEFT, pos(5, 5)),
+			},
+			headOnRule: HeadOnLongerSurvives,
+			want:       []string{"b"},
+		},
+		{
+			name: "three heads meet",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_RIGHT, pos(4, 5), pos(3, 5), pos(2, 5)),
+				testSnake("b", entity.Direction_LEFT, pos(6, 5)),
+				testSnake("c", entity.Direction_DOWN, pos(5, 4)),
+			},
+			headOnRule: HeadOnLongerSurvives,
+			want:       []string{"b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := testGame(tt.snakes...)
+			game.Walls = tt.walls
+			if tt.wallMode != "" {
+				game.Options.WallMode = tt.wallMode
+			}
+
+			playerIDs := make([]string, 0, len(tt.snakes))
+			newHeads := make(map[string]entity.Position, len(tt.snakes))
+			for _, snake := range tt.snakes {
+				playerIDs = append(playerIDs, snake.PlayerID)
+				newHeads[snake.PlayerID] = nextPosition(game, snake.Segments[0].Position, snake.Direction)
+			}
+
+			got := resolveCollisions(game, playerIDs, newHeads, tt.growing, tt.headOnRule)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveCollisions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
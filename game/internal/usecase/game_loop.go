@@ -0,0 +1,457 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"snake-game/game/domain/entity"
+)
+
+// gameLoop 单个房间的模拟循环
+type gameLoop struct {
+	roomID   string
+	interval time.Duration
+	stop     chan struct{}
+}
+
+// startLoop 以房间配置的帧间隔启动模拟循环，已在运行则忽略
+func (uc *GameUsecase) startLoop(roomID string, interval time.Duration) {
+	uc.loopsMutex.Lock()
+	defer uc.loopsMutex.Unlock()
+
+	if _, exists := uc.loops[roomID]; exists {
+		return
+	}
+
+	loop := &gameLoop{
+		roomID:   roomID,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+	uc.loops[roomID] = loop
+	go uc.runLoop(loop)
+}
+
+// stopLoop 停止房间的模拟循环
+func (uc *GameUsecase) stopLoop(roomID string) {
+	uc.loopsMutex.Lock()
+	defer uc.loopsMutex.Unlock()
+
+	if loop, exists := uc.loops[roomID]; exists {
+		close(loop.stop)
+		delete(uc.loops, roomID)
+	}
+}
+
+// removeLoop 循环自然结束后从表中移除
+func (uc *GameUsecase) removeLoop(loop *gameLoop) {
+	uc.loopsMutex.Lock()
+	defer uc.loopsMutex.Unlock()
+
+	if uc.loops[loop.roomID] == loop {
+		delete(uc.loops, loop.roomID)
+	}
+}
+
+func (uc *GameUsecase) runLoop(loop *gameLoop) {
+	ticker := time.NewTicker(loop.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-loop.stop:
+			return
+		case <-ticker.C:
+			if !uc.tick(loop.roomID) {
+				uc.removeLoop(loop)
+				return
+			}
+		}
+	}
+}
+
+// tick 推进一帧并推送更新。返回 false 表示循环应结束
+func (uc *GameUsecase) tick(roomID string) bool {
+	ctx := context.Background()
+
+	game, err := uc.gameRepo.GetGame(ctx, roomID)
+	if err != nil || game == nil {
+		return false
+	}
+
+	game.Lock()
+	if game.Status != "playing" {
+		game.Unlock()
+		return false
+	}
+
+	eliminated, ateAny, finished := step(game, uc.config.HeadOnRule)
+	if finished {
+		uc.endGame(ctx, game)
+	}
+	game.UpdatedAt = time.Now()
+
+	update := game.NewUpdate(updateType(ateAny, finished), eliminated)
+	game.Unlock()
+	update.SpectatorCount = uc.broadcaster.spectatorCount(roomID)
+
+	uc.broadcaster.publish(roomID, update)
+	if finished {
+		uc.broadcaster.closeRoom(roomID)
+	}
+
+	if err := uc.gameRepo.UpdateGame(ctx, game); err != nil {
+		return false
+	}
+	return !finished
+}
+
+// step 模拟一帧：应用输入、判定碰撞、移动、吃食物，并判断游戏是否结束。
+// 结果只取决于当前状态和对局的随机数生成器，实时对局与回放共用。调用方需持有锁
+func step(game *entity.GameState, headOnRule string) (eliminated []string, ateAny bool, finished bool) {
+	game.Tick++
+
+	// 按玩家ID排序，保证每帧的结算顺序固定
+	playerIDs := make([]string, 0, len(game.Snakes))
+	for playerID, snake := range game.Snakes {
+		if snake.Alive {
+			playerIDs = append(playerIDs, playerID)
+		}
+	}
+	sort.Strings(playerIDs)
+
+	// 应用本帧输入并计算新蛇头
+	newHeads := make(map[string]entity.Position, len(playerIDs))
+	for _, playerID := range playerIDs {
+		snake := game.Snakes[playerID]
+		if snake.NextDirection != entity.Direction_NONE &&
+			!(snake.Length > 1 && snake.Direction.IsOpposite(snake.NextDirection)) {
+			snake.Direction = snake.NextDirection
+		}
+		snake.NextDirection = entity.Direction_NONE
+		newHeads[playerID] = nextPosition(game, snake.Segments[0].Position, snake.Direction)
+	}
+
+	// 判定哪些蛇本帧会吃到食物（吃到食物的蛇尾巴不会让出格子）
+	growing := make(map[string]bool, len(playerIDs))
+	for _, playerID := range playerIDs {
+		if foodIndex(game.Foods, newHeads[playerID]) >= 0 {
+			growing[playerID] = true
+		}
+	}
+
+	// 同时结算所有碰撞
+	eliminated = resolveCollisions(game, playerIDs, newHeads, growing, headOnRule)
+	for _, playerID := range eliminated {
+		snake := game.Snakes[playerID]
+		snake.Alive = false
+		snake.EliminatedAt = game.Tick
+	}
+
+	// 存活的蛇前进并吃食物
+	for _, playerID := range playerIDs {
+		snake := game.Snakes[playerID]
+		if !snake.Alive {
+			continue
+		}
+		newHead := newHeads[playerID]
+
+		ateFood := false
+		if i := foodIndex(game.Foods, newHead); i >= 0 {
+			ateFood = true
+			ateAny = true
+			game.Foods = append(game.Foods[:i], game.Foods[i+1:]...)
+			snake.Length++
+			snake.Score += 10
+		}
+
+		// 更新蛇的位置
+		newSegments := []entity.SnakeSegment{{Position: newHead}}
+		if ateFood {
+			newSegments = append(newSegments, snake.Segments...)
+		} else {
+			newSegments = append(newSegments, snake.Segments[:len(snake.Segments)-1]...)
+		}
+		snake.Segments = newSegments
+	}
+
+	// 补充被吃掉的食物（在所有蛇移动后生成，避免落在蛇身上）
+	for missing := game.Options.FoodCount - len(game.Foods); missing > 0; missing-- {
+		spawnFood(game)
+	}
+
+	return eliminated, ateAny, checkGameOver(game)
+}
+
+// updateType 返回一帧更新的类型
+func updateType(ateAny, finished bool) string {
+	if finished {
+		return "game_over"
+	}
+	if ateAny {
+		return "food_eaten"
+	}
+	return "snake_moved"
+}
+
+// checkGameOver 判断游戏是否结束并记录获胜者。
+// 多人游戏剩余不超过一条蛇时结束，唯一存活者获胜；单人游戏在蛇死亡时结束。
+// 是否多人按本局放置过的蛇数判断，其他玩家中途离开后剩下的玩家同样获胜。
+// 有存活者达到目标分数或对局超时时也会结束，由存活者中分数最高者获胜
+func checkGameOver(game *entity.GameState) bool {
+	alive := make([]string, 0, len(game.Snakes))
+	for playerID, snake := range game.Snakes {
+		if snake.Alive {
+			alive = append(alive, playerID)
+		}
+	}
+	sort.Strings(alive)
+
+	if game.Options.MaxScore > 0 {
+		if leader, score := topScorer(game, alive); score >= game.Options.MaxScore {
+			game.WinnerID = leader
+			return true
+		}
+	}
+
+	// 按帧数计时，与服务器负载和暂停无关
+	if game.Options.TimeLimit > 0 &&
+		time.Duration(game.Tick)*game.Options.TickInterval >= game.Options.TimeLimit {
+		game.WinnerID, _ = topScorer(game, alive)
+		return true
+	}
+
+	if game.PlayerCount > 1 {
+		if len(alive) > 1 {
+			return false
+		}
+		if len(alive) == 1 {
+			game.WinnerID = alive[0]
+		}
+		return true
+	}
+	return len(alive) == 0
+}
+
+// topScorer 返回分数最高的玩家及其分数，最高分不止一人时玩家为空（平局）
+func topScorer(game *entity.GameState, playerIDs []string) (string, int) {
+	leader := ""
+	maxScore := -1
+	for _, playerID := range playerIDs {
+		score := game.Snakes[playerID].Score
+		if score > maxScore {
+			leader = playerID
+			maxScore = score
+		} else if score == maxScore {
+			leader = ""
+		}
+	}
+	return leader, maxScore
+}
+
+// playerResults 计算对局名次：获胜者第一，其余存活者按分数排序，
+// 淘汰者按淘汰帧数排序（越晚淘汰名次越靠前），存活时离开的玩家排在最后，不能靠离开逃避评分。
+// 同分或同帧淘汰的玩家名次相同
+func playerResults(game *entity.GameState) []entity.PlayerResult {
+	type standing struct {
+		snake *entity.GameSnake
+		class int   // 0 获胜者，1 存活，2 已淘汰，3 认输
+		value int64 // 同类中越大越靠前
+	}
+
+	standings := make([]standing, 0, len(game.Snakes))
+	for playerID, snake := range game.Snakes {
+		switch {
+		case playerID == game.WinnerID:
+			standings = append(standings, standing{snake: snake, class: 0})
+		case snake.Alive:
+			standings = append(standings, standing{snake: snake, class: 1, value: int64(snake.Score)})
+		case snake.Forfeited:
+			standings = append(standings, standing{snake: snake, class: 3, value: snake.EliminatedAt})
+		default:
+			standings = append(standings, standing{snake: snake, class: 2, value: snake.EliminatedAt})
+		}
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].class != standings[j].class {
+			return standings[i].class < standings[j].class
+		}
+		if standings[i].value != standings[j].value {
+			return standings[i].value > standings[j].value
+		}
+		return standings[i].snake.PlayerID < standings[j].snake.PlayerID
+	})
+
+	results := make([]entity.PlayerResult, len(standings))
+	for i, s := range standings {
+		placement := i + 1
+		if i > 0 && s.class == standings[i-1].class && s.value == standings[i-1].value {
+			placement = results[i-1].Placement
+		}
+		results[i] = entity.PlayerResult{
+			PlayerID:  s.snake.PlayerID,
+			Score:     s.snake.Score,
+			Placement: placement,
+			Length:    s.snake.Length,
+		}
+	}
+	return results
+}
+
+// foodIndex 返回该位置食物的下标，没有食物返回 -1
+func foodIndex(foods []entity.Position, position entity.Position) int {
+	for i, food := range foods {
+		if food == position {
+			return i
+		}
+	}
+	return -1
+}
+
+// spawnFood 在空闲格子上随机生成一个食物，棋盘已满时放弃
+func spawnFood(game *entity.GameState) {
+	free := freePositions(game, 0)
+	if len(free) > 0 {
+		game.Foods = append(game.Foods, free[game.Rand().Intn(len(free))])
+	}
+}
+
+// spawnRoster 为开局的所有玩家分配出生点：按行均匀分布、左右两侧交替，
+// 左侧的蛇向右、右侧的蛇向左；预设位置被占用时退回随机出生点
+func spawnRoster(game *entity.GameState, playerIDs []string) error {
+	rows := int32((len(playerIDs) + 1) / 2)
+	for i, playerID := range playerIDs {
+		if _, exists := game.Snakes[playerID]; exists {
+			return fmt.Errorf("duplicate player %s", playerID)
+		}
+
+		position := entity.Position{
+			X: spawnMargin,
+			Y: (int32(i/2) + 1) * game.Options.BoardHeight / (rows + 1),
+		}
+		direction := entity.Direction_RIGHT
+		if i%2 == 1 {
+			position.X = game.Options.BoardWidth - 1 - spawnMargin
+			direction = entity.Direction_LEFT
+		}
+
+		occupied := occupiedPositions(game)
+		if occupied[position] || !clearAhead(game, occupied, position, direction) {
+			var ok bool
+			if position, ok = spawnPosition(game); !ok {
+				return errors.New("no free spawn position")
+			}
+			direction = entity.Direction_RIGHT
+		}
+		game.Snakes[playerID] = newSnake(game, playerID, position, direction)
+		game.PlayerCount++
+	}
+	return nil
+}
+
+// spawnPosition 为新加入的蛇选择出生点：第一条蛇优先出生在棋盘中心，
+// 否则随机选择远离边界、且前方（向右）留有空间的空闲格子
+func spawnPosition(game *entity.GameState) (entity.Position, bool) {
+	occupied := occupiedPositions(game)
+
+	center := entity.Position{X: game.Options.BoardWidth / 2, Y: game.Options.BoardHeight / 2}
+	if len(game.Snakes) == 0 && !occupied[center] && clearAhead(game, occupied, center, entity.Direction_RIGHT) {
+		return center, true
+	}
+
+	candidates := make([]entity.Position, 0)
+	for _, position := range freePositions(game, spawnMargin) {
+		if clearAhead(game, occupied, position, entity.Direction_RIGHT) {
+			candidates = append(candidates, position)
+		}
+	}
+	if len(candidates) == 0 {
+		return entity.Position{}, false
+	}
+	return candidates[game.Rand().Intn(len(candidates))], true
+}
+
+// clearAhead 判断出生点沿初始方向 spawnMargin 格内是否无遮挡
+func clearAhead(game *entity.GameState, occupied map[entity.Position]bool, position entity.Position, direction entity.Direction) bool {
+	for i := 0; i < spawnMargin; i++ {
+		position = nextPosition(game, position, direction)
+		if occupied[position] {
+			return false
+		}
+	}
+	return true
+}
+
+// newSnake 在出生点创建一条长度为 1 的蛇
+func newSnake(game *entity.GameState, playerID string, position entity.Position, direction entity.Direction) *entity.GameSnake {
+	return &entity.GameSnake{
+		PlayerID:  playerID,
+		Segments:  []entity.SnakeSegment{{Position: position}},
+		Color:     fmt.Sprintf("#%06x", game.Rand().Intn(0xffffff)),
+		Length:    1,
+		Score:     0,
+		Alive:     true,
+		Direction: direction,
+	}
+}
+
+// freePositions 返回未被存活的蛇、墙和食物占据的格子，margin 为与边界的最小距离
+func freePositions(game *entity.GameState, margin int32) []entity.Position {
+	occupied := occupiedPositions(game)
+
+	free := make([]entity.Position, 0, game.Options.BoardWidth*game.Options.BoardHeight)
+	for x := margin; x < game.Options.BoardWidth-margin; x++ {
+		for y := margin; y < game.Options.BoardHeight-margin; y++ {
+			position := entity.Position{X: x, Y: y}
+			if !occupied[position] {
+				free = append(free, position)
+			}
+		}
+	}
+	return free
+}
+
+// occupiedPositions 返回被存活的蛇、墙和食物占据的格子
+func occupiedPositions(game *entity.GameState) map[entity.Position]bool {
+	occupied := make(map[entity.Position]bool)
+	for _, snake := range game.Snakes {
+		if !snake.Alive {
+			continue
+		}
+		for _, segment := range snake.Segments {
+			occupied[segment.Position] = true
+		}
+	}
+	for _, wall := range game.Walls {
+		occupied[wall] = true
+	}
+	for _, food := range game.Foods {
+		occupied[food] = true
+	}
+	return occupied
+}
+
+// nextPosition 计算沿方向前进一格后的位置，穿墙模式下越过边界的位置回绕到对边
+func nextPosition(game *entity.GameState, head entity.Position, direction entity.Direction) entity.Position {
+	next := head
+	switch direction {
+	case entity.Direction_UP:
+		next.Y--
+	case entity.Direction_DOWN:
+		next.Y++
+	case entity.Direction_LEFT:
+		next.X--
+	case entity.Direction_RIGHT:
+		next.X++
+	}
+
+	if game.Options.WallMode == entity.WallModeWrap {
+		width, height := game.Options.BoardWidth, game.Options.BoardHeight
+		next.X = (next.X + width) % width
+		next.Y = (next.Y + height) % height
+	}
+	return next
+}
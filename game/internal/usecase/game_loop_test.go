@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"snake-game/game/domain/entity"
+)
+
+func TestStep(t *testing.T) {
+	tests := []struct {
+		name           string
+		snakes         []*entity.GameSnake
+		next           map[string]entity.Direction
+		foods          []entity.Position
+		headOnRule     string
+		wantHeads      map[string]entity.Position
+		wantLengths    map[string]int
+		wantEliminated []string
+		wantAte        bool
+		wantFinished   bool
+		wantWinner     string
+	}{
+		{
+			name:        "moves forward",
+			snakes:      []*entity.GameSnake{testSnake("a", entity.Direction_RIGHT, pos(5, 5), pos(4, 5))},
+			wantHeads:   map[string]entity.Position{"a": pos(6, 5)},
+			wantLengths: map[string]int{"a": 2},
+		},
+		{
+			name:        "applies the queued direction",
+			snakes:      []*entity.GameSnake{testSnake("a", entity.Direction_RIGHT, pos(5, 5), pos(4, 5))},
+			next:        map[string]entity.Direction{"a": entity.Direction_UP},
+			wantHeads:   map[string]entity.Position{"a": pos(5, 4)},
+			wantLengths: map[string]int{"a": 2},
+		},
+		{
+			name:        "ignores reversing",
+			snakes:      []*entity.GameSnake{testSnake("a", entity.Direction_RIGHT, pos(5, 5), pos(4, 5))},
+			next:        map[string]entity.Direction{"a": entity.Direction_LEFT},
+			wantHeads:   map[string]entity.Position{"a": pos(6, 5)},
+			wantLengths: map[string]int{"a": 2},
+		},
+		{
+			name:        "eats food and grows",
+			snakes:      []*entity.GameSnake{testSnake("a", entity.Direction_RIGHT, pos(5, 5), pos(4, 5))},
+			foods:       []entity.Position{pos(6, 5)},
+			wantHeads:   map[string]entity.Position{"a": pos(6, 5)},
+			wantLengths: map[string]int{"a": 3},
+			wantAte:     true,
+		},
+		{
+			name:           "single player dies at the edge",
+			snakes:         []*entity.GameSnake{testSnake("a", entity.Direction_LEFT, pos(0, 5))},
+			wantEliminated: []string{"a"},
+			wantFinished:   true,
+		},
+		{
+			name: "last survivor wins",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_LEFT, pos(0, 5)),
+				testSnake("b", entity.Direction_RIGHT, pos(5, 5)),
+			},
+			wantHeads:      map[string]entity.Position{"b": pos(6, 5)},
+			wantEliminated: []string{"a"},
+			wantFinished:   true,
+			wantWinner:     "b",
+		},
+		{
+			name: "head-on ends in a draw",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_RIGHT, pos(4, 5)),
+				testSnake("b", entity.Direction_LEFT, pos(6, 5)),
+			},
+			headOnRule:     HeadOnBothDie,
+			wantEliminated: []string{"a", "b"},
+			wantFinished:   true,
+		},
+		{
+			name: "longer snake survives a head-on",
+			snakes: []*entity.GameSnake{
+				testSnake("a", entity.Direction_RIGHT, pos(4, 5), pos(3, 5)),
+				testSnake("b", entity.Direction_LEFT, pos(6, 5)),
+			},
+			headOnRule:     HeadOnLongerSurvives,
+			wantHeads:      map[string]entity.Position{"a": pos(5, 5)},
+			wantEliminated: []string{"b"},
+			wantFinished:   true,
+			wantWinner:     "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := testGame(tt.snakes...)
+			game.Foods = append([]entity.Position(nil), tt.foods...)
+			game.Options.FoodCount = len(tt.foods)
+			for playerID, direction := range tt.next {
+				game.Snakes[playerID].NextDirection = direction
+			}
+
+			eliminated, ate, finished := step(game, tt.headOnRule)
+
+			if game.Tick != 1 {
+				t.Errorf("Tick = %d, want 1", game.Tick)
+			}
+			if tt.wantEliminated == nil {
+				tt.wantEliminated = []string{}
+			}
+			if !reflect.DeepEqual(eliminated, tt.wantEliminated) {
+				t.Errorf("eliminated = %v, want %v", eliminated, tt.wantEliminated)
+			}
+			if ate != tt.wantAte {
+				t.Errorf("ate = %v, want %v", ate, tt.wantAte)
+			}
+			if finished != tt.wantFinished {
+				t.Errorf("finished = %v, want %v", finished, tt.wantFinished)
+			}
+			if game.WinnerID != tt.wantWinner {
+				t.Errorf("WinnerID = %q, want %q", game.WinnerID, tt.wantWinner)
+			}
+			for playerID, head := range tt.wantHeads {
+				if got := game.Snakes[playerID].Segments[0].Position; got != head {
+					t.Errorf("head of %s = %v, want %v", playerID, got, head)
+				}
+			}
+			for playerID, length := range tt.wantLengths {
+				snake := game.Snakes[playerID]
+				if snake.Length != length || len(snake.Segments) != length {
+					t.Errorf("length of %s = %d (%d segments), want %d", playerID, snake.Length, len(snake.Segments), length)
+				}
+			}
+			for _, playerID := range eliminated {
+				snake := game.Snakes[playerID]
+				if snake.Alive || snake.EliminatedAt != 1 {
+					t.Errorf("%s: Alive = %v, EliminatedAt = %d, want eliminated at tick 1", playerID, snake.Alive, snake.EliminatedAt)
+				}
+			}
+			if len(game.Foods) != len(tt.foods) {
+				t.Errorf("food count = %d, want %d", len(game.Foods), len(tt.foods))
+			}
+		})
+	}
+}
+
+func TestCheckGameOver(t *testing.T) {
+	// alive/dead 构造指定分数的蛇
+	alive := func(playerID string, score int) *entity.GameSnake {
+		snake := testSnake(playerID, entity.Direction_RIGHT, pos(1, 1))
+		snake.Score = score
+		return snake
+	}
+	dead := func(playerID string, score int) *entity.GameSnake {
+		snake := alive(playerID, score)
+		snake.Alive = false
+		return snake
+	}
+
+	tests := []struct {
+		name         string
+		snakes       []*entity.GameSnake
+		playerCount  int
+		tick         int64
+		maxScore     int
+		timeLimit    time.Duration
+		wantFinished bool
+		wantWinner   string
+	}{
+		{
+			name:        "single player still alive",
+			snakes:      []*entity.GameSnake{alive("a", 0)},
+			playerCount: 1,
+		},
+		{
+			name:         "single player died",
+			snakes:       []*entity.GameSnake{dead("a", 30)},
+			playerCount:  1,
+			wantFinished: true,
+		},
+		{
+			name:        "two players alive",
+			snakes:      []*entity.GameSnake{alive("a", 0), alive("b", 0)},
+			playerCount: 2,
+		},
+		{
+			name:         "one of two left",
+			snakes:       []*entity.GameSnake{alive("a", 0), dead("b", 50)},
+			playerCount:  2,
+			wantFinished: true,
+			wantWinner:   "a",
+		},
+		{
+			name:         "nobody left",
+			snakes:       []*entity.GameSnake{dead("a", 0), dead("b", 0)},
+			playerCount:  2,
+			wantFinished: true,
+		},
+		{
+			// 另一名玩家的蛇已不在棋盘上，仍按多人规则由剩下的玩家获胜
+			name:         "multiplayer game down to one snake on the board",
+			snakes:       []*entity.GameSnake{alive("a", 0)},
+			playerCount:  2,
+			wantFinished: true,
+			wantWinner:   "a",
+		},
+		{
+			name:        "below max score",
+			snakes:      []*entity.GameSnake{alive("a", 40), alive("b", 10)},
+			playerCount: 2,
+			maxScore:    50,
+		},
+		{
+			name:         "max score reached",
+			snakes:       []*entity.GameSnake{alive("a", 50), alive("b", 10)},
+			playerCount:  2,
+			maxScore:     50,
+			wantFinished: true,
+			wantWinner:   "a",
+		},
+		{
+			name:         "max score ignores eliminated players",
+			snakes:       []*entity.GameSnake{dead("a", 60), alive("b", 10), alive("c", 0)},
+			playerCount:  3,
+			maxScore:     50,
+			wantFinished: false,
+		},
+		{
+			name:        "before the time limit",
+			snakes:      []*entity.GameSnake{alive("a", 20), alive("b", 10)},
+			playerCount: 2,
+			tick:        9,
+			timeLimit:   time.Second,
+		},
+		{
+			name:         "time limit reached",
+			snakes:       []*entity.GameSnake{alive("a", 20), alive("b", 10)},
+			playerCount:  2,
+			tick:         10,
+			timeLimit:    time.Second,
+			wantFinished: true,
+			wantWinner:   "a",
+		},
+		{
+			name:         "time limit reached with tied scores",
+			snakes:       []*entity.GameSnake{alive("a", 20), alive("b", 20)},
+			playerCount:  2,
+			tick:         10,
+			timeLimit:    time.Second,
+			wantFinished: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := testGame(tt.snakes...)
+			game.PlayerCount = tt.playerCount
+			game.Tick = tt.tick
+			game.Options.MaxScore = tt.maxScore
+			game.Options.TimeLimit = tt.timeLimit
+
+			if finished := checkGameOver(game); finished != tt.wantFinished {
+				t.Errorf("checkGameOver() = %v, want %v", finished, tt.wantFinished)
+			}
+			if game.WinnerID != tt.wantWinner {
+				t.Errorf("WinnerID = %q, want %q", game.WinnerID, tt.wantWinner)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"snake-game/game/domain/entity"
+)
+
+// 未指定时使用的默认配置
+const (
+	defaultBoardSize = 20
+	defaultFoodCount = 5
+)
+
+// 配置的取值范围，房间服务创建房间时按相同的范围校验
+const (
+	minBoardSize    = 10
+	maxBoardSize    = 60
+	maxFoodCount    = 50
+	minTickInterval = 50 * time.Millisecond
+	maxTickInterval = time.Second
+	maxTimeLimit    = time.Hour
+)
+
+// 启用障碍墙时，每多少个格子放置一块墙
+const cellsPerWall = 50
+
+// normalizeOptions 为未设置的字段填充默认值并校验取值范围
+func (uc *GameUsecase) normalizeOptions(options entity.GameOptions) (entity.GameOptions, error) {
+	if options.BoardWidth == 0 {
+		options.BoardWidth = defaultBoardSize
+	}
+	if options.BoardHeight == 0 {
+		options.BoardHeight = defaultBoardSize
+	}
+	if options.FoodCount == 0 {
+		options.FoodCount = defaultFoodCount
+	}
+	if options.TickInterval == 0 {
+		options.TickInterval = uc.config.TickInterval
+	}
+	if options.WallMode == "" {
+		options.WallMode = entity.WallModeSolid
+	}
+
+	if options.BoardWidth < minBoardSize || options.BoardWidth > maxBoardSize ||
+		options.BoardHeight < minBoardSize || options.BoardHeight > maxBoardSize {
+		return options, fmt.Errorf("board size must be between %d and %d", minBoardSize, maxBoardSize)
+	}
+	if options.FoodCount < 1 || options.FoodCount > maxFoodCount {
+		return options, fmt.Errorf("food count must be between 1 and %d", maxFoodCount)
+	}
+	if options.TickInterval < minTickInterval || options.TickInterval > maxTickInterval {
+		return options, fmt.Errorf("tick interval must be between %v and %v", minTickInterval, maxTickInterval)
+	}
+	if options.WallMode != entity.WallModeSolid && options.WallMode != entity.WallModeWrap {
+		return options, errors.New("wall mode must be solid or wrap")
+	}
+	if options.MaxScore < 0 {
+		return options, errors.New("max score must not be negative")
+	}
+	if options.TimeLimit < 0 || options.TimeLimit > maxTimeLimit {
+		return options, fmt.Errorf("time limit must be between 0 and %v", maxTimeLimit)
+	}
+	return options, nil
+}
+
+// newGameState 按配置生成等待玩家加入的空棋盘
+func newGameState(roomID string, options entity.GameOptions) *entity.GameState {
+	game := &entity.GameState{
+		ID:        fmt.Sprintf("game_%s", roomID),
+		RoomID:    roomID,
+		Snakes:    make(map[string]*entity.GameSnake),
+		Foods:     []entity.Position{},
+		Walls:     []entity.Position{},
+		Status:    "waiting",
+		Options:   options,
+		UpdatedAt: time.Now(),
+	}
+	if options.WallEnabled {
+		spawnWalls(game)
+	}
+	for i := 0; i < options.FoodCount; i++ {
+		spawnFood(game)
+	}
+	return game
+}
+
+// spawnWalls 在空闲格子上随机放置障碍墙（不贴边界），出生点会避开墙
+func spawnWalls(game *entity.GameState) {
+	count := int(game.Options.BoardWidth*game.Options.BoardHeight) / cellsPerWall
+	for i := 0; i < count; i++ {
+		free := freePositions(game, 1)
+		if len(free) == 0 {
+			return
+		}
+		game.Walls = append(game.Walls, free[game.Rand().Intn(len(free))])
+	}
+}
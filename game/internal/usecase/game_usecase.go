@@ -5,25 +5,48 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"math/rand"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"snake-game/auth"
 	"snake-game/game/domain/entity"
 	"snake-game/game/domain/repository"
 	pb "snake-game/proto"
 )
 
+// 出生点与边界的最小距离
+const spawnMargin = 3
+
+// 蛇头相撞的判定规则
+const (
+	HeadOnBothDie        = "both_die"        // 双方都死亡
+	HeadOnLongerSurvives = "longer_survives" // 较长的一方存活，等长则都死亡
+)
+
+// GameConfig 游戏引擎配置
+type GameConfig struct {
+	TickInterval   time.Duration // 房间未指定时使用的帧间隔
+	HeadOnRule     string        // 蛇头相撞规则
+	SpectatorDelay time.Duration // 观战画面的延迟，防止观战者给玩家报点
+}
+
 type GameUsecase struct {
 	gameRepo     repository.GameRepository
+	recordRepo   repository.GameRecordRepository
 	leaderboardClient pb.LeaderboardServiceClient
+	roomClient   pb.RoomServiceClient
+	config       GameConfig
+	loops        map[string]*gameLoop // 房间ID -> 模拟循环
+	loopsMutex   sync.Mutex
+	broadcaster  *broadcaster         // 游戏更新的房间级扇出
 }
 
-func NewGameUsecase(gameRepo repository.GameRepository) *GameUsecase {
+func NewGameUsecase(gameRepo repository.GameRepository, recordRepo repository.GameRecordRepository, config GameConfig) *GameUsecase {
 	// 连接到排行榜服务
-	conn, err := grpc.Dial("localhost:50054", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial("localhost:50054", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
 	if err != nil {
 		log.Printf("Failed to connect to leaderboard service: %v", err)
 		return nil
@@ -31,12 +54,75 @@ func NewGameUsecase(gameRepo repository.GameRepository) *GameUsecase {
 
 	leaderboardClient := pb.NewLeaderboardServiceClient(conn)
 
+	// 连接到房间服务，用于上报对局结果
+	roomConn, err := grpc.Dial("localhost:50053", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
+	if err != nil {
+		log.Printf("Failed to connect to room service: %v", err)
+		return nil
+	}
+
+	roomClient := pb.NewRoomServiceClient(roomConn)
+
 	return &GameUsecase{
 		gameRepo:          gameRepo,
+		recordRepo:        recordRepo,
 		leaderboardClient: leaderboardClient,
+		roomClient:        roomClient,
+		config:            config,
+		loops:             make(map[string]*gameLoop),
+		broadcaster:       newBroadcaster(config.SpectatorDelay),
 	}
 }
 
+// CreateGame 按房间配置创建游戏。提供玩家名单时一次性放置所有玩家并立即开始，
+// 否则创建空棋盘由玩家通过 JoinGame 加入。已结束的旧对局会被替换，进行中的对局不允许重复创建
+func (uc *GameUsecase) CreateGame(ctx context.Context, roomID string, options entity.GameOptions, playerIDs []string) error {
+	options, err := uc.normalizeOptions(options)
+	if err != nil {
+		return err
+	}
+
+	existing, err := uc.gameRepo.GetGame(ctx, roomID)
+	if err != nil {
+		return errors.New("failed to get game")
+	}
+	if existing != nil {
+		existing.Lock()
+		status := existing.Status
+		existing.Unlock()
+		if status != "finished" {
+			return errors.New("game already exists")
+		}
+		uc.stopLoop(roomID)
+		uc.broadcaster.closeRoom(roomID)
+		if err := uc.gameRepo.DeleteGame(ctx, roomID); err != nil {
+			return errors.New("failed to replace finished game")
+		}
+	}
+
+	game := newGameState(roomID, options)
+	if len(playerIDs) > 0 {
+		// 先放置全部玩家再保存，任何玩家无法放置时整局不创建
+		if err := spawnRoster(game, playerIDs); err != nil {
+			return err
+		}
+		game.RosterOnly = true
+		game.Status = "playing"
+		game.StartedAt = time.Now()
+		uc.startRecording(game)
+	}
+
+	if err := uc.gameRepo.CreateGame(ctx, game); err != nil {
+		return errors.New("failed to create game")
+	}
+	if game.Status == "playing" {
+		uc.startLoop(roomID, options.TickInterval)
+	}
+	return nil
+}
+
+// JoinGame 将玩家加入游戏，第一个玩家加入时开始模拟。
+// 未通过 CreateGame 预先创建的房间使用默认配置，按名单开局的对局只允许名单内的玩家
 func (uc *GameUsecase) JoinGame(ctx context.Context, roomID, playerID string) error {
 	game, err := uc.gameRepo.GetGame(ctx, roomID)
 	if err != nil {
@@ -44,44 +130,53 @@ func (uc *GameUsecase) JoinGame(ctx context.Context, roomID, playerID string) er
 	}
 
 	if game == nil {
-		// 如果游戏不存在，创建新游戏
-		game = &entity.GameState{
-			ID:     fmt.Sprintf("game_%s", roomID),
-			RoomID: roomID,
-			Snakes: make(map[string]*entity.GameSnake),
-			Foods:  []entity.Position{},
-			Walls:  []entity.Position{},
-			Status: "playing",
-		}
-		game.Snakes[playerID] = &entity.GameSnake{
-			PlayerID:  playerID,
-			Segments:  []entity.SnakeSegment{{Position: entity.Position{X: 10, Y: 10}}},
-			Color:     fmt.Sprintf("#%06x", rand.Intn(0xffffff)),
-			Length:    1,
-			Score:     0,
-			Alive:     true,
-			Direction: entity.Direction_RIGHT,
+		options, err := uc.normalizeOptions(entity.GameOptions{})
+		if err != nil {
+			return err
 		}
-		// 生成一些食物
-		for i := 0; i < 5; i++ {
-			game.Foods = append(game.Foods, entity.Position{X: int32(rand.Intn(20)), Y: int32(rand.Intn(20))})
+		game = newGameState(roomID, options)
+		if err := uc.gameRepo.CreateGame(ctx, game); err != nil {
+			return errors.New("failed to create game")
 		}
-		err = uc.gameRepo.CreateGame(ctx, game)
-	} else {
-		// 如果游戏存在，添加玩家
-		game.Snakes[playerID] = &entity.GameSnake{
-			PlayerID:  playerID,
-			Segments:  []entity.SnakeSegment{{Position: entity.Position{X: 10, Y: 10}}},
-			Color:     fmt.Sprintf("#%06x", rand.Intn(0xffffff)),
-			Length:    1,
-			Score:     0,
-			Alive:     true,
-			Direction: entity.Direction_RIGHT,
-		}
-		err = uc.gameRepo.UpdateGame(ctx, game)
 	}
 
-	return err
+	game.Lock()
+	if _, exists := game.Snakes[playerID]; exists {
+		// 开局时已由房间放置，重复加入（例如刷新页面）不做处理
+		game.Unlock()
+		return nil
+	}
+	if game.Status == "finished" {
+		game.Unlock()
+		return errors.New("game already finished")
+	}
+	if game.RosterOnly {
+		// 按房间或匹配名单开局的对局不允许名单外的玩家加入
+		game.Unlock()
+		return errors.New("player not in game roster")
+	}
+	if err := joinSnake(game, playerID); err != nil {
+		game.Unlock()
+		return err
+	}
+	recordInput(game, entity.InputJoin, playerID, entity.Direction_NONE)
+	start := game.Status == "waiting"
+	if start {
+		game.Status = "playing"
+		game.StartedAt = time.Now()
+		uc.startRecording(game)
+	}
+	interval := game.Options.TickInterval
+	game.Unlock()
+
+	if err := uc.gameRepo.UpdateGame(ctx, game); err != nil {
+		return err
+	}
+	if start {
+		// 由服务器按房间配置的频率推进游戏
+		uc.startLoop(roomID, interval)
+	}
+	return nil
 }
 
 func (uc *GameUsecase) LeaveGame(ctx context.Context, roomID, playerID string) error {
@@ -90,106 +185,195 @@ func (uc *GameUsecase) LeaveGame(ctx context.Context, roomID, playerID string) e
 		return errors.New("game not found")
 	}
 
-	// 从游戏中移除玩家
-	delete(game.Snakes, playerID)
+	game.Lock()
+	if game.Status == "playing" {
+		// 进行中的对局保留离开的玩家并按淘汰处理，对局记录仍包含所有玩家
+		if snake, exists := game.Snakes[playerID]; exists && !snake.Left {
+			recordInput(game, entity.InputLeave, playerID, entity.Direction_NONE)
+			leaveSnake(game, playerID)
+		}
+	} else {
+		delete(game.Snakes, playerID)
+	}
+	remaining := 0
+	for _, snake := range game.Snakes {
+		if !snake.Left {
+			remaining++
+		}
+	}
+
+	// 所有玩家都离开时照常结束对局，保存记录并通知房间服务重置房间状态
+	var update *entity.GameUpdate
+	if remaining == 0 && game.Status == "playing" {
+		game.WinnerID = ""
+		uc.endGame(ctx, game)
+		update = game.NewUpdate("game_over", nil)
+	}
+	game.Unlock()
 
 	// 检查是否还有其他玩家，如果没有则删除游戏
-	if len(game.Snakes) == 0 {
+	if remaining == 0 {
+		uc.stopLoop(roomID)
+		if update != nil {
+			uc.broadcaster.publish(roomID, update)
+		}
+		uc.broadcaster.closeRoom(roomID)
 		return uc.gameRepo.DeleteGame(ctx, roomID)
 	}
 
 	return uc.gameRepo.UpdateGame(ctx, game)
 }
 
+// Move 记录玩家的意图方向，蛇的实际移动由模拟循环在下一帧统一结算
 func (uc *GameUsecase) Move(ctx context.Context, roomID, playerID string, direction entity.Direction) error {
+	if direction == entity.Direction_NONE {
+		return errors.New("invalid direction")
+	}
+
 	game, err := uc.gameRepo.GetGame(ctx, roomID)
 	if err != nil || game == nil {
 		return errors.New("game not found")
 	}
 
+	game.Lock()
+	defer game.Unlock()
+
+	if game.Status != "playing" {
+		return errors.New("game is not running")
+	}
+
 	snake, exists := game.Snakes[playerID]
 	if !exists || !snake.Alive {
 		return errors.New("player not in game or dead")
 	}
 
-	// 更新蛇的方向
-	snake.Direction = direction
-
-	// 这里应该实现实际的游戏移动逻辑
-	// 为简化，只是更新蛇的位置
-	head := snake.Segments[0].Position
-	var newHead entity.Position
-
-	switch direction {
-	case entity.Direction_UP:
-		newHead = entity.Position{X: head.X, Y: head.Y - 1}
-	case entity.Direction_DOWN:
-		newHead = entity.Position{X: head.X, Y: head.Y + 1}
-	case entity.Direction_LEFT:
-		newHead = entity.Position{X: head.X - 1, Y: head.Y}
-	case entity.Direction_RIGHT:
-		newHead = entity.Position{X: head.X + 1, Y: head.Y}
-	default:
-		return errors.New("invalid direction")
+	// 不允许直接掉头
+	if snake.Length > 1 && snake.Direction.IsOpposite(direction) {
+		return errors.New("cannot reverse direction")
 	}
 
-	// 检查边界
-	if newHead.X < 0 || newHead.X >= 20 || newHead.Y < 0 || newHead.Y >= 20 {
-		snake.Alive = false
-		// 如果蛇死亡，检查是否所有人都死了，如果是则结束游戏
-		allDead := true
-		for _, s := range game.Snakes {
-			if s.Alive {
-				allDead = false
-				break
-			}
-		}
-		if allDead {
-			uc.endGame(ctx, game)
-		}
-		return uc.gameRepo.UpdateGame(ctx, game)
-	}
-
-	// 检查食物
-	ateFood := false
-	for i, food := range game.Foods {
-		if food.X == newHead.X && food.Y == newHead.Y {
-			// 吃到了食物
-			ateFood = true
-			// 移除被吃的食物
-			game.Foods = append(game.Foods[:i], game.Foods[i+1:]...)
-			// 生成新食物
-			game.Foods = append(game.Foods, entity.Position{
-				X: int32(rand.Intn(20)),
-				Y: int32(rand.Intn(20)),
-			})
-			snake.Length++
-			snake.Score += 10
-			break
-		}
+	// 同一帧内多次输入只保留最后一次
+	snake.NextDirection = direction
+	recordInput(game, entity.InputMove, playerID, direction)
+	return nil
+}
+
+func (uc *GameUsecase) GetGameState(ctx context.Context, roomID string) (*entity.GameState, error) {
+	game, err := uc.gameRepo.GetGame(ctx, roomID)
+	if err != nil || game == nil {
+		return game, err
 	}
+	// 返回快照，避免与模拟循环并发读写
+	return game.Clone(), nil
+}
 
-	// 更新蛇的位置
-	newSegments := []entity.SnakeSegment{{Position: newHead}}
-	if ateFood {
-		// 如果吃了食物，增加身体长度
-		newSegments = append(newSegments, snake.Segments...)
-	} else {
-		// 否则移动身体
-		newSegments = append(newSegments, snake.Segments[:len(snake.Segments)-1]...)
+// SubscribeGameUpdates 订阅房间的游戏更新。首条消息为当前完整状态，
+// 游戏结束后通道被关闭；返回的取消函数必须在订阅方退出时调用
+func (uc *GameUsecase) SubscribeGameUpdates(ctx context.Context, roomID string) (<-chan *entity.GameUpdate, func(), error) {
+	game, err := uc.gameRepo.GetGame(ctx, roomID)
+	if err != nil || game == nil {
+		return nil, nil, errors.New("game not found")
 	}
-	snake.Segments = newSegments
 
-	return uc.gameRepo.UpdateGame(ctx, game)
-}
+	finished := false
+	sub := uc.broadcaster.subscribe(roomID, false, func(spectators int) *entity.GameUpdate {
+		snapshot := game.Snapshot()
+		snapshot.SpectatorCount = spectators
+		if snapshot.Status == "finished" {
+			snapshot.Type = "game_over"
+			finished = true
+		}
+		return snapshot
+	})
 
-func (uc *GameUsecase) GetGameState(ctx context.Context, roomID string) (*entity.GameState, error) {
-	return uc.gameRepo.GetGame(ctx, roomID)
+	cancel := func() {
+		uc.broadcaster.unsubscribe(roomID, sub)
+	}
+	if finished {
+		cancel()
+	}
+	return sub.updates, cancel, nil
 }
 
-// endGame 结束游戏并更新排行榜
+// endGame 结束游戏，更新排行榜并通知房间服务
 func (uc *GameUsecase) endGame(ctx context.Context, game *entity.GameState) {
 	game.Status = "finished"
+
+	// 通知房间对局已结束，房间随后可以再来一局
+	go func(roomID, winnerID string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := uc.roomClient.ReportGameResult(ctx, &pb.ReportGameResultRequest{
+			RoomId:         roomID,
+			WinnerPlayerId: winnerID,
+		})
+		if err != nil {
+			log.Printf("Failed to report result of room %s: %v", roomID, err)
+		} else if !resp.Success {
+			log.Printf("Room %s rejected game result: %s", roomID, resp.Message)
+		}
+	}(game.RoomID, game.WinnerID)
+
+	// 对局ID包含开始时间，同一房间再来一局时不会与之前的对局混淆
+	now := time.Now()
+	record := &entity.GameRecord{
+		ID:        fmt.Sprintf("%s_%d", game.ID, game.StartedAt.UnixNano()),
+		RoomID:    game.RoomID,
+		Players:   playerResults(game),
+		WinnerID:  game.WinnerID,
+		Duration:  now.Sub(game.StartedAt),
+		StartedAt: game.StartedAt,
+		EndedAt:   now,
+	}
+
+	// 回放与对局记录使用同一个对局ID
+	replay := game.Replay
+	if replay != nil {
+		replay.MatchID = record.ID
+		replay.FinalTick = game.Tick
+	}
+
+	// 保存对局记录和回放，供玩家查看历史战绩
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := uc.recordRepo.SaveRecord(ctx, record); err != nil {
+			log.Printf("Failed to save record of match %s: %v", record.ID, err)
+		}
+		if replay != nil {
+			if err := uc.recordRepo.SaveReplay(ctx, replay); err != nil {
+				log.Printf("Failed to save replay of match %s: %v", record.ID, err)
+			}
+		}
+	}()
+
+	// 多人对局按名次结算评分
+	if len(record.Players) > 1 {
+		placements := make([]*pb.MatchPlacement, len(record.Players))
+		for i, player := range record.Players {
+			placements[i] = &pb.MatchPlacement{
+				UserId:    player.PlayerID,
+				Placement: int32(player.Placement),
+			}
+		}
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := uc.leaderboardClient.RecordMatchResult(ctx, &pb.RecordMatchResultRequest{
+				MatchId:    record.ID,
+				Placements: placements,
+			})
+			if err != nil {
+				log.Printf("Failed to record rating of match %s: %v", record.ID, err)
+			} else if !resp.Success {
+				log.Printf("Leaderboard rejected match %s: %s", record.ID, resp.Message)
+			}
+		}()
+	}
 	
 	// 更新每个玩家的分数到排行榜服务
 	for playerID, snake := range game.Snakes {
@@ -197,7 +381,7 @@ func (uc *GameUsecase) endGame(ctx context.Context, game *entity.GameState) {
 		req := &pb.UpdateScoreRequest{
 			UserId:  playerID,
 			Score:   int32(snake.Score),
-			GameWon: snake.Alive, // 如果蛇还活着，算作获胜
+			GameWon: playerID == game.WinnerID,
 		}
 		
 		// 在 goroutine 中异步更新排行榜，避免阻塞游戏
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"snake-game/game/domain/entity"
+)
+
+// 历史对局分页
+const (
+	defaultHistoryPageSize = 20
+	maxHistoryPageSize     = 50
+)
+
+// GetMatchHistory 分页获取玩家参与过的对局，最近结束的在前，page 从 1 开始
+func (uc *GameUsecase) GetMatchHistory(ctx context.Context, playerID string, page, pageSize int) ([]*entity.GameRecord, int64, error) {
+	if playerID == "" {
+		return nil, 0, errors.New("missing user id")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultHistoryPageSize
+	}
+	if pageSize > maxHistoryPageSize {
+		pageSize = maxHistoryPageSize
+	}
+
+	records, total, err := uc.recordRepo.GetRecordsByPlayer(ctx, playerID, int64((page-1)*pageSize), int64(pageSize))
+	if err != nil {
+		return nil, 0, errors.New("failed to get match history")
+	}
+	return records, total, nil
+}
+
+// GetMatch 获取单局对局记录
+func (uc *GameUsecase) GetMatch(ctx context.Context, matchID string) (*entity.GameRecord, error) {
+	record, err := uc.recordRepo.GetRecord(ctx, matchID)
+	if err != nil {
+		return nil, errors.New("failed to get match")
+	}
+	if record == nil {
+		return nil, errors.New("match not found")
+	}
+	return record, nil
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"snake-game/game/domain/entity"
+)
+
+// 回放的最大播放倍速
+const maxReplaySpeed = 16
+
+// startRecording 开局时重新设置随机种子并保存开局状态，之后影响模拟的输入都会记录下来。
+// 调用方需持有锁（或对局尚未被其他协程访问）
+func (uc *GameUsecase) startRecording(game *entity.GameState) {
+	game.Reseed(time.Now().UnixNano())
+	game.Replay = &entity.Replay{
+		RoomID:     game.RoomID,
+		Seed:       game.Seed,
+		HeadOnRule: uc.config.HeadOnRule,
+		Initial:    game.Copy(),
+		Inputs:     []entity.InputEvent{},
+	}
+}
+
+// recordInput 记录一次输入，只记录进行中的对局。同一帧内同一玩家的多次转向只保留最后一次，调用方需持有锁
+func recordInput(game *entity.GameState, inputType, playerID string, direction entity.Direction) {
+	if game.Replay == nil || game.Status != "playing" {
+		return
+	}
+
+	inputs := game.Replay.Inputs
+	if inputType == entity.InputMove && len(inputs) > 0 {
+		last := &inputs[len(inputs)-1]
+		if last.Tick == game.Tick && last.Type == entity.InputMove && last.PlayerID == playerID {
+			last.Direction = direction
+			return
+		}
+	}
+	game.Replay.Inputs = append(inputs, entity.InputEvent{
+		Tick:      game.Tick,
+		Type:      inputType,
+		PlayerID:  playerID,
+		Direction: direction,
+	})
+}
+
+// joinSnake 为中途加入的玩家放置一条蛇，实时对局与回放共用，调用方需持有锁
+func joinSnake(game *entity.GameState, playerID string) error {
+	spawn, ok := spawnPosition(game)
+	if !ok {
+		return errors.New("no free spawn position")
+	}
+	game.Snakes[playerID] = newSnake(game, playerID, spawn, entity.Direction_RIGHT)
+	game.PlayerCount++
+	return nil
+}
+
+// leaveSnake 玩家中途离开：保留蛇并按当前帧淘汰，对局记录仍包含该玩家。
+// 实时对局与回放共用，调用方需持有锁
+func leaveSnake(game *entity.GameState, playerID string) {
+	snake, exists := game.Snakes[playerID]
+	if !exists || snake.Left {
+		return
+	}
+	snake.Left = true
+	if snake.Alive {
+		snake.Forfeited = true
+		snake.Alive = false
+		snake.EliminatedAt = game.Tick
+	}
+}
+
+// applyInput 在回放中重现一次输入。输入在录制时已经过校验，这里只应用其效果
+func applyInput(game *entity.GameState, input entity.InputEvent) {
+	switch input.Type {
+	case entity.InputMove:
+		if snake, exists := game.Snakes[input.PlayerID]; exists && snake.Alive {
+			snake.NextDirection = input.Direction
+		}
+	case entity.InputJoin:
+		joinSnake(game, input.PlayerID)
+	case entity.InputLeave:
+		leaveSnake(game, input.PlayerID)
+	}
+}
+
+// replayer 从开局状态重新模拟一局
+type replayer struct {
+	replay *entity.Replay
+	game   *entity.GameState
+	next   int // 下一条待应用的输入
+}
+
+func newReplayer(replay *entity.Replay) *replayer {
+	game := replay.Initial.Copy()
+	game.Reseed(replay.Seed)
+	return &replayer{
+		replay: replay,
+		game:   game,
+	}
+}
+
+// initial 返回开局帧
+func (r *replayer) initial() *entity.GameUpdate {
+	return r.game.NewUpdate("game_state", nil)
+}
+
+// step 应用本帧之前的输入并模拟一帧，返回该帧的更新及回放是否已结束
+func (r *replayer) step() (*entity.GameUpdate, bool) {
+	inputs := r.replay.Inputs
+	for r.next < len(inputs) && inputs[r.next].Tick <= r.game.Tick {
+		applyInput(r.game, inputs[r.next])
+		r.next++
+	}
+
+	eliminated, ateAny, finished := step(r.game, r.replay.HeadOnRule)
+	// 记录的结束帧是上限，避免异常数据导致回放无法结束
+	if r.game.Tick >= r.replay.FinalTick {
+		finished = true
+	}
+	if finished {
+		r.game.Status = "finished"
+	}
+	return r.game.NewUpdate(updateType(ateAny, finished), eliminated), finished
+}
+
+// GetReplay 获取对局回放
+func (uc *GameUsecase) GetReplay(ctx context.Context, matchID string) (*entity.Replay, error) {
+	replay, err := uc.recordRepo.GetReplay(ctx, matchID)
+	if err != nil {
+		return nil, errors.New("failed to get replay")
+	}
+	if replay == nil || replay.Initial == nil {
+		return nil, errors.New("replay not found")
+	}
+	return replay, nil
+}
+
+// StreamReplay 重新模拟对局，按原始帧间隔除以倍速推送回放帧（首帧为开局状态）。
+// 回放结束或 ctx 结束时关闭通道
+func (uc *GameUsecase) StreamReplay(ctx context.Context, matchID string, speed float64) (<-chan *entity.GameUpdate, error) {
+	replay, err := uc.GetReplay(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if speed <= 0 {
+		speed = 1
+	}
+	if speed > maxReplaySpeed {
+		speed = maxReplaySpeed
+	}
+
+	r := newReplayer(replay)
+	interval := time.Duration(float64(r.game.Options.TickInterval) / speed)
+
+	frames := make(chan *entity.GameUpdate, 1)
+	go func() {
+		defer close(frames)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		frame, finished := r.initial(), false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case frames <- frame:
+			}
+			if finished {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				frame, finished = r.step()
+			}
+		}
+	}()
+	return frames, nil
+}
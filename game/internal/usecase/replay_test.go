@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"snake-game/game/domain/entity"
+)
+
+// TestReplayReproducesGame 录制一局包含转向、中途加入和离开的对局，
+// 回放后每一帧及最终状态都应与实时对局一致
+func TestReplayReproducesGame(t *testing.T) {
+	tests := []struct {
+		seed       int64
+		headOnRule string
+	}{
+		{2, HeadOnBothDie}, // 以蛇头相撞结束
+		{2, HeadOnLongerSurvives},
+		{6, HeadOnBothDie}, // 打满时长上限
+		{6, HeadOnLongerSurvives},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("seed %d %s", tt.seed, tt.headOnRule), func(t *testing.T) {
+			uc := &GameUsecase{config: GameConfig{HeadOnRule: tt.headOnRule}}
+			live, frames := recordGame(t, uc, tt.seed)
+
+			r := newReplayer(live.Replay)
+			if got := r.initial(); !reflect.DeepEqual(got, frames[0]) {
+				t.Fatalf("initial frame = %+v, want %+v", got, frames[0])
+			}
+			for i := 1; i < len(frames); i++ {
+				got, finished := r.step()
+				if !reflect.DeepEqual(got, frames[i]) {
+					t.Fatalf("frame %d = %+v, want %+v", i, got, frames[i])
+				}
+				if finished != (i == len(frames)-1) {
+					t.Fatalf("frame %d: finished = %v", i, finished)
+				}
+			}
+
+			replayed := r.game
+			if replayed.Tick != live.Tick || replayed.Status != live.Status || replayed.WinnerID != live.WinnerID {
+				t.Errorf("replay ended at tick %d (%s, winner %q), want tick %d (%s, winner %q)",
+					replayed.Tick, replayed.Status, replayed.WinnerID, live.Tick, live.Status, live.WinnerID)
+			}
+			if replayed.PlayerCount != live.PlayerCount {
+				t.Errorf("PlayerCount = %d, want %d", replayed.PlayerCount, live.PlayerCount)
+			}
+			if !reflect.DeepEqual(replayed.Snakes, live.Snakes) {
+				t.Errorf("snakes differ after replay")
+			}
+			if !reflect.DeepEqual(replayed.Foods, live.Foods) {
+				t.Errorf("Foods = %v, want %v", replayed.Foods, live.Foods)
+			}
+		})
+	}
+}
+
+// recordGame 按实时对局的流程模拟一局：第一名玩家加入时开始录制，之后由给定种子驱动
+// 玩家的转向、加入和离开，直到对局结束。返回结束时的对局及每一帧的更新（首帧为开局状态）
+func recordGame(t *testing.T, uc *GameUsecase, seed int64) (*entity.GameState, []*entity.GameUpdate) {
+	t.Helper()
+
+	// 与 newGameState 相同，但障碍墙和食物也由给定种子生成，使测试可重复
+	game := newGameState("room", entity.GameOptions{
+		BoardWidth:   20,
+		BoardHeight:  20,
+		TickInterval: 100 * time.Millisecond,
+		WallMode:     entity.WallModeSolid,
+		TimeLimit:    30 * time.Second,
+	})
+	game.Reseed(seed)
+	game.Options.WallEnabled = true
+	game.Options.FoodCount = 3
+	spawnWalls(game)
+	for i := 0; i < game.Options.FoodCount; i++ {
+		spawnFood(game)
+	}
+	join := func(playerID string) {
+		if err := joinSnake(game, playerID); err != nil {
+			t.Fatalf("joinSnake(%s) error = %v", playerID, err)
+		}
+		recordInput(game, entity.InputJoin, playerID, entity.Direction_NONE)
+	}
+
+	join("a")
+	game.Status = "playing"
+	uc.startRecording(game)
+	// 开局随机种子取自当前时间，这里同样固定下来
+	game.Reseed(seed)
+	game.Replay.Seed = seed
+	frames := []*entity.GameUpdate{game.NewUpdate("game_state", nil)}
+
+	players := rand.New(rand.NewSource(seed))
+	for game.Tick < 1000 {
+		switch game.Tick {
+		case 1:
+			join("b")
+		case 2:
+			join("c")
+		case 3:
+			recordInput(game, entity.InputLeave, "c", entity.Direction_NONE)
+			leaveSnake(game, "c")
+		}
+
+		// 出生点前方留有空位，开始转向前不会有玩家被淘汰
+		if game.Tick >= 3 {
+			steer(game, players)
+		}
+
+		eliminated, ateAny, finished := step(game, uc.config.HeadOnRule)
+		if finished {
+			game.Status = "finished"
+		}
+		frames = append(frames, game.NewUpdate(updateType(ateAny, finished), eliminated))
+		if finished {
+			break
+		}
+	}
+	if game.Status != "finished" {
+		t.Fatalf("game still running at tick %d", game.Tick)
+	}
+	game.Replay.FinalTick = game.Tick
+
+	kinds := make(map[string]bool)
+	for _, input := range game.Replay.Inputs {
+		kinds[input.Type] = true
+	}
+	if !kinds[entity.InputMove] || !kinds[entity.InputJoin] || !kinds[entity.InputLeave] {
+		t.Fatalf("recorded inputs %v, want moves, joins and a leave", game.Replay.Inputs)
+	}
+	return game, frames
+}
+
+var directions = []entity.Direction{entity.Direction_UP, entity.Direction_DOWN, entity.Direction_LEFT, entity.Direction_RIGHT}
+
+// steer 模拟玩家输入：前方受阻时转向空闲的格子，否则偶尔随机转向，有时同一帧内连续转向两次。
+// 输入经过与 Move 相同的校验：只接受存活玩家的非掉头方向
+func steer(game *entity.GameState, players *rand.Rand) {
+	blocked := make(map[entity.Position]bool)
+	for _, snake := range game.Snakes {
+		if snake.Alive {
+			for _, segment := range snake.Segments {
+				blocked[segment.Position] = true
+			}
+		}
+	}
+	for _, wall := range game.Walls {
+		blocked[wall] = true
+	}
+	free := func(position entity.Position) bool {
+		return position.X >= 0 && position.X < game.Options.BoardWidth &&
+			position.Y >= 0 && position.Y < game.Options.BoardHeight && !blocked[position]
+	}
+
+	for _, playerID := range []string{"a", "b", "c"} {
+		snake, exists := game.Snakes[playerID]
+		if !exists || !snake.Alive {
+			continue
+		}
+		head := snake.Segments[0].Position
+
+		safe := make([]entity.Direction, 0, len(directions))
+		for _, direction := range directions {
+			if !snake.Direction.IsOpposite(direction) && free(nextPosition(game, head, direction)) {
+				safe = append(safe, direction)
+			}
+		}
+		if len(safe) == 0 || (free(nextPosition(game, head, snake.Direction)) && players.Intn(5) != 0) {
+			continue
+		}
+
+		if players.Intn(4) == 0 {
+			first := directions[players.Intn(len(directions))]
+			if !snake.Direction.IsOpposite(first) {
+				snake.NextDirection = first
+				recordInput(game, entity.InputMove, playerID, first)
+			}
+		}
+		direction := safe[players.Intn(len(safe))]
+		snake.NextDirection = direction
+		recordInput(game, entity.InputMove, playerID, direction)
+	}
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"snake-game/game/domain/entity"
+)
+
+// SpectateGame 以观战者身份订阅房间的游戏更新，不会放置蛇。
+// 所有更新（包括首条完整状态）都延迟 SpectatorDelay 后送达；返回的取消函数必须在观战者退出时调用
+func (uc *GameUsecase) SpectateGame(ctx context.Context, roomID, spectatorID string) (<-chan *entity.GameUpdate, func(), error) {
+	game, err := uc.gameRepo.GetGame(ctx, roomID)
+	if err != nil || game == nil {
+		return nil, nil, errors.New("game not found")
+	}
+
+	game.Lock()
+	isPlayer := inGame(game, spectatorID)
+	status := game.Status
+	game.Unlock()
+	if isPlayer && status != "finished" {
+		return nil, nil, errors.New("players cannot spectate their own game")
+	}
+
+	finished := false
+	sub := uc.broadcaster.subscribe(roomID, true, func(spectators int) *entity.GameUpdate {
+		snapshot := game.Snapshot()
+		snapshot.SpectatorCount = spectators
+		if snapshot.Status == "finished" {
+			snapshot.Type = "game_over"
+			finished = true
+		}
+		return snapshot
+	})
+
+	cancel := func() {
+		uc.broadcaster.unsubscribe(roomID, sub)
+	}
+	if finished {
+		cancel()
+	}
+	return delayUpdates(ctx, sub.updates, uc.config.SpectatorDelay), cancel, nil
+}
+
+// IsPlayer 判断用户是否是房间当前对局中的玩家
+func (uc *GameUsecase) IsPlayer(ctx context.Context, roomID, userID string) (bool, error) {
+	game, err := uc.gameRepo.GetGame(ctx, roomID)
+	if err != nil || game == nil {
+		return false, errors.New("game not found")
+	}
+
+	game.Lock()
+	isPlayer := inGame(game, userID)
+	game.Unlock()
+	return isPlayer, nil
+}
+
+// inGame 判断用户是否仍在对局中，中途离开的玩家不再算作玩家，调用方需持有锁
+func inGame(game *entity.GameState, userID string) bool {
+	snake, exists := game.Snakes[userID]
+	return exists && !snake.Left
+}
+
+// SpectatorState 返回非玩家可以看到的游戏状态：进行中的对局返回 SpectatorDelay 之前的画面，
+// 与观战流保持一致；等待开始或已结束的对局返回当前状态。开局不足 SpectatorDelay 时还没有可公开的画面
+func (uc *GameUsecase) SpectatorState(ctx context.Context, roomID string) (*entity.GameUpdate, error) {
+	game, err := uc.gameRepo.GetGame(ctx, roomID)
+	if err != nil || game == nil {
+		return nil, errors.New("game not found")
+	}
+
+	snapshot := game.Snapshot()
+	if snapshot.Status != "playing" {
+		return snapshot, nil
+	}
+
+	update := uc.broadcaster.delayed(roomID)
+	if update == nil {
+		return nil, errors.New("game state not available yet")
+	}
+	return update, nil
+}
+
+// SpectatorCount 返回房间当前的观战人数
+func (uc *GameUsecase) SpectatorCount(roomID string) int {
+	return uc.broadcaster.spectatorCount(roomID)
+}
+
+// GetActiveGames 返回玩家ID到其所在进行中对局的房间ID，不在对局中的玩家不包含在结果中
+func (uc *GameUsecase) GetActiveGames(ctx context.Context, playerIDs []string) (map[string]string, error) {
+	games, err := uc.gameRepo.ListGames(ctx)
+	if err != nil {
+		return nil, errors.New("failed to list games")
+	}
+
+	wanted := make(map[string]bool, len(playerIDs))
+	for _, playerID := range playerIDs {
+		wanted[playerID] = true
+	}
+
+	active := make(map[string]string)
+	for _, game := range games {
+		game.Lock()
+		if game.Status == "playing" {
+			for playerID, snake := range game.Snakes {
+				if wanted[playerID] && !snake.Left {
+					active[playerID] = game.RoomID
+				}
+			}
+		}
+		game.Unlock()
+	}
+	return active, nil
+}
+
+// delayUpdates 将每条更新推迟 delay 后按原顺序转发。输入关闭后仍会送达已缓存的更新再关闭输出，
+// ctx 结束时立即退出
+func delayUpdates(ctx context.Context, in <-chan *entity.GameUpdate, delay time.Duration) <-chan *entity.GameUpdate {
+	if delay <= 0 {
+		return in
+	}
+
+	type pending struct {
+		update *entity.GameUpdate
+		due    time.Time
+	}
+
+	out := make(chan *entity.GameUpdate, subscriberBufferSize)
+	go func() {
+		defer close(out)
+
+		queue := make([]pending, 0)
+		for in != nil || len(queue) > 0 {
+			var due <-chan time.Time
+			if len(queue) > 0 {
+				due = time.After(time.Until(queue[0].due))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				queue = append(queue, pending{update: update, due: time.Now().Add(delay)})
+			case <-due:
+				select {
+				case <-ctx.Done():
+					return
+				case out <- queue[0].update:
+				}
+				queue = queue[1:]
+			}
+		}
+	}()
+	return out
+}
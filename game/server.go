@@ -3,20 +3,73 @@ package main
 import (
 	"log"
 	"net"
+	"os"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
+	"snake-game/auth"
 	grpc_handler "snake-game/game/internal/delivery/grpc"
 	"snake-game/game/internal/repository"
 	"snake-game/game/internal/usecase"
+	"snake-game/healthcheck"
+	"snake-game/mongodb"
 	pb "snake-game/proto"
 )
 
 func main() {
-	// 初始化仓库层（基于内存的游戏状态管理）
+	// 从环境变量获取 MongoDB URI
+	mongoURI := os.Getenv("MONGODB_URI")
+	if mongoURI == "" {
+		mongoURI = "mongodb://localhost:27017"
+	}
+
+	// 连接数据库，用于保存对局记录
+	err := mongodb.Connect(mongoURI)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer mongodb.Disconnect()
+
+	// 初始化仓库层（游戏状态在内存中管理，对局记录持久化到数据库）
 	gameRepo := repository.NewGameMemoryRepository()
+	recordRepo := repository.NewGameRecordRepository()
+
+	// 模拟循环帧间隔（毫秒），可通过环境变量调整
+	tickInterval := 200 * time.Millisecond
+	if value := os.Getenv("GAME_TICK_INTERVAL_MS"); value != "" {
+		ms, err := strconv.Atoi(value)
+		if err != nil || ms <= 0 {
+			log.Fatalf("Invalid GAME_TICK_INTERVAL_MS: %s", value)
+		}
+		tickInterval = time.Duration(ms) * time.Millisecond
+	}
+
+	// 蛇头相撞规则：both_die 或 longer_survives
+	headOnRule := os.Getenv("GAME_HEAD_ON_RULE")
+	if headOnRule == "" {
+		headOnRule = usecase.HeadOnBothDie
+	}
+	if headOnRule != usecase.HeadOnBothDie && headOnRule != usecase.HeadOnLongerSurvives {
+		log.Fatalf("Invalid GAME_HEAD_ON_RULE: %s", headOnRule)
+	}
+
+	// 观战画面延迟（毫秒），0 表示不延迟
+	spectatorDelay := 3 * time.Second
+	if value := os.Getenv("GAME_SPECTATOR_DELAY_MS"); value != "" {
+		ms, err := strconv.Atoi(value)
+		if err != nil || ms < 0 {
+			log.Fatalf("Invalid GAME_SPECTATOR_DELAY_MS: %s", value)
+		}
+		spectatorDelay = time.Duration(ms) * time.Millisecond
+	}
 
 	// 初始化业务逻辑层
-	gameUsecase := usecase.NewGameUsecase(gameRepo)
+	gameUsecase := usecase.NewGameUsecase(gameRepo, recordRepo, usecase.GameConfig{
+		TickInterval:   tickInterval,
+		HeadOnRule:     headOnRule,
+		SpectatorDelay: spectatorDelay,
+	})
 
 	// 初始化通信层
 	gameHandler := grpc_handler.NewGameHandler(gameUsecase)
@@ -27,9 +80,13 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	// 识别调用方，handler 据此校验操作权限
+	s := grpc.NewServer(auth.ServerOptions(auth.NewLobbyValidator())...)
 	pb.RegisterGameServiceServer(s, gameHandler)
 
+	// 标准健康检查，网关据此判断服务是否可用
+	healthcheck.Register(s, "game_service.GameService", mongodb.Ping)
+
 	log.Println("Game service is running on :50055")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
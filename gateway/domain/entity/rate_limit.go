@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// RateLimit 令牌桶限流策略
+type RateLimit struct {
+	Name     string  // 策略名，同一调用方在不同策略下使用独立的令牌桶
+	Capacity int     // 桶容量，即允许的突发请求数
+	Rate     float64 // 每秒补充的令牌数
+}
+
+// RateLimitResult 一次取令牌的结果
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int           // 本次请求后剩余的令牌数
+	RetryAfter time.Duration // 被拒绝时距离下一个令牌可用的时间
+	ResetAfter time.Duration // 距离令牌桶补满的时间
+}
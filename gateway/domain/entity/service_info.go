@@ -1,7 +1,29 @@
 package entity
 
+import (
+	"strings"
+	"time"
+)
+
 type ServiceInfo struct {
-	Name    string
-	Address string
-	Health  bool
-}
\ No newline at end of file
+	Name     string
+	Address  string // 多个实例以逗号分隔，网关在实例之间轮询
+	Health   bool
+	Critical bool // 关键依赖不可用时网关整体视为不健康
+
+	// 最近一次健康检查的结果
+	Latency     time.Duration
+	CheckedAt   time.Time
+	HealthError string
+}
+
+// Endpoints 返回服务的全部实例地址
+func (s *ServiceInfo) Endpoints() []string {
+	var endpoints []string
+	for _, address := range strings.Split(s.Address, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			endpoints = append(endpoints, address)
+		}
+	}
+	return endpoints
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"google.golang.org/grpc"
+
+	"snake-game/gateway/domain/entity"
+)
+
+// ConnectionManager 管理到后端服务的长连接。返回的连接由管理器负责关闭，调用方不能 Close
+type ConnectionManager interface {
+	GetConn(service *entity.ServiceInfo) (*grpc.ClientConn, error)
+	Close() error
+}
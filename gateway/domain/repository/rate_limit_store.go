@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"snake-game/gateway/domain/entity"
+)
+
+// RateLimitStore 保存令牌桶状态。Take 需要原子地完成补充和扣减，
+// 多个网关副本共享同一个存储（如 Redis）时限流才能全局生效
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitResult, error)
+}
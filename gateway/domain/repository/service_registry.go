@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"snake-game/gateway/domain/entity"
 )
 
+// ServiceRegistry 服务注册表。读取方法返回副本，修改需通过注册表的方法
 type ServiceRegistry interface {
 	RegisterService(ctx context.Context, service *entity.ServiceInfo) error
 	GetService(ctx context.Context, name string) (*entity.ServiceInfo, error)
 	GetAllServices(ctx context.Context) ([]*entity.ServiceInfo, error)
-	UpdateServiceHealth(ctx context.Context, name string, health bool) error
+	// UpdateServiceHealth 记录一次健康检查的结果，checkErr 为空表示检查通过
+	UpdateServiceHealth(ctx context.Context, name string, health bool, latency time.Duration, checkErr string) error
 }
\ No newline at end of file
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"snake-game/gateway/internal/usecase"
+)
+
+// publicRoutes 无需认证即可访问的路由
+var publicRoutes = map[string]bool{
+	"/health":            true,
+	"/discovery":         true,
+	"/auth/register":     true,
+	"/auth/login":        true,
+	"/auth/refreshToken": true,
+}
+
+// identityFields 代表调用者身份的请求字段，转发前改写为已认证的用户ID。
+// 同时覆盖驼峰和下划线两种写法
+var identityFields = []string{
+	"userId", "user_id",
+	"playerId", "player_id",
+	"senderId", "sender_id",
+}
+
+// AuthMiddleware 校验 Bearer 令牌，拒绝未认证的请求，
+// 并用令牌中的用户ID改写请求中的身份字段和 gRPC 元数据
+func (h *GatewayHandler) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 未匹配到路由的请求交给 Gin 返回 404
+		path := c.FullPath()
+		if path == "" || publicRoutes[path] {
+			c.Next()
+			return
+		}
+
+		userID, err := h.usecase.ValidateToken(c.Request.Context(), requestToken(c))
+		if err != nil {
+			// 大厅服务不可用时返回 503 等状态，而不是 401，避免客户端误以为登录已失效
+			h.usecase.WriteRPCError(c, "lobby", err)
+			c.Abort()
+			return
+		}
+
+		if err := overwriteIdentity(c, userID); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+			return
+		}
+
+		c.Set("userId", userID)
+		c.Request = c.Request.WithContext(usecase.WithIdentity(c.Request.Context(), userID))
+		c.Next()
+	}
+}
+
+// requestToken 读取访问令牌。EventSource 和 WebSocket 无法设置请求头，
+// 因此同时支持 token 查询参数
+func requestToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	}
+	// 不使用 c.Query，避免在改写查询参数之前建立 Gin 的查询缓存
+	return c.Request.URL.Query().Get("token")
+}
+
+// overwriteIdentity 将查询参数和 JSON 请求体中的身份字段改写为已认证的用户ID
+func overwriteIdentity(c *gin.Context, userID string) error {
+	query := c.Request.URL.Query()
+	for _, field := range identityFields {
+		if query.Has(field) {
+			query.Set(field, userID)
+		}
+	}
+	query.Del("token")
+	c.Request.URL.RawQuery = query.Encode()
+
+	if c.Request.Body == nil || c.Request.Method == http.MethodGet {
+		return nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+
+	if len(body) == 0 {
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		return nil
+	}
+
+	var reqBody map[string]interface{}
+	if err := json.Unmarshal(body, &reqBody); err != nil {
+		return err
+	}
+	for _, field := range identityFields {
+		if _, ok := reqBody[field]; ok {
+			reqBody[field] = userID
+		}
+	}
+
+	body, err = json.Marshal(reqBody)
+	if err != nil {
+		return err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.ContentLength = int64(len(body))
+	return nil
+}
@@ -17,117 +17,32 @@ func NewGatewayHandler(usecase *usecase.APIGatewayUsecase) *GatewayHandler {
 
 // SetupRoutes 设置路由
 func (h *GatewayHandler) SetupRoutes(r *gin.Engine) {
+	// 认证之前按客户端 IP 限流，令牌无效的请求同样计数
+	r.Use(h.RateLimitMiddleware())
+	// 除公开路由外均需携带有效的访问令牌
+	r.Use(h.AuthMiddleware())
+	// 认证之后已登录的用户再按用户ID计数
+	r.Use(h.UserRateLimitMiddleware())
+
 	// 健康检查端点
 	r.GET("/health", h.usecase.HealthCheck)
 
 	// 服务发现端点
 	r.GET("/discovery", h.usecase.GetServiceDiscovery)
 
-	// 认证相关路由
-	authGroup := r.Group("/auth")
-	{
-		authGroup.POST("/register", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "lobby")
-		})
-		authGroup.POST("/login", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "lobby")
-		})
-		authGroup.POST("/getUserProfile", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "lobby")
-		})
-		authGroup.POST("/logout", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "lobby")
-		})
-	}
-
-	// 匹配相关路由
-	matchGroup := r.Group("/match")
-	{
-		matchGroup.POST("/findMatch", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "matching")
-		})
-		matchGroup.POST("/cancelMatch", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "matching")
-		})
-		matchGroup.POST("/getWaitingPlayers", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "matching")
-		})
-		matchGroup.POST("/getOnlinePlayers", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "matching")
-		})
-	}
-
-	// 房间相关路由
-	roomGroup := r.Group("/room")
-	{
-		roomGroup.POST("/createRoom", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "room")
-		})
-		roomGroup.POST("/joinRoom", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "room")
-		})
-		roomGroup.POST("/leaveRoom", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "room")
-		})
-		roomGroup.POST("/sendMessage", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "room")
-		})
-		roomGroup.POST("/getRoomMessages", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "room")
-		})
-		roomGroup.POST("/startGame", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "room")
-		})
-	}
-
-	// 排行榜相关路由
-	leaderboardGroup := r.Group("/leaderboard")
-	{
-		leaderboardGroup.POST("/getLeaderboard", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "leaderboard")
-		})
-		leaderboardGroup.POST("/updateScore", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "leaderboard")
-		})
-		leaderboardGroup.POST("/getUserRank", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "leaderboard")
-		})
-	}
+	// 实时通道：房间的游戏更新、聊天与方向输入
+	r.GET("/ws", h.usecase.HandleWebSocket)
 
-	// 游戏相关路由
-	gameGroup := r.Group("/game")
-	{
-		gameGroup.POST("/joinGame", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "game")
-		})
-		gameGroup.POST("/leaveGame", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "game")
-		})
-		gameGroup.POST("/move", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "game")
-		})
-		gameGroup.POST("/getGameState", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "game")
-		})
+	// 后端服务的一元 RPC 通过转码层自动暴露为 POST /<前缀>/<方法名>，
+	// 请求体和响应体均为 protobuf 的 JSON 映射（驼峰字段名）
+	for _, route := range h.usecase.RPCRoutes() {
+		r.POST(route.Path, h.usecase.ForwardRPC(route))
 	}
 
-	// 好友相关路由
-	friendsGroup := r.Group("/friends")
-	{
-		friendsGroup.POST("/addFriend", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "friends")
-		})
-		friendsGroup.POST("/removeFriend", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "friends")
-		})
-		friendsGroup.POST("/getFriends", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "friends")
-		})
-		friendsGroup.POST("/sendFriendRequest", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "friends")
-		})
-		friendsGroup.POST("/respondFriendRequest", func(c *gin.Context) {
-			h.usecase.ForwardRequest(c, "friends")
-		})
-	}
-}
\ No newline at end of file
+	// 流式 RPC 通过 Server-Sent Events 推送
+	r.GET("/match/watch", h.usecase.WatchMatch)
+	r.GET("/game/replay", h.usecase.StreamReplay)
+	r.GET("/game/spectate", h.usecase.SpectateGame)
+	r.GET("/friends/presence", h.usecase.WatchFriendPresence)
+	r.GET("/friends/invites", h.usecase.WatchInvites)
+}
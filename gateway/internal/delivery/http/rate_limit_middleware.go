@@ -0,0 +1,77 @@
+package http
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"snake-game/gateway/domain/entity"
+)
+
+// RateLimitMiddleware 在认证之前按客户端 IP 限流。公开路由（登录、注册等）使用路由策略；
+// 需要认证的路由使用令牌校验策略，令牌无效的请求同样计数，不会无限制地消耗令牌校验
+func (h *GatewayHandler) RateLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 健康检查由编排系统频繁调用，不参与限流
+		path := c.FullPath()
+		if path == "" || path == "/health" {
+			c.Next()
+			return
+		}
+
+		clientKey := "ip:" + c.ClientIP()
+		if publicRoutes[path] {
+			limit, result, err := h.usecase.TakeRateLimit(c.Request.Context(), path, clientKey)
+			h.enforceRateLimit(c, limit, result, err)
+			return
+		}
+		limit, result, err := h.usecase.TakeTokenCheckRateLimit(c.Request.Context(), clientKey)
+		h.enforceRateLimit(c, limit, result, err)
+	}
+}
+
+// UserRateLimitMiddleware 在认证之后按用户ID和路由策略限流，公开路由已在认证之前按 IP 计数
+func (h *GatewayHandler) UserRateLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("userId")
+		if userID == "" {
+			c.Next()
+			return
+		}
+
+		limit, result, err := h.usecase.TakeRateLimit(c.Request.Context(), c.FullPath(), "user:"+userID)
+		h.enforceRateLimit(c, limit, result, err)
+	}
+}
+
+// enforceRateLimit 在响应中写入当前的限流状态，超出限制时返回 429 和 Retry-After
+func (h *GatewayHandler) enforceRateLimit(c *gin.Context, limit entity.RateLimit, result entity.RateLimitResult, err error) {
+	if err != nil {
+		// 限流存储故障时放行，不影响正常请求
+		log.Printf("Rate limit check for %s failed: %v", c.FullPath(), err)
+		c.Next()
+		return
+	}
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Capacity))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+	if !result.Allowed {
+		c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error": "Too many requests",
+			"code":  "ResourceExhausted",
+		})
+		return
+	}
+	c.Next()
+}
+
+// ceilSeconds 向上取整到秒，避免不足一秒的等待时间显示为 0
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
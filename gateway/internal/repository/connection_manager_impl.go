@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+
+	"snake-game/auth"
+	"snake-game/gateway/domain/entity"
+)
+
+const (
+	// connDrainDelay 服务地址变更后旧连接保留的时间，让进行中的请求和流有机会结束
+	connDrainDelay = time.Minute
+	// roundRobinConfig 在同一服务的多个实例之间轮询
+	roundRobinConfig = `{"loadBalancingConfig": [{"round_robin": {}}]}`
+)
+
+// 断线重连的退避策略
+var reconnectBackoff = grpc.ConnectParams{
+	Backoff: backoff.Config{
+		BaseDelay:  500 * time.Millisecond,
+		Multiplier: 1.6,
+		Jitter:     0.2,
+		MaxDelay:   15 * time.Second,
+	},
+	MinConnectTimeout: 5 * time.Second,
+}
+
+type connectionManagerImpl struct {
+	mutex    sync.Mutex
+	conns    map[string]*grpc.ClientConn // 按 ServiceInfo.Address 缓存的连接
+	services map[string]string           // 服务名 -> 当前使用的地址
+	closed   bool
+}
+
+func NewConnectionManager() *connectionManagerImpl {
+	return &connectionManagerImpl{
+		conns:    make(map[string]*grpc.ClientConn),
+		services: make(map[string]string),
+	}
+}
+
+// GetConn 返回服务当前地址对应的长连接。注册表中的地址变化后会建立新连接，
+// 旧连接在没有其他服务使用时延迟关闭
+func (m *connectionManagerImpl) GetConn(service *entity.ServiceInfo) (*grpc.ClientConn, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.closed {
+		return nil, errors.New("connection manager is closed")
+	}
+
+	previous, known := m.services[service.Name]
+	m.services[service.Name] = service.Address
+	if known && previous != service.Address {
+		m.releaseLocked(previous)
+	}
+
+	if conn, ok := m.conns[service.Address]; ok {
+		return conn, nil
+	}
+
+	conn, err := dial(service)
+	if err != nil {
+		return nil, err
+	}
+	m.conns[service.Address] = conn
+	return conn, nil
+}
+
+// releaseLocked 地址不再被任何服务使用时，延迟关闭对应的连接
+func (m *connectionManagerImpl) releaseLocked(address string) {
+	for _, inUse := range m.services {
+		if inUse == address {
+			return
+		}
+	}
+	conn, ok := m.conns[address]
+	if !ok {
+		return
+	}
+	delete(m.conns, address)
+
+	log.Printf("Service address %s is no longer registered, closing its connection in %v", address, connDrainDelay)
+	time.AfterFunc(connDrainDelay, func() {
+		conn.Close()
+	})
+}
+
+// Close 关闭全部连接
+func (m *connectionManagerImpl) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.closed = true
+	for address, conn := range m.conns {
+		conn.Close()
+		delete(m.conns, address)
+	}
+	return nil
+}
+
+// dial 建立到服务全部实例的连接，实例列表由手动解析器提供
+func dial(service *entity.ServiceInfo) (*grpc.ClientConn, error) {
+	endpoints := service.Endpoints()
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("service %s has no address", service.Name)
+	}
+
+	addresses := make([]resolver.Address, len(endpoints))
+	for i, endpoint := range endpoints {
+		addresses[i] = resolver.Address{Addr: endpoint}
+	}
+	r := manual.NewBuilderWithScheme("gateway")
+	r.InitialState(resolver.State{Addresses: addresses})
+
+	conn, err := grpc.Dial(r.Scheme()+":///"+service.Name,
+		grpc.WithResolvers(r),
+		grpc.WithDefaultServiceConfig(roundRobinConfig),
+		grpc.WithConnectParams(reconnectBackoff),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		auth.WithInternalCredentials(),
+	)
+	if err != nil {
+		return nil, errors.New("Failed to connect to " + service.Name + " service")
+	}
+	return conn, nil
+}
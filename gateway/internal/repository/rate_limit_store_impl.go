@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"snake-game/gateway/domain/entity"
+	"snake-game/gateway/domain/repository"
+)
+
+// bucketSweepInterval 清理已补满的令牌桶的间隔，补满的桶与新建的桶等价
+const bucketSweepInterval = time.Minute
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	capacity  int
+	rate      float64
+}
+
+// refill 按经过的时间补充令牌
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens = math.Min(float64(b.capacity), b.tokens+now.Sub(b.updatedAt).Seconds()*b.rate)
+	b.updatedAt = now
+}
+
+type rateLimitStoreImpl struct {
+	mutex   sync.Mutex
+	buckets map[string]*tokenBucket
+	sweptAt time.Time
+}
+
+// NewRateLimitStore 创建进程内的令牌桶存储，只对当前网关实例生效
+func NewRateLimitStore() repository.RateLimitStore {
+	return &rateLimitStoreImpl{
+		buckets: make(map[string]*tokenBucket),
+		sweptAt: time.Now(),
+	}
+}
+
+func (s *rateLimitStoreImpl) Take(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.sweptAt) >= bucketSweepInterval {
+		s.sweepLocked(now)
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(limit.Capacity), updatedAt: now}
+		s.buckets[key] = bucket
+	}
+	// 策略可能调整过，以最新的为准
+	bucket.capacity = limit.Capacity
+	bucket.rate = limit.Rate
+	bucket.refill(now)
+
+	result := entity.RateLimitResult{}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - bucket.tokens) / limit.Rate)
+	}
+	result.Remaining = int(bucket.tokens)
+	result.ResetAfter = secondsToDuration((float64(limit.Capacity) - bucket.tokens) / limit.Rate)
+	return result, nil
+}
+
+// sweepLocked 删除已经补满的令牌桶，避免大量一次性的客户端占用内存
+func (s *rateLimitStoreImpl) sweepLocked(now time.Time) {
+	for key, bucket := range s.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= float64(bucket.capacity) {
+			delete(s.buckets, key)
+		}
+	}
+	s.sweptAt = now
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"sync"
+	"time"
 
 	"snake-game/gateway/domain/entity"
 )
@@ -22,7 +23,8 @@ func (r *serviceRegistryImpl) RegisterService(ctx context.Context, service *enti
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 	
-	r.services[service.Name] = service
+	copied := *service
+	r.services[service.Name] = &copied
 	return nil
 }
 
@@ -35,7 +37,9 @@ func (r *serviceRegistryImpl) GetService(ctx context.Context, name string) (*ent
 		return nil, nil
 	}
 	
-	return service, nil
+	// 返回副本，避免调用方与健康检查并发读写
+	copied := *service
+	return &copied, nil
 }
 
 func (r *serviceRegistryImpl) GetAllServices(ctx context.Context) ([]*entity.ServiceInfo, error) {
@@ -44,13 +48,14 @@ func (r *serviceRegistryImpl) GetAllServices(ctx context.Context) ([]*entity.Ser
 	
 	services := make([]*entity.ServiceInfo, 0, len(r.services))
 	for _, service := range r.services {
-		services = append(services, service)
+		copied := *service
+		services = append(services, &copied)
 	}
 	
 	return services, nil
 }
 
-func (r *serviceRegistryImpl) UpdateServiceHealth(ctx context.Context, name string, health bool) error {
+func (r *serviceRegistryImpl) UpdateServiceHealth(ctx context.Context, name string, health bool, latency time.Duration, checkErr string) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 	
@@ -60,6 +65,9 @@ func (r *serviceRegistryImpl) UpdateServiceHealth(ctx context.Context, name stri
 	}
 	
 	service.Health = health
+	service.Latency = latency
+	service.CheckedAt = time.Now()
+	service.HealthError = checkErr
 	r.services[name] = service
 	
 	return nil
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"snake-game/auth"
+	pb "snake-game/proto"
+)
+
+// ValidateToken 调用大厅服务校验访问令牌，返回令牌所属的用户ID。
+// 令牌无效时返回 Unauthenticated，大厅服务不可用时返回其他状态码
+func (uc *APIGatewayUsecase) ValidateToken(ctx context.Context, accessToken string) (string, error) {
+	if accessToken == "" {
+		return "", status.Error(codes.Unauthenticated, "missing access token")
+	}
+
+	var resp *pb.ValidateTokenResponse
+	err := uc.call(ctx, "lobby", uc.policy("ValidateToken"), func(ctx context.Context, conn *grpc.ClientConn) error {
+		var err error
+		resp, err = pb.NewLobbyServiceClient(conn).ValidateToken(ctx, &pb.ValidateTokenRequest{
+			AccessToken: accessToken,
+		})
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	if !resp.Success {
+		return "", status.Error(codes.Unauthenticated, resp.Message)
+	}
+	return resp.UserId, nil
+}
+
+// WithIdentity 将已认证的用户ID写入发往后端服务的 gRPC 元数据，后端服务据此识别调用方
+func WithIdentity(ctx context.Context, userID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, auth.UserIDKey, userID)
+}
+
+// detachedContext 返回不随请求取消、但保留身份元数据的上下文，
+// 用于生命周期长于单个 HTTP 请求的上游调用
+func detachedContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return context.Background()
+	}
+	return metadata.NewOutgoingContext(context.Background(), md)
+}
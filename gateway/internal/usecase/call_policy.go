@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultRPCTimeout 未单独配置的路由使用的超时
+	defaultRPCTimeout = 5 * time.Second
+	// readRetries 幂等读接口失败后的最大重试次数
+	readRetries = 2
+	// retryBaseDelay 重试的初始退避时间，每次翻倍并加入随机抖动
+	retryBaseDelay = 100 * time.Millisecond
+)
+
+// GatewayConfig 网关转发配置
+type GatewayConfig struct {
+	DefaultTimeout time.Duration            // 为 0 时使用 defaultRPCTimeout
+	RouteTimeouts  map[string]time.Duration // 按 RPC 方法名（如 GetReplay）覆盖超时
+}
+
+// callPolicy 单个 RPC 的调用策略
+type callPolicy struct {
+	timeout time.Duration // 每次尝试的超时
+	retries int           // 失败后的最大重试次数，只用于幂等的读接口
+}
+
+// idempotentMethods 可以安全重试的只读 RPC
+var idempotentMethods = map[string]bool{
+	"GetUserProfile":     true,
+	"ValidateToken":      true,
+	"GetWaitingPlayers":  true,
+	"GetOnlinePlayers":   true,
+	"GetRoomMessages":    true,
+	"GetRoom":            true,
+	"GetLeaderboard":     true,
+	"GetUserRank":        true,
+	"GetRating":          true,
+	"GetMatchHistory":    true,
+	"GetMatch":           true,
+	"GetReplay":          true,
+	"GetFriends":         true,
+	"ListFriendRequests": true,
+	"ListBlocked":        true,
+	"ListInvites":        true,
+}
+
+// policy 返回 RPC 方法（不含服务名）的调用策略
+func (uc *APIGatewayUsecase) policy(method string) callPolicy {
+	p := callPolicy{timeout: uc.config.DefaultTimeout}
+	if p.timeout <= 0 {
+		p.timeout = defaultRPCTimeout
+	}
+	if timeout, ok := uc.config.RouteTimeouts[method]; ok {
+		p.timeout = timeout
+	}
+	if idempotentMethods[method] {
+		p.retries = readRetries
+	}
+	return p
+}
+
+// call 在熔断器保护下调用后端服务。每次尝试单独计时，
+// 只有幂等接口会在服务暂时不可用或超时后重试
+func (uc *APIGatewayUsecase) call(ctx context.Context, serviceName string, p callPolicy, fn func(ctx context.Context, conn *grpc.ClientConn) error) error {
+	b := uc.breaker(serviceName)
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = b.allow(); err != nil {
+			return err
+		}
+
+		var conn *grpc.ClientConn
+		conn, err = uc.serviceConn(serviceName)
+		if err == nil {
+			attemptCtx, cancel := context.WithTimeout(ctx, p.timeout)
+			err = fn(attemptCtx, conn)
+			cancel()
+		}
+		b.record(err)
+
+		if err == nil || attempt >= p.retries || !isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		// 指数退避并加入抖动，避免重试同时打到刚恢复的服务
+		delay := retryBaseDelay << attempt
+		delay += time.Duration(rand.Int63n(int64(delay)))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// streamConn 返回流式 RPC 使用的连接。流的生命周期很长，不计入熔断统计，
+// 只在熔断器断开期间直接拒绝
+func (uc *APIGatewayUsecase) streamConn(serviceName string) (*grpc.ClientConn, error) {
+	if uc.breaker(serviceName).isOpen() {
+		return nil, status.Error(codes.Unavailable, "circuit breaker is open")
+	}
+	return uc.serviceConn(serviceName)
+}
+
+// isRetryable 只重试说明服务暂时不可用的错误
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// HTTPStatus 将 gRPC 状态码映射为 HTTP 状态码
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499 // 客户端已断开
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// WriteRPCError 按 gRPC 状态返回错误响应。内部错误不向客户端暴露细节
+func (uc *APIGatewayUsecase) WriteRPCError(c *gin.Context, serviceName string, err error) {
+	st := status.Convert(err)
+	code := HTTPStatus(st.Code())
+
+	message := st.Message()
+	if code == http.StatusInternalServerError {
+		message = "Internal server error"
+	}
+	if st.Code() == codes.Unavailable {
+		retryAfter := int(uc.breaker(serviceName).retryAfter().Seconds() + 0.5)
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+	}
+
+	c.JSON(code, gin.H{
+		"error": message,
+		"code":  st.Code().String(),
+	})
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	breakerFailureThreshold = 5                // 连续失败该次数后断开
+	breakerOpenTimeout      = 10 * time.Second // 断开后等待该时间再放行试探请求
+)
+
+// 熔断器状态
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half_open"
+)
+
+// circuitBreaker 单个后端服务的熔断器。
+// closed 时正常放行；连续失败达到阈值后 open，直接拒绝请求；
+// 超时后进入 half_open，只放行一个试探请求，成功则恢复，失败则重新 open
+type circuitBreaker struct {
+	mutex    sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool // half_open 状态下是否已有试探请求在进行
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{state: breakerClosed}
+}
+
+// allow 判断请求能否发出，被拒绝时返回 Unavailable 错误
+func (b *circuitBreaker) allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < breakerOpenTimeout {
+			return status.Error(codes.Unavailable, "circuit breaker is open")
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return nil
+	case breakerHalfOpen:
+		if b.probing {
+			return status.Error(codes.Unavailable, "circuit breaker is open")
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// isOpen 熔断器是否处于断开期，不改变状态
+func (b *circuitBreaker) isOpen() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.state == breakerOpen && time.Since(b.openedAt) < breakerOpenTimeout
+}
+
+// record 记录请求结果。只有表明服务本身故障的错误才计为失败
+func (b *circuitBreaker) record(err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !isServiceFailure(err) {
+		b.state = breakerClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= breakerFailureThreshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+		b.probing = false
+	}
+}
+
+// retryAfter 熔断器断开时距离下一次试探的剩余时间
+func (b *circuitBreaker) retryAfter() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state != breakerOpen {
+		return breakerOpenTimeout
+	}
+	return breakerOpenTimeout - time.Since(b.openedAt)
+}
+
+// isServiceFailure 判断错误是否说明后端服务不可用（而不是请求本身有问题）
+func isServiceFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// breaker 返回服务的熔断器，首次使用时创建
+func (uc *APIGatewayUsecase) breaker(serviceName string) *circuitBreaker {
+	uc.breakerMutex.Lock()
+	defer uc.breakerMutex.Unlock()
+
+	b, ok := uc.breakers[serviceName]
+	if !ok {
+		b = newCircuitBreaker()
+		uc.breakers[serviceName] = b
+	}
+	return b
+}
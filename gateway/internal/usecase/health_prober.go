@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"snake-game/gateway/domain/entity"
+)
+
+const (
+	healthProbeInterval = 5 * time.Second // 探测间隔
+	healthProbeTimeout  = 2 * time.Second // 单次探测超时
+)
+
+// StartHealthProber 在后台定期通过 grpc.health.v1 探测所有后端服务并更新注册表，直到 ctx 取消
+func (uc *APIGatewayUsecase) StartHealthProber(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(healthProbeInterval)
+		defer ticker.Stop()
+
+		for {
+			uc.probeAll(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// probeAll 并发探测全部服务，慢服务不会拖慢其他服务的探测
+func (uc *APIGatewayUsecase) probeAll(ctx context.Context) {
+	services, err := uc.serviceRegistry.GetAllServices(ctx)
+	if err != nil {
+		log.Printf("Failed to list services for health probing: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, service := range services {
+		wg.Add(1)
+		go func(service *entity.ServiceInfo) {
+			defer wg.Done()
+			uc.probe(ctx, service)
+		}(service)
+	}
+	wg.Wait()
+}
+
+// probe 探测单个服务。不经过 serviceConn，否则被标记为不健康的服务将无法恢复
+func (uc *APIGatewayUsecase) probe(ctx context.Context, service *entity.ServiceInfo) {
+	healthy, latency, checkErr := false, time.Duration(0), ""
+
+	conn, err := uc.connManager.GetConn(service)
+	if err != nil {
+		checkErr = err.Error()
+	} else {
+		probeCtx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+		start := time.Now()
+		resp, err := healthpb.NewHealthClient(conn).Check(probeCtx, &healthpb.HealthCheckRequest{})
+		latency = time.Since(start)
+		cancel()
+
+		switch {
+		case err != nil:
+			checkErr = status.Convert(err).Message()
+		case resp.Status != healthpb.HealthCheckResponse_SERVING:
+			checkErr = resp.Status.String()
+		default:
+			healthy = true
+		}
+	}
+
+	if healthy != service.Health {
+		if healthy {
+			log.Printf("Service %s is healthy again", service.Name)
+		} else {
+			log.Printf("Service %s became unhealthy: %s", service.Name, checkErr)
+		}
+	}
+	uc.serviceRegistry.UpdateServiceHealth(ctx, service.Name, healthy, latency, checkErr)
+}
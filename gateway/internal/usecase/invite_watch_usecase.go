@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	pb "snake-game/proto"
+)
+
+// WatchInvites 通过 Server-Sent Events 推送收到的房间邀请：
+// 连接建立时先推送未过期的邀请，之后推送新邀请，事件名均为 invite。
+// 重新连接时会再次收到未过期的邀请，客户端按 inviteId 去重
+func (uc *APIGatewayUsecase) WatchInvites(c *gin.Context) {
+	userID := c.GetString("userId")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	conn, err := uc.streamConn("friends")
+	if err != nil {
+		uc.WriteRPCError(c, "friends", err)
+		return
+	}
+
+	// 客户端断开时请求上下文取消，上游流随之结束
+	stream, err := pb.NewFriendsServiceClient(conn).WatchInvites(c.Request.Context(), &pb.WatchInvitesRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		uc.WriteRPCError(c, "friends", err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// 关闭 nginx 的响应缓冲，保证事件即时送达
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		invite, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF && c.Request.Context().Err() == nil {
+				log.Printf("Invite stream for user %s failed: %v", userID, err)
+				c.SSEvent("invite_error", gin.H{"message": "Invite stream interrupted"})
+			}
+			return false
+		}
+		c.SSEvent("invite", protoJSON(invite))
+		return true
+	})
+}
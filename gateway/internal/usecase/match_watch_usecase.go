@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	pb "snake-game/proto"
+)
+
+// WatchMatch 通过 Server-Sent Events 推送匹配进度：
+// queued（排队位置、预计等待）、match_found（房间与对手）、match_cancelled。
+// 客户端断开时保留排队，重新连接可继续；取消匹配需调用 /match/cancelMatch
+func (uc *APIGatewayUsecase) WatchMatch(c *gin.Context) {
+	playerID := c.GetString("userId")
+	if playerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	conn, err := uc.streamConn("matching")
+	if err != nil {
+		uc.WriteRPCError(c, "matching", err)
+		return
+	}
+
+	// 客户端断开时请求上下文取消，上游流随之结束
+	stream, err := pb.NewMatchingServiceClient(conn).WatchMatch(c.Request.Context(), &pb.WatchMatchRequest{
+		PlayerId: playerID,
+	})
+	if err != nil {
+		uc.WriteRPCError(c, "matching", err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// 关闭 nginx 的响应缓冲，保证事件即时送达
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		event, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF && c.Request.Context().Err() == nil {
+				log.Printf("Match stream for player %s failed: %v", playerID, err)
+				// 不使用 "error" 作为事件名，避免与 EventSource 的连接错误事件混淆
+				c.SSEvent("match_error", gin.H{"message": "Match stream interrupted"})
+			}
+			return false
+		}
+		c.SSEvent(event.Type, protoJSON(event))
+		return true
+	})
+}
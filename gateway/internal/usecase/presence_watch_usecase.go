@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	pb "snake-game/proto"
+)
+
+// WatchFriendPresence 通过 Server-Sent Events 推送好友的在线状态：
+// 连接建立时先推送全部好友的当前状态，之后推送每次变化，事件名均为 presence
+func (uc *APIGatewayUsecase) WatchFriendPresence(c *gin.Context) {
+	userID := c.GetString("userId")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	conn, err := uc.streamConn("friends")
+	if err != nil {
+		uc.WriteRPCError(c, "friends", err)
+		return
+	}
+
+	// 客户端断开时请求上下文取消，上游流随之结束
+	stream, err := pb.NewFriendsServiceClient(conn).WatchFriendPresence(c.Request.Context(), &pb.WatchFriendPresenceRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		uc.WriteRPCError(c, "friends", err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// 关闭 nginx 的响应缓冲，保证事件即时送达
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		presence, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF && c.Request.Context().Err() == nil {
+				log.Printf("Presence stream for user %s failed: %v", userID, err)
+				c.SSEvent("presence_error", gin.H{"message": "Presence stream interrupted"})
+			}
+			return false
+		}
+		c.SSEvent("presence", protoJSON(presence))
+		return true
+	})
+}
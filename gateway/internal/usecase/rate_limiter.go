@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+
+	"snake-game/gateway/domain/entity"
+)
+
+var (
+	// 登录注册严格限流，防止暴力破解和批量注册
+	authRateLimit    = entity.RateLimit{Name: "auth", Capacity: 5, Rate: 5.0 / 60}
+	refreshRateLimit = entity.RateLimit{Name: "refresh", Capacity: 10, Rate: 10.0 / 60}
+	// 对局操作频繁，放宽限制
+	gameplayRateLimit = entity.RateLimit{Name: "gameplay", Capacity: 50, Rate: 20}
+	// 建立长连接（WebSocket、Server-Sent Events）
+	streamRateLimit  = entity.RateLimit{Name: "stream", Capacity: 10, Rate: 1}
+	defaultRateLimit = entity.RateLimit{Name: "default", Capacity: 60, Rate: 10}
+	// 认证之前按 IP 校验令牌的次数，同一出口 IP 后可能有多个玩家，因此较宽松
+	tokenCheckRateLimit = entity.RateLimit{Name: "token_check", Capacity: 120, Rate: 30}
+)
+
+// routeRateLimits 按路由指定的限流策略，未列出的路由使用 defaultRateLimit
+var routeRateLimits = map[string]entity.RateLimit{
+	"/auth/login":        authRateLimit,
+	"/auth/register":     authRateLimit,
+	"/auth/refreshToken": refreshRateLimit,
+	"/game/move":         gameplayRateLimit,
+	"/ws":                streamRateLimit,
+	"/match/watch":       streamRateLimit,
+	"/game/replay":       streamRateLimit,
+	"/game/spectate":     streamRateLimit,
+	"/friends/presence":  streamRateLimit,
+	"/friends/invites":   streamRateLimit,
+}
+
+// TakeRateLimit 为调用方在路由对应的令牌桶中取一个令牌。
+// clientKey 标识调用方，已认证时为用户ID，否则为客户端 IP
+func (uc *APIGatewayUsecase) TakeRateLimit(ctx context.Context, route, clientKey string) (entity.RateLimit, entity.RateLimitResult, error) {
+	limit, ok := routeRateLimits[route]
+	if !ok {
+		limit = defaultRateLimit
+	}
+	result, err := uc.rateLimitStore.Take(ctx, limit.Name+":"+clientKey, limit)
+	return limit, result, err
+}
+
+// TakeTokenCheckRateLimit 在校验访问令牌之前为客户端 IP 取一个令牌，
+// 每次校验都要调用大厅服务，限制后无法无限制地猜测令牌
+func (uc *APIGatewayUsecase) TakeTokenCheckRateLimit(ctx context.Context, clientKey string) (entity.RateLimit, entity.RateLimitResult, error) {
+	result, err := uc.rateLimitStore.Take(ctx, tokenCheckRateLimit.Name+":"+clientKey, tokenCheckRateLimit)
+	return tokenCheckRateLimit, result, err
+}
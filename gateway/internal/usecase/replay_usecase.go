@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/status"
+
+	pb "snake-game/proto"
+)
+
+// StreamReplay 通过 Server-Sent Events 推送对局回放帧，事件类型与实时对局的 GameUpdate 一致
+// （game_state、snake_moved、food_eaten、game_over）。speed 为播放倍速，缺省为原速
+func (uc *APIGatewayUsecase) StreamReplay(c *gin.Context) {
+	matchID := c.Query("matchId")
+	if matchID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing matchId"})
+		return
+	}
+	speed, err := strconv.ParseFloat(c.DefaultQuery("speed", "1"), 64)
+	if err != nil || speed <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid speed"})
+		return
+	}
+
+	conn, err := uc.streamConn("game")
+	if err != nil {
+		uc.WriteRPCError(c, "game", err)
+		return
+	}
+
+	// 客户端断开时请求上下文取消，上游流随之结束
+	stream, err := pb.NewGameServiceClient(conn).StreamReplay(c.Request.Context(), &pb.StreamReplayRequest{
+		MatchId: matchID,
+		Speed:   speed,
+	})
+	if err != nil {
+		uc.WriteRPCError(c, "game", err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// 关闭 nginx 的响应缓冲，保证事件即时送达
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		frame, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF && c.Request.Context().Err() == nil {
+				log.Printf("Replay stream of match %s failed: %v", matchID, err)
+				// 回放不存在等错误在首次接收时才返回，把原因转给客户端
+				c.SSEvent("replay_error", gin.H{"message": status.Convert(err).Message()})
+			}
+			return false
+		}
+		c.SSEvent(frame.Type, protoJSON(frame))
+		return true
+	})
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/status"
+
+	pb "snake-game/proto"
+)
+
+// SpectateGame 通过 Server-Sent Events 推送房间的游戏更新（观战模式，画面有延迟）。
+// 事件类型与实时对局的 GameUpdate 一致，出错时推送 spectate_error
+func (uc *APIGatewayUsecase) SpectateGame(c *gin.Context) {
+	roomID := c.Query("roomId")
+	userID := c.GetString("userId")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing roomId"})
+		return
+	}
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	conn, err := uc.streamConn("game")
+	if err != nil {
+		uc.WriteRPCError(c, "game", err)
+		return
+	}
+
+	// 客户端断开时请求上下文取消，上游流随之结束
+	stream, err := pb.NewGameServiceClient(conn).SpectateGame(c.Request.Context(), &pb.SpectateGameRequest{
+		RoomId:      roomID,
+		SpectatorId: userID,
+	})
+	if err != nil {
+		uc.WriteRPCError(c, "game", err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// 关闭 nginx 的响应缓冲，保证事件即时送达
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		update, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF && c.Request.Context().Err() == nil {
+				log.Printf("Spectate stream of room %s failed: %v", roomID, err)
+				c.SSEvent("spectate_error", gin.H{"message": status.Convert(err).Message()})
+			}
+			return false
+		}
+		c.SSEvent(update.Type, protoJSON(update))
+		return true
+	})
+}
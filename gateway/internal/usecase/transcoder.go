@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	pb "snake-game/proto"
+)
+
+// routeGroup 将 HTTP 路径前缀映射到注册表中的服务和对应的 gRPC 服务
+type routeGroup struct {
+	prefix  string
+	service string
+	grpc    protoreflect.FullName
+}
+
+var routeGroups = []routeGroup{
+	{prefix: "auth", service: "lobby", grpc: "lobby_service.LobbyService"},
+	{prefix: "match", service: "matching", grpc: "matching_service.MatchingService"},
+	{prefix: "room", service: "room", grpc: "room_service.RoomService"},
+	{prefix: "leaderboard", service: "leaderboard", grpc: "leaderboard_service.LeaderboardService"},
+	{prefix: "game", service: "game", grpc: "game_service.GameService"},
+	{prefix: "friends", service: "friends", grpc: "friends_service.FriendsService"},
+}
+
+var (
+	// 请求中未知的字段直接忽略，兼容旧版客户端多传的参数
+	jsonUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+	// 零值字段也输出，客户端无需为缺省字段做兼容
+	jsonMarshal = protojson.MarshalOptions{EmitUnpopulated: true}
+)
+
+// RPCRoute 一条由 gRPC 方法自动生成的 HTTP 路由
+type RPCRoute struct {
+	Path       string // POST /<前缀>/<首字母小写的方法名>
+	Service    string // 注册表中的服务名
+	FullMethod string // /<包名>.<服务名>/<方法名>
+	method     string // 方法名，用于查找超时和重试策略
+	input      protoreflect.MessageType
+	output     protoreflect.MessageType
+}
+
+// requestHooks 转发前对请求做的补充，键为 FullMethod
+var requestHooks = map[string]func(c *gin.Context, req proto.Message){
+	// 登出时吊销当前会话，令牌来自 Authorization 请求头
+	"/lobby_service.LobbyService/Logout": func(c *gin.Context, req proto.Message) {
+		logout := req.(*pb.LogoutRequest)
+		if logout.AccessToken == "" {
+			logout.AccessToken = bearerToken(c)
+		}
+	},
+}
+
+// detachedMethods 客户端断开后仍需完成的 RPC，键为 FullMethod。
+// 其余调用随请求取消，客户端离开后不再占用后端或继续重试
+var detachedMethods = map[string]bool{
+	// 登出时吊销会话，不能因为客户端关闭页面而中断
+	"/lobby_service.LobbyService/Logout": true,
+}
+
+// internalMethods 只供服务之间调用的 RPC，不生成 HTTP 路由。
+// 后端同样用 auth.RequireInternal 拒绝代表用户的调用，这里只是不再对外暴露
+var internalMethods = map[string]bool{
+	"/lobby_service.LobbyService/UpdateActivity":                true,
+	"/lobby_service.LobbyService/GetPresence":                   true,
+	"/lobby_service.LobbyService/GetUsersByIds":                 true,
+	"/lobby_service.LobbyService/FindUserByUsername":            true,
+	"/room_service.RoomService/ReportGameResult":                true,
+	"/game_service.GameService/CreateGame":                      true,
+	"/game_service.GameService/GetActiveGames":                  true,
+	"/game_service.GameService/GetGameState":                    true,
+	"/leaderboard_service.LeaderboardService/UpdateScore":       true,
+	"/leaderboard_service.LeaderboardService/RecordMatchResult": true,
+	"/friends_service.FriendsService/GetBlockRelations":         true,
+}
+
+// RPCRoutes 通过 protobuf 反射列出所有后端服务对外开放的一元 RPC。
+// 流式 RPC 由各自的 Server-Sent Events 端点提供
+func (uc *APIGatewayUsecase) RPCRoutes() []RPCRoute {
+	var routes []RPCRoute
+	for _, group := range routeGroups {
+		descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(group.grpc)
+		if err != nil {
+			log.Fatalf("gRPC service %s is not registered: %v", group.grpc, err)
+		}
+		methods := descriptor.(protoreflect.ServiceDescriptor).Methods()
+
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			if method.IsStreamingClient() || method.IsStreamingServer() {
+				continue
+			}
+			input, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+			if err != nil {
+				log.Fatalf("Message %s is not registered: %v", method.Input().FullName(), err)
+			}
+			output, err := protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+			if err != nil {
+				log.Fatalf("Message %s is not registered: %v", method.Output().FullName(), err)
+			}
+
+			name := string(method.Name())
+			fullMethod := fmt.Sprintf("/%s/%s", group.grpc, name)
+			if internalMethods[fullMethod] {
+				continue
+			}
+			routes = append(routes, RPCRoute{
+				Path:       "/" + group.prefix + "/" + strings.ToLower(name[:1]) + name[1:],
+				Service:    group.service,
+				FullMethod: fullMethod,
+				method:     name,
+				input:      input,
+				output:     output,
+			})
+		}
+	}
+	return routes
+}
+
+// ForwardRPC 将 JSON 请求体转码为 protobuf 请求，调用后端服务并原样返回 JSON 格式的响应。
+// 后端返回的 gRPC 错误按状态码转换为对应的 HTTP 状态
+func (uc *APIGatewayUsecase) ForwardRPC(route RPCRoute) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+
+		req := route.input.New().Interface()
+		if len(body) > 0 {
+			if err := jsonUnmarshal.Unmarshal(body, req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+				return
+			}
+		}
+		if hook, ok := requestHooks[route.FullMethod]; ok {
+			hook(c, req)
+		}
+
+		ctx := c.Request.Context()
+		if detachedMethods[route.FullMethod] {
+			ctx = detachedContext(ctx)
+		}
+
+		resp := route.output.New().Interface()
+		err = uc.call(ctx, route.Service, uc.policy(route.method),
+			func(ctx context.Context, conn *grpc.ClientConn) error {
+				return conn.Invoke(ctx, route.FullMethod, req, resp)
+			})
+		if err != nil {
+			uc.WriteRPCError(c, route.Service, err)
+			return
+		}
+
+		data, err := jsonMarshal.Marshal(resp)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encode response"})
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+	}
+}
+
+// protoJSON 将推送给客户端的消息编码为与 REST 响应一致的 JSON
+func protoJSON(msg proto.Message) json.RawMessage {
+	data, err := jsonMarshal.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to encode %s: %v", msg.ProtoReflect().Descriptor().FullName(), err)
+		return json.RawMessage("{}")
+	}
+	return data
+}
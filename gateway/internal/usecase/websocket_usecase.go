@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "snake-game/proto"
+)
+
+const (
+	wsSendBufferSize  = 64               // 每个连接的待发送帧缓冲
+	wsMaxMessageSize  = 4096             // 客户端单帧最大字节数
+	wsWriteTimeout    = 10 * time.Second // 单帧写超时
+	wsPongTimeout     = 60 * time.Second // 超过该时间未收到 pong 视为断线
+	wsPingInterval    = 30 * time.Second // 服务端 ping 间隔
+	wsGameRetryDelay  = 2 * time.Second  // 游戏尚未开始时重试订阅的间隔
+	wsUpstreamTimeout = 5 * time.Second  // 上行 RPC 超时
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// 与 CORS 配置保持一致，允许所有来源
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// wsInbound 客户端发来的帧
+type wsInbound struct {
+	Type      string `json:"type"` // subscribe, unsubscribe, move, chat, ping
+	RoomID    string `json:"roomId"`
+	Direction string `json:"direction"`
+	Content   string `json:"content"`
+}
+
+// wsOutbound 推送给客户端的帧
+type wsOutbound struct {
+	Type    string      `json:"type"` // subscribed, unsubscribed, game_update, chat_message, error, pong
+	RoomID  string      `json:"roomId,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// wsSession 单个 WebSocket 连接的会话状态
+type wsSession struct {
+	userID     string
+	conn       *websocket.Conn
+	send       chan wsOutbound
+	gameClient pb.GameServiceClient
+	roomClient pb.RoomServiceClient
+	ctx        context.Context
+	cancel     context.CancelFunc
+
+	mutex      sync.Mutex
+	roomID     string             // 当前订阅的房间
+	roomCancel context.CancelFunc // 取消当前房间的上游订阅
+}
+
+// HandleWebSocket 建立 WebSocket 连接，桥接房间的游戏更新与聊天
+func (uc *APIGatewayUsecase) HandleWebSocket(c *gin.Context) {
+	userID := c.GetString("userId")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	gameConn, err := uc.streamConn("game")
+	if err != nil {
+		uc.WriteRPCError(c, "game", err)
+		return
+	}
+
+	roomConn, err := uc.streamConn("room")
+	if err != nil {
+		uc.WriteRPCError(c, "room", err)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade 已经向客户端写回了错误
+		log.Printf("WebSocket upgrade failed: %v", err)
+		return
+	}
+
+	// 会话的上游调用携带认证中间件注入的身份元数据
+	ctx, cancel := context.WithCancel(detachedContext(c.Request.Context()))
+	session := &wsSession{
+		userID:     userID,
+		conn:       conn,
+		send:       make(chan wsOutbound, wsSendBufferSize),
+		gameClient: pb.NewGameServiceClient(gameConn),
+		roomClient: pb.NewRoomServiceClient(roomConn),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	go session.writeLoop()
+	session.readLoop()
+}
+
+// readLoop 读取客户端帧直到连接断开
+func (s *wsSession) readLoop() {
+	defer func() {
+		s.unsubscribe()
+		s.cancel()
+		s.conn.Close()
+	}()
+
+	s.conn.SetReadLimit(wsMaxMessageSize)
+	s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		var frame wsInbound
+		if err := s.conn.ReadJSON(&frame); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Printf("WebSocket read error for user %s: %v", s.userID, err)
+			}
+			return
+		}
+
+		switch frame.Type {
+		case "subscribe":
+			s.subscribe(frame.RoomID)
+		case "unsubscribe":
+			s.unsubscribe()
+			s.push(wsOutbound{Type: "unsubscribed"})
+		case "move":
+			s.move(frame.Direction)
+		case "chat":
+			s.chat(frame.Content)
+		case "ping":
+			s.push(wsOutbound{Type: "pong"})
+		default:
+			s.push(wsOutbound{Type: "error", Message: "Unknown frame type"})
+		}
+	}
+}
+
+// writeLoop 串行写出所有帧并定期发送 ping
+func (s *wsSession) writeLoop() {
+	ticker := time.NewTicker(wsPingInterval)
+	defer func() {
+		ticker.Stop()
+		s.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		case frame := <-s.send:
+			s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := s.conn.WriteJSON(frame); err != nil {
+				s.cancel()
+				return
+			}
+		case <-ticker.C:
+			s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := s.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				s.cancel()
+				return
+			}
+		}
+	}
+}
+
+// push 将帧放入发送队列；客户端消费过慢导致队列已满时断开连接
+func (s *wsSession) push(frame wsOutbound) {
+	select {
+	case s.send <- frame:
+	case <-s.ctx.Done():
+	default:
+		log.Printf("WebSocket client %s is too slow, closing connection", s.userID)
+		s.cancel()
+	}
+}
+
+// subscribe 订阅房间的游戏更新与聊天消息，会替换之前的订阅
+func (s *wsSession) subscribe(roomID string) {
+	if roomID == "" {
+		s.push(wsOutbound{Type: "error", Message: "Missing roomId"})
+		return
+	}
+
+	s.unsubscribe()
+
+	roomCtx, roomCancel := context.WithCancel(s.ctx)
+	s.mutex.Lock()
+	s.roomID = roomID
+	s.roomCancel = roomCancel
+	s.mutex.Unlock()
+
+	go s.relayGameUpdates(roomCtx, roomID)
+	go s.relayRoomMessages(roomCtx, roomID)
+
+	s.push(wsOutbound{Type: "subscribed", RoomID: roomID})
+}
+
+// unsubscribe 取消当前房间订阅
+func (s *wsSession) unsubscribe() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.roomCancel != nil {
+		s.roomCancel()
+		s.roomCancel = nil
+	}
+	s.roomID = ""
+}
+
+// currentRoom 返回当前订阅的房间
+func (s *wsSession) currentRoom() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.roomID
+}
+
+// relayGameUpdates 转发游戏服务的更新流；游戏尚未开始或已结束时定期重试，
+// 以便房间再来一局时继续推送
+func (s *wsSession) relayGameUpdates(ctx context.Context, roomID string) {
+	ended := false
+	for {
+		stream, err := s.gameClient.SubscribeGameUpdates(ctx, &pb.SubscribeGameUpdatesRequest{
+			RoomId:   roomID,
+			PlayerId: s.userID,
+		})
+		if err == nil {
+			err = s.forwardGameUpdates(stream, roomID, ended)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err == io.EOF {
+			// 对局结束，等待同一房间的下一局
+			ended = true
+		} else if code := status.Code(err); code != codes.NotFound && code != codes.PermissionDenied {
+			// 游戏尚未开始或用户不在当前对局中时静默重试，其他错误才记录
+			log.Printf("Game update stream for room %s failed: %v", roomID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wsGameRetryDelay):
+		}
+	}
+}
+
+// forwardGameUpdates 转发单局的更新流；skipFinished 为真时不再重复推送已转发过的对局结果
+func (s *wsSession) forwardGameUpdates(stream pb.GameService_SubscribeGameUpdatesClient, roomID string, skipFinished bool) error {
+	first := true
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if first && skipFinished && update.Status == "finished" {
+			return io.EOF
+		}
+		first = false
+		s.push(wsOutbound{Type: "game_update", RoomID: roomID, Data: protoJSON(update)})
+	}
+}
+
+// relayRoomMessages 转发房间服务的聊天消息流
+func (s *wsSession) relayRoomMessages(ctx context.Context, roomID string) {
+	stream, err := s.roomClient.SubscribeRoomMessages(ctx, &pb.SubscribeRoomMessagesRequest{
+		RoomId: roomID,
+		UserId: s.userID,
+	})
+	if err != nil {
+		s.push(wsOutbound{Type: "error", RoomID: roomID, Message: "Failed to subscribe room messages"})
+		return
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil && err != io.EOF {
+				log.Printf("Room message stream for room %s failed: %v", roomID, err)
+			}
+			return
+		}
+		s.push(wsOutbound{Type: "chat_message", RoomID: roomID, Data: protoJSON(msg)})
+	}
+}
+
+// move 将方向输入转发到游戏服务
+func (s *wsSession) move(directionStr string) {
+	roomID := s.currentRoom()
+	if roomID == "" {
+		s.push(wsOutbound{Type: "error", Message: "Not subscribed to a room"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, wsUpstreamTimeout)
+	defer cancel()
+
+	resp, err := s.gameClient.Move(ctx, &pb.MoveRequest{
+		RoomId:    roomID,
+		PlayerId:  s.userID,
+		Direction: parseDirection(directionStr),
+	})
+	if err != nil {
+		s.push(wsOutbound{Type: "error", RoomID: roomID, Message: err.Error()})
+		return
+	}
+	if !resp.Success {
+		s.push(wsOutbound{Type: "error", RoomID: roomID, Message: resp.Message})
+	}
+}
+
+// chat 将聊天消息转发到房间服务
+func (s *wsSession) chat(content string) {
+	roomID := s.currentRoom()
+	if roomID == "" {
+		s.push(wsOutbound{Type: "error", Message: "Not subscribed to a room"})
+		return
+	}
+	if content == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, wsUpstreamTimeout)
+	defer cancel()
+
+	resp, err := s.roomClient.SendMessage(ctx, &pb.SendMessageRequest{
+		RoomId:   roomID,
+		SenderId: s.userID,
+		Content:  content,
+		Type:     "text",
+	})
+	if err != nil {
+		s.push(wsOutbound{Type: "error", RoomID: roomID, Message: err.Error()})
+		return
+	}
+	if !resp.Success {
+		s.push(wsOutbound{Type: "error", RoomID: roomID, Message: resp.Message})
+	}
+}
+
+// parseDirection 将 "UP"/"DOWN"/"LEFT"/"RIGHT" 转换为 protobuf 枚举
+func parseDirection(direction string) pb.Direction {
+	if value, ok := pb.Direction_value[direction]; ok {
+		return pb.Direction(value)
+	}
+	return pb.Direction_NONE
+}
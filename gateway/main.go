@@ -4,6 +4,9 @@ import (
 	"context"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -36,6 +39,18 @@ func main() {
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.Default()
 
+	// 只信任配置的反向代理转发的 X-Forwarded-For，否则客户端可以伪造 IP 绕过按 IP 的限流。
+	// 格式为逗号分隔的 IP 或 CIDR，未配置时直接使用连接的远端地址
+	var trustedProxies []string
+	if value := os.Getenv("GATEWAY_TRUSTED_PROXIES"); value != "" {
+		for _, proxy := range strings.Split(value, ",") {
+			trustedProxies = append(trustedProxies, strings.TrimSpace(proxy))
+		}
+	}
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatalf("Invalid GATEWAY_TRUSTED_PROXIES: %v", err)
+	}
+
 	// 添加 OpenTelemetry 中间件
 	r.Use(otel.GinMiddleware("api-gateway"))
 
@@ -43,26 +58,31 @@ func main() {
 	config := cors.DefaultConfig()
 	config.AllowAllOrigins = true
 	config.AllowHeaders = append(config.AllowHeaders, "Content-Type", "Authorization")
+	config.ExposeHeaders = append(config.ExposeHeaders, "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset")
 	r.Use(cors.New(config))
 
 	// 初始化仓库层
 	serviceRegistry := repository.NewServiceRegistry()
 
-	// 从环境变量读取服务地址（支持k8s服务发现）
+	// 从环境变量读取服务地址（支持k8s服务发现），多个实例以逗号分隔。
+	// Critical 的服务不可用时 /health 返回 503
 	lobbyService := &entity.ServiceInfo{
-		Name:    "lobby",
-		Address: getEnv("LOBBY_SERVICE_ADDR", "localhost:50051"),
-		Health:  true,
+		Name:     "lobby",
+		Address:  getEnv("LOBBY_SERVICE_ADDR", "localhost:50051"),
+		Health:   true,
+		Critical: true,
 	}
 	matchingService := &entity.ServiceInfo{
-		Name:    "matching",
-		Address: getEnv("MATCHING_SERVICE_ADDR", "localhost:50052"),
-		Health:  true,
+		Name:     "matching",
+		Address:  getEnv("MATCHING_SERVICE_ADDR", "localhost:50052"),
+		Health:   true,
+		Critical: true,
 	}
 	roomService := &entity.ServiceInfo{
-		Name:    "room",
-		Address: getEnv("ROOM_SERVICE_ADDR", "localhost:50053"),
-		Health:  true,
+		Name:     "room",
+		Address:  getEnv("ROOM_SERVICE_ADDR", "localhost:50053"),
+		Health:   true,
+		Critical: true,
 	}
 	leaderboardService := &entity.ServiceInfo{
 		Name:    "leaderboard",
@@ -70,9 +90,10 @@ func main() {
 		Health:  true,
 	}
 	gameService := &entity.ServiceInfo{
-		Name:    "game",
-		Address: getEnv("GAME_SERVICE_ADDR", "localhost:50055"),
-		Health:  true,
+		Name:     "game",
+		Address:  getEnv("GAME_SERVICE_ADDR", "localhost:50055"),
+		Health:   true,
+		Critical: true,
 	}
 	friendsService := &entity.ServiceInfo{
 		Name:    "friends",
@@ -88,8 +109,44 @@ func main() {
 	serviceRegistry.RegisterService(nil, gameService)
 	serviceRegistry.RegisterService(nil, friendsService)
 
+	// 到后端服务的长连接，服务地址变化时自动切换
+	connManager := repository.NewConnectionManager()
+	defer connManager.Close()
+
+	// 限流状态保存在进程内，多副本部署时可替换为共享存储
+	rateLimitStore := repository.NewRateLimitStore()
+
+	// 转发 RPC 的默认超时（毫秒）
+	defaultTimeout := 5 * time.Second
+	if value := os.Getenv("GATEWAY_RPC_TIMEOUT_MS"); value != "" {
+		ms, err := strconv.Atoi(value)
+		if err != nil || ms <= 0 {
+			log.Fatalf("Invalid GATEWAY_RPC_TIMEOUT_MS: %s", value)
+		}
+		defaultTimeout = time.Duration(ms) * time.Millisecond
+	}
+
+	// 按方法覆盖超时，格式为 "GetReplay=15000,GetMatchHistory=8000"
+	routeTimeouts := make(map[string]time.Duration)
+	if value := os.Getenv("GATEWAY_ROUTE_TIMEOUTS"); value != "" {
+		for _, entry := range strings.Split(value, ",") {
+			method, msValue, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			ms, err := strconv.Atoi(msValue)
+			if !ok || method == "" || err != nil || ms <= 0 {
+				log.Fatalf("Invalid GATEWAY_ROUTE_TIMEOUTS entry: %s", entry)
+			}
+			routeTimeouts[method] = time.Duration(ms) * time.Millisecond
+		}
+	}
+
 	// 初始化业务逻辑层
-	apiGatewayUsecase := usecase.NewAPIGatewayUsecase(serviceRegistry)
+	apiGatewayUsecase := usecase.NewAPIGatewayUsecase(serviceRegistry, connManager, rateLimitStore, usecase.GatewayConfig{
+		DefaultTimeout: defaultTimeout,
+		RouteTimeouts:  routeTimeouts,
+	})
+
+	// 后台探测各服务的健康状况
+	apiGatewayUsecase.StartHealthProber(ctx)
 
 	// 初始化通信层
 	gatewayHandler := http.NewGatewayHandler(apiGatewayUsecase)
@@ -0,0 +1,72 @@
+package healthcheck
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	checkInterval = 10 * time.Second // 依赖检查间隔
+	checkTimeout  = 3 * time.Second  // 单次依赖检查超时
+)
+
+// Check 检查服务的一个依赖，返回错误表示依赖不可用
+type Check func(ctx context.Context) error
+
+// Register 在 gRPC 服务器上注册标准的 grpc.health.v1 健康检查服务。
+// service 为 gRPC 服务全名；提供依赖检查时定期执行，任一失败则服务状态为 NOT_SERVING
+func Register(s *grpc.Server, service string, checks ...Check) *health.Server {
+	server := health.NewServer()
+	healthpb.RegisterHealthServer(s, server)
+	setStatus(server, service, healthpb.HealthCheckResponse_SERVING)
+
+	if len(checks) > 0 {
+		go watch(server, service, checks)
+	}
+	return server
+}
+
+// watch 定期执行依赖检查并更新服务状态
+func watch(server *health.Server, service string, checks []Check) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	serving := true
+	for {
+		healthy := runChecks(checks)
+		if healthy != serving {
+			serving = healthy
+			if healthy {
+				log.Printf("Dependencies of %s recovered", service)
+				setStatus(server, service, healthpb.HealthCheckResponse_SERVING)
+			} else {
+				setStatus(server, service, healthpb.HealthCheckResponse_NOT_SERVING)
+			}
+		}
+		<-ticker.C
+	}
+}
+
+func runChecks(checks []Check) bool {
+	for _, check := range checks {
+		ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+		err := check(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("Health check failed: %v", err)
+			return false
+		}
+	}
+	return true
+}
+
+// setStatus 同时更新整体状态（空服务名）和具体服务的状态
+func setStatus(server *health.Server, service string, status healthpb.HealthCheckResponse_ServingStatus) {
+	server.SetServingStatus("", status)
+	server.SetServingStatus(service, status)
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// Rating 用户的 Glicko-2 评分（以 Glicko 标度存储）
+type Rating struct {
+	UserID      string    `bson:"_id" json:"user_id"`
+	Rating      float64   `bson:"rating" json:"rating"`
+	Deviation   float64   `bson:"deviation" json:"deviation"`   // 评分偏差 RD
+	Volatility  float64   `bson:"volatility" json:"volatility"` // 波动率 σ
+	GamesPlayed int       `bson:"games_played" json:"games_played"`
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Placement 玩家在一局中的名次，1 为第一名
+type Placement struct {
+	UserID    string
+	Placement int
+}
+
+// RatingChange 一局结算后的评分变化
+type RatingChange struct {
+	UserID    string
+	OldRating float64
+	NewRating float64
+	Deviation float64
+}
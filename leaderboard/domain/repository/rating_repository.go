@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"snake-game/leaderboard/domain/entity"
+)
+
+type RatingRepository interface {
+	// GetRatings 批量获取评分，没有记录的用户不在结果中
+	GetRatings(ctx context.Context, userIDs []string) (map[string]*entity.Rating, error)
+	SaveRatings(ctx context.Context, ratings []*entity.Rating) error
+	// MarkMatchRecorded 记录对局已结算，已结算过返回 false
+	MarkMatchRecorded(ctx context.Context, matchID string) (bool, error)
+	// UnmarkMatchRecorded 结算失败时撤销标记，允许重试
+	UnmarkMatchRecorded(ctx context.Context, matchID string) error
+}
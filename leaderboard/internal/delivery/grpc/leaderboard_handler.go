@@ -2,19 +2,24 @@ package grpc
 
 import (
 	"context"
+	"math"
 
+	"snake-game/auth"
+	"snake-game/leaderboard/domain/entity"
 	"snake-game/leaderboard/internal/usecase"
 	pb "snake-game/proto"
 )
 
 type LeaderboardHandler struct {
-	usecase *usecase.LeaderboardUsecase
+	usecase       *usecase.LeaderboardUsecase
+	ratingUsecase *usecase.RatingUsecase
 	pb.UnimplementedLeaderboardServiceServer
 }
 
-func NewLeaderboardHandler(usecase *usecase.LeaderboardUsecase) *LeaderboardHandler {
+func NewLeaderboardHandler(usecase *usecase.LeaderboardUsecase, ratingUsecase *usecase.RatingUsecase) *LeaderboardHandler {
 	return &LeaderboardHandler{
-		usecase: usecase,
+		usecase:       usecase,
+		ratingUsecase: ratingUsecase,
 	}
 }
 
@@ -46,8 +51,12 @@ func (h *LeaderboardHandler) GetLeaderboard(ctx context.Context, req *pb.GetLead
 	}, nil
 }
 
-// UpdateScore 更新分数
+// UpdateScore 更新分数，只接受游戏服务在对局结束时的上报
 func (h *LeaderboardHandler) UpdateScore(ctx context.Context, req *pb.UpdateScoreRequest) (*pb.UpdateScoreResponse, error) {
+	if err := auth.RequireInternal(ctx); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.UpdateScore(ctx, req.UserId, req.Score, req.GameWon)
 	if err != nil {
 		return &pb.UpdateScoreResponse{
@@ -91,4 +100,66 @@ func (h *LeaderboardHandler) GetUserRank(ctx context.Context, req *pb.GetUserRan
 		Rank:    rank,
 		TotalUsers: total,
 	}, nil
-}
\ No newline at end of file
+}
+
+// RecordMatchResult 按对局名次更新评分
+func (h *LeaderboardHandler) RecordMatchResult(ctx context.Context, req *pb.RecordMatchResultRequest) (*pb.RecordMatchResultResponse, error) {
+	if err := auth.RequireInternal(ctx); err != nil {
+		return nil, err
+	}
+
+	placements := make([]entity.Placement, len(req.Placements))
+	for i, placement := range req.Placements {
+		placements[i] = entity.Placement{
+			UserID:    placement.UserId,
+			Placement: int(placement.Placement),
+		}
+	}
+
+	changes, err := h.ratingUsecase.RecordMatchResult(ctx, req.MatchId, placements)
+	if err != nil {
+		return &pb.RecordMatchResultResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	pbChanges := make([]*pb.RatingChange, len(changes))
+	for i, change := range changes {
+		pbChanges[i] = &pb.RatingChange{
+			UserId:    change.UserID,
+			OldRating: roundRating(change.OldRating),
+			NewRating: roundRating(change.NewRating),
+			Deviation: roundRating(change.Deviation),
+		}
+	}
+
+	return &pb.RecordMatchResultResponse{
+		Success: true,
+		Message: "Match result recorded successfully",
+		Changes: pbChanges,
+	}, nil
+}
+
+// GetRating 获取用户评分
+func (h *LeaderboardHandler) GetRating(ctx context.Context, req *pb.GetRatingRequest) (*pb.GetRatingResponse, error) {
+	rating, err := h.ratingUsecase.GetRating(ctx, req.UserId)
+	if err != nil {
+		return &pb.GetRatingResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetRatingResponse{
+		Success:     true,
+		Message:     "Rating retrieved successfully",
+		Rating:      roundRating(rating.Rating),
+		Deviation:   roundRating(rating.Deviation),
+		GamesPlayed: int32(rating.GamesPlayed),
+	}, nil
+}
+
+func roundRating(value float64) int32 {
+	return int32(math.Round(value))
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"snake-game/leaderboard/domain/entity"
+	"snake-game/mongodb"
+)
+
+type ratingRepositoryImpl struct {
+	collection *mongo.Collection
+	matches    *mongo.Collection
+}
+
+func NewRatingRepository() *ratingRepositoryImpl {
+	return &ratingRepositoryImpl{
+		collection: mongodb.DB.Collection(mongodb.RatingCollection),
+		matches:    mongodb.DB.Collection(mongodb.RatedMatchCollection),
+	}
+}
+
+func (r *ratingRepositoryImpl) GetRatings(ctx context.Context, userIDs []string) (map[string]*entity.Rating, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ratings []*entity.Rating
+	if err = cursor.All(ctx, &ratings); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*entity.Rating, len(ratings))
+	for _, rating := range ratings {
+		result[rating.UserID] = rating
+	}
+	return result, nil
+}
+
+func (r *ratingRepositoryImpl) SaveRatings(ctx context.Context, ratings []*entity.Rating) error {
+	models := make([]mongo.WriteModel, len(ratings))
+	for i, rating := range ratings {
+		models[i] = mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": rating.UserID}).
+			SetReplacement(rating).
+			SetUpsert(true)
+	}
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *ratingRepositoryImpl) MarkMatchRecorded(ctx context.Context, matchID string) (bool, error) {
+	_, err := r.matches.InsertOne(ctx, bson.M{"_id": matchID, "recorded_at": time.Now()})
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ratingRepositoryImpl) UnmarkMatchRecorded(ctx context.Context, matchID string) error {
+	_, err := r.matches.DeleteOne(ctx, bson.M{"_id": matchID})
+	return err
+}
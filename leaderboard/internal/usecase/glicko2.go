@@ -0,0 +1,91 @@
+package usecase
+
+import "math"
+
+// Glicko-2 参数，见 http://www.glicko.net/glicko/glicko2.pdf
+const (
+	defaultRating     = 1500.0
+	defaultDeviation  = 350.0
+	defaultVolatility = 0.06
+	minDeviation      = 30.0 // 偏差下限，避免老玩家评分完全僵化
+	glickoScale       = 173.7178
+	glickoTau         = 0.5 // 波动率变化的约束
+	glickoEpsilon     = 0.000001
+)
+
+// glickoPlayer Glicko 标度下的评分
+type glickoPlayer struct {
+	rating     float64
+	deviation  float64
+	volatility float64
+}
+
+// glickoResult 对单个对手的结果，score 为 1 胜、0.5 平、0 负
+type glickoResult struct {
+	opponent glickoPlayer
+	score    float64
+}
+
+// updateGlicko2 将一局中对所有对手的结果视为一个评分周期，计算新的评分
+func updateGlicko2(player glickoPlayer, results []glickoResult) glickoPlayer {
+	if len(results) == 0 {
+		return player
+	}
+
+	mu := (player.rating - defaultRating) / glickoScale
+	phi := player.deviation / glickoScale
+	sigma := player.volatility
+
+	// 估计方差 v 和评分改进量 delta
+	var vInv, deltaSum float64
+	for _, result := range results {
+		muJ := (result.opponent.rating - defaultRating) / glickoScale
+		phiJ := result.opponent.deviation / glickoScale
+		g := 1 / math.Sqrt(1+3*phiJ*phiJ/(math.Pi*math.Pi))
+		e := 1 / (1 + math.Exp(-g*(mu-muJ)))
+		vInv += g * g * e * (1 - e)
+		deltaSum += g * (result.score - e)
+	}
+	v := 1 / vInv
+	delta := v * deltaSum
+
+	// 迭代求新的波动率（Illinois 算法）
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		return ex*(delta*delta-phi*phi-v-ex)/(2*math.Pow(phi*phi+v+ex, 2)) - (x-a)/(glickoTau*glickoTau)
+	}
+	lower := a
+	var upper float64
+	if delta*delta > phi*phi+v {
+		upper = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*glickoTau) < 0 {
+			k++
+		}
+		upper = a - k*glickoTau
+	}
+	fLower, fUpper := f(lower), f(upper)
+	for math.Abs(upper-lower) > glickoEpsilon {
+		c := lower + (lower-upper)*fLower/(fUpper-fLower)
+		fC := f(c)
+		if fC*fUpper <= 0 {
+			lower, fLower = upper, fUpper
+		} else {
+			fLower /= 2
+		}
+		upper, fUpper = c, fC
+	}
+	newSigma := math.Exp(lower / 2)
+
+	phiStar := math.Sqrt(phi*phi + newSigma*newSigma)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*deltaSum
+
+	return glickoPlayer{
+		rating:     newMu*glickoScale + defaultRating,
+		deviation:  math.Max(minDeviation, math.Min(defaultDeviation, newPhi*glickoScale)),
+		volatility: newSigma,
+	}
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"snake-game/leaderboard/domain/entity"
+)
+
+// memoryRatingRepo 仅用于测试的内存评分仓库
+type memoryRatingRepo struct {
+	ratings  map[string]*entity.Rating
+	recorded map[string]bool
+}
+
+func newMemoryRatingRepo() *memoryRatingRepo {
+	return &memoryRatingRepo{
+		ratings:  make(map[string]*entity.Rating),
+		recorded: make(map[string]bool),
+	}
+}
+
+func (r *memoryRatingRepo) GetRatings(ctx context.Context, userIDs []string) (map[string]*entity.Rating, error) {
+	result := make(map[string]*entity.Rating, len(userIDs))
+	for _, userID := range userIDs {
+		if rating, exists := r.ratings[userID]; exists {
+			result[userID] = rating
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRatingRepo) SaveRatings(ctx context.Context, ratings []*entity.Rating) error {
+	for _, rating := range ratings {
+		r.ratings[rating.UserID] = rating
+	}
+	return nil
+}
+
+func (r *memoryRatingRepo) MarkMatchRecorded(ctx context.Context, matchID string) (bool, error) {
+	if r.recorded[matchID] {
+		return false, nil
+	}
+	r.recorded[matchID] = true
+	return true, nil
+}
+
+func (r *memoryRatingRepo) UnmarkMatchRecorded(ctx context.Context, matchID string) error {
+	delete(r.recorded, matchID)
+	return nil
+}
+
+// TestUpdateGlicko2PaperExample 使用 Glickman 论文中的计算示例
+func TestUpdateGlicko2PaperExample(t *testing.T) {
+	player := glickoPlayer{rating: 1500, deviation: 200, volatility: 0.06}
+	results := []glickoResult{
+		{opponent: glickoPlayer{rating: 1400, deviation: 30, volatility: 0.06}, score: 1},
+		{opponent: glickoPlayer{rating: 1550, deviation: 100, volatility: 0.06}, score: 0},
+		{opponent: glickoPlayer{rating: 1700, deviation: 300, volatility: 0.06}, score: 0},
+	}
+
+	got := updateGlicko2(player, results)
+
+	tests := []struct {
+		name      string
+		got, want float64
+		tolerance float64
+	}{
+		{"rating", got.rating, 1464.06, 0.01},
+		{"deviation", got.deviation, 151.52, 0.01},
+		{"volatility", got.volatility, 0.05999, 0.00001},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > tt.tolerance {
+			t.Errorf("%s = %.5f, want %.5f", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestUpdateGlicko2NoResults(t *testing.T) {
+	player := glickoPlayer{rating: 1620, deviation: 80, volatility: 0.06}
+	if got := updateGlicko2(player, nil); got != player {
+		t.Errorf("updateGlicko2() = %+v, want unchanged %+v", got, player)
+	}
+}
+
+func TestRecordMatchResultThreePlayers(t *testing.T) {
+	ctx := context.Background()
+	uc := NewRatingUsecase(newMemoryRatingRepo())
+
+	placements := []entity.Placement{
+		{UserID: "third", Placement: 3},
+		{UserID: "first", Placement: 1},
+		{UserID: "second", Placement: 2},
+	}
+	changes, err := uc.RecordMatchResult(ctx, "match-1", placements)
+	if err != nil {
+		t.Fatalf("RecordMatchResult() error = %v", err)
+	}
+
+	byUser := make(map[string]*entity.RatingChange, len(changes))
+	for _, change := range changes {
+		byUser[change.UserID] = change
+	}
+	first, second, third := byUser["first"], byUser["second"], byUser["third"]
+	if first == nil || second == nil || third == nil {
+		t.Fatalf("changes = %+v, want one per player", changes)
+	}
+
+	// 三人赛前评分相同：第一名两胜、第二名一胜一负、第三名两负
+	if first.NewRating <= defaultRating {
+		t.Errorf("first: new rating %.2f, want above %.0f", first.NewRating, defaultRating)
+	}
+	if math.Abs(second.NewRating-defaultRating) > 1e-9 {
+		t.Errorf("second: new rating %.2f, want unchanged", second.NewRating)
+	}
+	if third.NewRating >= defaultRating {
+		t.Errorf("third: new rating %.2f, want below %.0f", third.NewRating, defaultRating)
+	}
+	if gain, loss := first.NewRating-defaultRating, defaultRating-third.NewRating; math.Abs(gain-loss) > 1e-9 {
+		t.Errorf("first gained %.4f but third lost %.4f, want symmetric", gain, loss)
+	}
+	for _, change := range changes {
+		if change.OldRating != defaultRating {
+			t.Errorf("%s: old rating %.2f, want %.0f", change.UserID, change.OldRating, defaultRating)
+		}
+		if change.Deviation >= defaultDeviation {
+			t.Errorf("%s: deviation %.2f, want below %.0f", change.UserID, change.Deviation, defaultDeviation)
+		}
+	}
+
+	saved, err := uc.GetRating(ctx, "first")
+	if err != nil {
+		t.Fatalf("GetRating() error = %v", err)
+	}
+	if saved.GamesPlayed != 1 || saved.Rating != first.NewRating {
+		t.Errorf("saved rating = %+v, want GamesPlayed 1 and rating %.2f", saved, first.NewRating)
+	}
+
+	if _, err := uc.RecordMatchResult(ctx, "match-1", placements); err == nil {
+		t.Error("recording the same match twice succeeded, want error")
+	}
+}
+
+func TestRecordMatchResultTiedPlacement(t *testing.T) {
+	ctx := context.Background()
+	uc := NewRatingUsecase(newMemoryRatingRepo())
+
+	changes, err := uc.RecordMatchResult(ctx, "match-1", []entity.Placement{
+		{UserID: "a", Placement: 1},
+		{UserID: "b", Placement: 1},
+		{UserID: "c", Placement: 3},
+	})
+	if err != nil {
+		t.Fatalf("RecordMatchResult() error = %v", err)
+	}
+
+	byUser := make(map[string]float64, len(changes))
+	for _, change := range changes {
+		byUser[change.UserID] = change.NewRating
+	}
+	if math.Abs(byUser["a"]-byUser["b"]) > 1e-9 {
+		t.Errorf("tied players got %.4f and %.4f, want equal", byUser["a"], byUser["b"])
+	}
+	if byUser["a"] <= defaultRating || byUser["c"] >= defaultRating {
+		t.Errorf("ratings = %v, want tied winners above and last place below %.0f", byUser, defaultRating)
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"snake-game/leaderboard/domain/repository"
 )
 
+// defaultLeaderboardLimit 未指定条数时返回的排行榜条目数
+const defaultLeaderboardLimit = 10
+
 type LeaderboardUsecase struct {
 	repo repository.LeaderboardRepository
 }
@@ -20,6 +23,10 @@ func NewLeaderboardUsecase(repo repository.LeaderboardRepository) *LeaderboardUs
 }
 
 func (uc *LeaderboardUsecase) GetLeaderboard(ctx context.Context, limit, offset int32) ([]*entity.LeaderboardEntry, int32, error) {
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+
 	entries, err := uc.repo.GetTopEntries(ctx, limit, offset)
 	if err != nil {
 		return nil, 0, errors.New("failed to get leaderboard")
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"snake-game/leaderboard/domain/entity"
+	"snake-game/leaderboard/domain/repository"
+)
+
+type RatingUsecase struct {
+	repo repository.RatingRepository
+}
+
+func NewRatingUsecase(repo repository.RatingRepository) *RatingUsecase {
+	return &RatingUsecase{
+		repo: repo,
+	}
+}
+
+// GetRating 获取用户评分，没有记录的用户返回初始评分
+func (uc *RatingUsecase) GetRating(ctx context.Context, userID string) (*entity.Rating, error) {
+	ratings, err := uc.repo.GetRatings(ctx, []string{userID})
+	if err != nil {
+		return nil, errors.New("failed to get rating")
+	}
+	if rating, exists := ratings[userID]; exists {
+		return rating, nil
+	}
+	return newRating(userID), nil
+}
+
+// RecordMatchResult 按名次结算一局多人对局：每名玩家与其他所有玩家两两比较，
+// 名次靠前记为胜、相同记为平，所有人都基于赛前评分同时更新。同一对局只结算一次
+func (uc *RatingUsecase) RecordMatchResult(ctx context.Context, matchID string, placements []entity.Placement) ([]*entity.RatingChange, error) {
+	if matchID == "" {
+		return nil, errors.New("missing match id")
+	}
+	if len(placements) < 2 {
+		return nil, errors.New("at least two players are required")
+	}
+
+	userIDs := make([]string, len(placements))
+	seen := make(map[string]bool, len(placements))
+	for i, placement := range placements {
+		if placement.UserID == "" || placement.Placement < 1 {
+			return nil, errors.New("invalid placement")
+		}
+		if seen[placement.UserID] {
+			return nil, errors.New("duplicate player in placements")
+		}
+		seen[placement.UserID] = true
+		userIDs[i] = placement.UserID
+	}
+
+	recorded, err := uc.repo.MarkMatchRecorded(ctx, matchID)
+	if err != nil {
+		return nil, errors.New("failed to record match")
+	}
+	if !recorded {
+		return nil, errors.New("match already recorded")
+	}
+
+	existing, err := uc.repo.GetRatings(ctx, userIDs)
+	if err != nil {
+		uc.unmark(ctx, matchID)
+		return nil, errors.New("failed to get ratings")
+	}
+	before := make(map[string]*entity.Rating, len(placements))
+	for _, userID := range userIDs {
+		if rating, exists := existing[userID]; exists {
+			before[userID] = rating
+		} else {
+			before[userID] = newRating(userID)
+		}
+	}
+
+	now := time.Now()
+	updated := make([]*entity.Rating, 0, len(placements))
+	changes := make([]*entity.RatingChange, 0, len(placements))
+	for _, placement := range placements {
+		old := before[placement.UserID]
+
+		results := make([]glickoResult, 0, len(placements)-1)
+		for _, other := range placements {
+			if other.UserID == placement.UserID {
+				continue
+			}
+			score := 0.5
+			if placement.Placement < other.Placement {
+				score = 1
+			} else if placement.Placement > other.Placement {
+				score = 0
+			}
+			results = append(results, glickoResult{opponent: toGlicko(before[other.UserID]), score: score})
+		}
+
+		next := updateGlicko2(toGlicko(old), results)
+		updated = append(updated, &entity.Rating{
+			UserID:      placement.UserID,
+			Rating:      next.rating,
+			Deviation:   next.deviation,
+			Volatility:  next.volatility,
+			GamesPlayed: old.GamesPlayed + 1,
+			UpdatedAt:   now,
+		})
+		changes = append(changes, &entity.RatingChange{
+			UserID:    placement.UserID,
+			OldRating: old.Rating,
+			NewRating: next.rating,
+			Deviation: next.deviation,
+		})
+	}
+
+	if err := uc.repo.SaveRatings(ctx, updated); err != nil {
+		uc.unmark(ctx, matchID)
+		return nil, errors.New("failed to save ratings")
+	}
+	return changes, nil
+}
+
+// unmark 撤销对局的结算标记，允许调用方重试
+func (uc *RatingUsecase) unmark(ctx context.Context, matchID string) {
+	if err := uc.repo.UnmarkMatchRecorded(ctx, matchID); err != nil {
+		log.Printf("Failed to unmark match %s: %v", matchID, err)
+	}
+}
+
+func newRating(userID string) *entity.Rating {
+	return &entity.Rating{
+		UserID:     userID,
+		Rating:     defaultRating,
+		Deviation:  defaultDeviation,
+		Volatility: defaultVolatility,
+	}
+}
+
+func toGlicko(rating *entity.Rating) glickoPlayer {
+	return glickoPlayer{
+		rating:     rating.Rating,
+		deviation:  rating.Deviation,
+		volatility: rating.Volatility,
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"google.golang.org/grpc"
+	"snake-game/auth"
+	"snake-game/healthcheck"
 	grpc_handler "snake-game/leaderboard/internal/delivery/grpc"
 	"snake-game/leaderboard/internal/repository"
 	"snake-game/leaderboard/internal/usecase"
@@ -29,12 +31,14 @@ func main() {
 
 	// 初始化仓库层
 	leaderboardRepo := repository.NewLeaderboardRepository()
+	ratingRepo := repository.NewRatingRepository()
 
 	// 初始化业务逻辑层
 	leaderboardUsecase := usecase.NewLeaderboardUsecase(leaderboardRepo)
+	ratingUsecase := usecase.NewRatingUsecase(ratingRepo)
 
 	// 初始化通信层
-	leaderboardHandler := grpc_handler.NewLeaderboardHandler(leaderboardUsecase)
+	leaderboardHandler := grpc_handler.NewLeaderboardHandler(leaderboardUsecase, ratingUsecase)
 
 	// 启动 gRPC 服务器
 	lis, err := net.Listen("tcp", ":50054")
@@ -42,9 +46,13 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	// 识别调用方，handler 据此校验操作权限
+	s := grpc.NewServer(auth.ServerOptions(auth.NewLobbyValidator())...)
 	pb.RegisterLeaderboardServiceServer(s, leaderboardHandler)
 
+	// 标准健康检查，网关据此判断服务是否可用
+	healthcheck.Register(s, "leaderboard_service.LeaderboardService", mongodb.Ping)
+
 	log.Println("Leaderboard service is running on :50054")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
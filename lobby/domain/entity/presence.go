@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// 对外展示的在线状态
+const (
+	PresenceOnline  = "online"
+	PresenceIdle    = "idle"
+	PresenceInQueue = "in_queue"
+	PresenceInRoom  = "in_room"
+	PresenceInGame  = "in_game"
+	PresenceOffline = "offline"
+)
+
+// Presence 用户的实时在线状态，只保存在内存中
+type Presence struct {
+	UserID   string
+	Online   bool      // 心跳尚未过期
+	Idle     bool      // 客户端上报无操作
+	Activity string    // in_queue, in_room, in_game，为空表示没有进行中的活动
+	RoomID   string    // in_room、in_game 时所在的房间
+	LastSeen time.Time // 最近一次心跳的时间
+}
+
+// Status 对外展示的状态：离线优先，其次是进行中的活动，最后区分在线和空闲
+func (p *Presence) Status() string {
+	switch {
+	case !p.Online:
+		return PresenceOffline
+	case p.Activity != "":
+		return p.Activity
+	case p.Idle:
+		return PresenceIdle
+	default:
+		return PresenceOnline
+	}
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+)
+
+// 令牌类型
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+// Token 已签发的令牌，只保存摘要，明文只返回给客户端一次
+type Token struct {
+	Hash      string    `bson:"_id"` // 令牌明文的 SHA-256 摘要
+	UserID    string    `bson:"user_id"`
+	Type      string    `bson:"type"`       // access, refresh
+	SessionID string    `bson:"session_id"` // 同一次登录签发的令牌共享会话ID，登出时一起吊销
+	ExpiresAt time.Time `bson:"expires_at"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// TokenPair 登录或刷新时返回给客户端的令牌
+type TokenPair struct {
+	UserID       string
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration // 访问令牌的有效期
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"snake-game/lobby/domain/entity"
+)
+
+type PresenceRepository interface {
+	// GetPresence 没有记录时返回 nil
+	GetPresence(ctx context.Context, userID string) (*entity.Presence, error)
+	SavePresence(ctx context.Context, presence *entity.Presence) error
+	// FindExpired 返回仍标记为在线、但最近一次心跳早于 before 的用户
+	FindExpired(ctx context.Context, before time.Time) ([]*entity.Presence, error)
+}
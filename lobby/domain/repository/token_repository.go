@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"snake-game/lobby/domain/entity"
+)
+
+type TokenRepository interface {
+	SaveTokens(ctx context.Context, tokens ...*entity.Token) error
+	// FindToken 令牌不存在时返回 nil
+	FindToken(ctx context.Context, hash string) (*entity.Token, error)
+	// DeleteToken 删除令牌，返回令牌是否存在（用于保证刷新令牌只能使用一次）
+	DeleteToken(ctx context.Context, hash string) (bool, error)
+	DeleteSession(ctx context.Context, sessionID string) error
+	DeleteUserTokens(ctx context.Context, userID string) error
+}
@@ -9,5 +9,9 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user *entity.User) (string, error)
 	FindByUsername(ctx context.Context, username string) (*entity.User, error)
 	FindByID(ctx context.Context, id string) (*entity.User, error)
+	// FindByIDs 批量查询用户，不存在或格式错误的ID直接忽略
+	FindByIDs(ctx context.Context, ids []string) ([]*entity.User, error)
 	UpdateOnlineStatus(ctx context.Context, id string, online bool) error
+	// MarkAllOffline 将所有用户标记为离线
+	MarkAllOffline(ctx context.Context) error
 }
\ No newline at end of file
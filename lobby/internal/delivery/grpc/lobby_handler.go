@@ -2,19 +2,27 @@ package grpc
 
 import (
 	"context"
+	"log"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"snake-game/auth"
+	"snake-game/lobby/domain/entity"
 	"snake-game/lobby/internal/usecase"
 	pb "snake-game/proto"
 )
 
 type LobbyHandler struct {
-	usecase *usecase.AuthUsecase
+	usecase  *usecase.AuthUsecase
+	presence *usecase.PresenceUsecase
 	pb.UnimplementedLobbyServiceServer
 }
 
-func NewLobbyHandler(usecase *usecase.AuthUsecase) *LobbyHandler {
+func NewLobbyHandler(usecase *usecase.AuthUsecase, presence *usecase.PresenceUsecase) *LobbyHandler {
 	return &LobbyHandler{
-		usecase: usecase,
+		usecase:  usecase,
+		presence: presence,
 	}
 }
 
@@ -37,7 +45,7 @@ func (h *LobbyHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*
 
 // Login 用户登录
 func (h *LobbyHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	user, err := h.usecase.Login(ctx, req.Username, req.Password)
+	user, tokens, err := h.usecase.Login(ctx, req.Username, req.Password)
 	if err != nil {
 		return &pb.LoginResponse{
 			Success: false,
@@ -45,11 +53,19 @@ func (h *LobbyHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Log
 		}, nil
 	}
 
+	// 登录视为一次心跳，之后由客户端定期上报
+	if _, err := h.presence.Heartbeat(ctx, user.ID, false); err != nil {
+		log.Printf("Failed to record presence of %s: %v", user.ID, err)
+	}
+
 	return &pb.LoginResponse{
-		Success:  true,
-		Message:  "Login successful",
-		UserId:   user.ID,
-		Username: user.Username,
+		Success:      true,
+		Message:      "Login successful",
+		UserId:       user.ID,
+		Username:     user.Username,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
 	}, nil
 }
 
@@ -66,15 +82,15 @@ func (h *LobbyHandler) GetUserProfile(ctx context.Context, req *pb.GetUserProfil
 	// 获取用户排行榜信息
 	// 这里需要根据实际情况实现排行榜获取逻辑
 
+	pbUser := publicUser(ctx, user, h.presenceOf(ctx, []string{user.ID})[user.ID])
+	// 邮箱只返回给用户本人
+	if callerID, err := auth.CallerID(ctx); err == nil && callerID == req.UserId {
+		pbUser.Email = user.Email
+	}
+
 	return &pb.GetUserProfileResponse{
 		Success: true,
-		User: &pb.User{
-			Id:        user.ID,
-			Username:  user.Username,
-			Email:     user.Email,
-			Online:    user.Online,
-			CreatedAt: user.CreatedAt.Unix(),
-		},
+		User:    pbUser,
 		Score:       0, // Placeholder - 实际实现时需要从排行榜服务获取
 		GamesWon:    0, // Placeholder
 		GamesPlayed: 0, // Placeholder
@@ -83,7 +99,11 @@ func (h *LobbyHandler) GetUserProfile(ctx context.Context, req *pb.GetUserProfil
 
 // Logout 用户登出
 func (h *LobbyHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
-	err := h.usecase.Logout(ctx, req.UserId)
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	err := h.usecase.Logout(ctx, req.UserId, req.AccessToken)
 	if err != nil {
 		return &pb.LogoutResponse{
 			Success: false,
@@ -91,8 +111,245 @@ func (h *LobbyHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.L
 		}, nil
 	}
 
+	if err := h.presence.SetOffline(ctx, req.UserId); err != nil {
+		log.Printf("Failed to record presence of %s: %v", req.UserId, err)
+	}
+
 	return &pb.LogoutResponse{
 		Success: true,
 		Message: "Logout successful",
 	}, nil
-}
\ No newline at end of file
+}
+// RefreshToken 使用刷新令牌换取新的令牌
+func (h *LobbyHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	tokens, err := h.usecase.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return &pb.RefreshTokenResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.RefreshTokenResponse{
+		Success:      true,
+		Message:      "Token refreshed",
+		UserId:       tokens.UserID,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+	}, nil
+}
+
+// ValidateToken 校验访问令牌
+func (h *LobbyHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
+	userID, err := h.usecase.ValidateToken(ctx, req.AccessToken)
+	if err != nil {
+		return &pb.ValidateTokenResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ValidateTokenResponse{
+		Success: true,
+		Message: "Token is valid",
+		UserId:  userID,
+	}, nil
+}
+
+// GetUsersByIds 批量获取用户的公开资料，仅供好友服务等内部服务调用
+func (h *LobbyHandler) GetUsersByIds(ctx context.Context, req *pb.GetUsersByIdsRequest) (*pb.GetUsersByIdsResponse, error) {
+	if err := auth.RequireInternal(ctx); err != nil {
+		return nil, err
+	}
+
+	users, err := h.usecase.GetUsersByIDs(ctx, req.UserIds)
+	if err != nil {
+		return &pb.GetUsersByIdsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	userIDs := make([]string, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+	presences := h.presenceOf(ctx, userIDs)
+
+	pbUsers := make([]*pb.User, len(users))
+	for i, user := range users {
+		pbUsers[i] = publicUser(ctx, user, presences[user.ID])
+	}
+
+	return &pb.GetUsersByIdsResponse{
+		Success: true,
+		Message: "Users retrieved successfully",
+		Users:   pbUsers,
+	}, nil
+}
+
+// FindUserByUsername 按用户名查找用户，仅供好友服务等内部服务调用
+func (h *LobbyHandler) FindUserByUsername(ctx context.Context, req *pb.FindUserByUsernameRequest) (*pb.FindUserByUsernameResponse, error) {
+	if err := auth.RequireInternal(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := h.usecase.FindUserByUsername(ctx, req.Username)
+	if err != nil {
+		return &pb.FindUserByUsernameResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.FindUserByUsernameResponse{
+		Success: true,
+		Message: "User found",
+		User:    publicUser(ctx, user, h.presenceOf(ctx, []string{user.ID})[user.ID]),
+	}, nil
+}
+
+// publicUser 其他用户可见的资料，不包含邮箱。在线状态以心跳为准
+func publicUser(ctx context.Context, user *entity.User, presence *entity.Presence) *pb.User {
+	if presence == nil {
+		presence = &entity.Presence{UserID: user.ID}
+	}
+	pbUser := &pb.User{
+		Id:        user.ID,
+		Username:  user.Username,
+		Online:    presence.Online,
+		CreatedAt: user.CreatedAt.Unix(),
+	}
+	if presenceVisible(ctx, user.ID) {
+		pbUser.Presence = toPbPresence(presence)
+	}
+	return pbUser
+}
+
+// presenceVisible 详细的在线状态（排队、所在房间和对局）只对用户本人和内部服务可见，
+// 好友服务按好友关系过滤后再转交给用户
+func presenceVisible(ctx context.Context, userID string) bool {
+	caller, ok := auth.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	return caller.UserID == userID || (caller.Internal && caller.UserID == "")
+}
+
+// presenceOf 查询用户的在线状态，键为用户ID。查询失败时返回空结果，用户按离线处理
+func (h *LobbyHandler) presenceOf(ctx context.Context, userIDs []string) map[string]*entity.Presence {
+	result := make(map[string]*entity.Presence, len(userIDs))
+	presences, err := h.presence.GetPresence(ctx, userIDs)
+	if err != nil {
+		log.Printf("Failed to get presence: %v", err)
+		return result
+	}
+	for _, presence := range presences {
+		result[presence.UserID] = presence
+	}
+	return result
+}
+
+// Heartbeat 记录客户端心跳
+func (h *LobbyHandler) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	presence, err := h.presence.Heartbeat(ctx, req.UserId, req.Idle)
+	if err != nil {
+		return &pb.HeartbeatResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.HeartbeatResponse{
+		Success:    true,
+		Message:    "Heartbeat received",
+		Presence:   toPbPresence(presence),
+		TtlSeconds: int64(usecase.PresenceTTL.Seconds()),
+	}, nil
+}
+
+// UpdateActivity 接收其他服务上报的用户活动
+func (h *LobbyHandler) UpdateActivity(ctx context.Context, req *pb.UpdateActivityRequest) (*pb.UpdateActivityResponse, error) {
+	if err := auth.RequireInternal(ctx); err != nil {
+		return nil, err
+	}
+
+	err := h.presence.UpdateActivity(ctx, req.UserId, req.Activity, req.RoomId, req.ExpectedActivity, req.ExpectedRoomId)
+	if err != nil {
+		return &pb.UpdateActivityResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.UpdateActivityResponse{
+		Success: true,
+		Message: "Activity updated",
+	}, nil
+}
+
+// GetPresence 批量获取用户的在线状态。不对用户开放，好友的状态通过好友服务获取
+func (h *LobbyHandler) GetPresence(ctx context.Context, req *pb.GetPresenceRequest) (*pb.GetPresenceResponse, error) {
+	if err := auth.RequireInternal(ctx); err != nil {
+		return nil, err
+	}
+
+	presences, err := h.presence.GetPresence(ctx, req.UserIds)
+	if err != nil {
+		return &pb.GetPresenceResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	pbPresences := make([]*pb.Presence, len(presences))
+	for i, presence := range presences {
+		pbPresences[i] = toPbPresence(presence)
+	}
+
+	return &pb.GetPresenceResponse{
+		Success:   true,
+		Message:   "Presence retrieved successfully",
+		Presences: pbPresences,
+	}, nil
+}
+
+// WatchPresence 推送一组用户的在线状态变化
+func (h *LobbyHandler) WatchPresence(req *pb.WatchPresenceRequest, stream pb.LobbyService_WatchPresenceServer) error {
+	if err := auth.RequireInternal(stream.Context()); err != nil {
+		return err
+	}
+
+	presences, cancel, err := h.presence.WatchPresence(stream.Context(), req.UserIds)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer cancel()
+
+	for presence := range presences {
+		if err := stream.Send(toPbPresence(presence)); err != nil {
+			return err
+		}
+	}
+	// 订阅方落后过多时通道被关闭，由调用方重新订阅
+	return status.Error(codes.Unavailable, "presence stream interrupted")
+}
+
+// toPbPresence 转换在线状态
+func toPbPresence(presence *entity.Presence) *pb.Presence {
+	lastSeen := int64(0)
+	if !presence.LastSeen.IsZero() {
+		lastSeen = presence.LastSeen.Unix()
+	}
+	return &pb.Presence{
+		UserId:   presence.UserID,
+		Status:   presence.Status(),
+		RoomId:   presence.RoomID,
+		LastSeen: lastSeen,
+	}
+}
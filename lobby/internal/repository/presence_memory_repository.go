@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"snake-game/lobby/domain/entity"
+	"snake-game/lobby/domain/repository"
+)
+
+type presenceMemoryRepository struct {
+	presences map[string]*entity.Presence
+	mutex     sync.RWMutex
+}
+
+// NewPresenceMemoryRepository 在线状态变化频繁且可以由心跳重建，因此只保存在内存中
+func NewPresenceMemoryRepository() repository.PresenceRepository {
+	return &presenceMemoryRepository{
+		presences: make(map[string]*entity.Presence),
+	}
+}
+
+func (r *presenceMemoryRepository) GetPresence(ctx context.Context, userID string) (*entity.Presence, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	presence, exists := r.presences[userID]
+	if !exists {
+		return nil, nil
+	}
+	copied := *presence
+	return &copied, nil
+}
+
+func (r *presenceMemoryRepository) SavePresence(ctx context.Context, presence *entity.Presence) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	copied := *presence
+	r.presences[presence.UserID] = &copied
+	return nil
+}
+
+func (r *presenceMemoryRepository) FindExpired(ctx context.Context, before time.Time) ([]*entity.Presence, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	expired := make([]*entity.Presence, 0)
+	for _, presence := range r.presences {
+		if presence.Online && presence.LastSeen.Before(before) {
+			copied := *presence
+			expired = append(expired, &copied)
+		}
+	}
+	return expired, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"snake-game/lobby/domain/entity"
+	"snake-game/lobby/domain/repository"
+	"snake-game/mongodb"
+)
+
+type tokenRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+func NewTokenRepository() repository.TokenRepository {
+	r := &tokenRepositoryImpl{
+		collection: mongodb.DB.Collection(mongodb.TokenCollection),
+	}
+	r.ensureExpiryIndex()
+	return r
+}
+
+// ensureExpiryIndex 创建 expires_at 上的 TTL 索引，由 MongoDB 删除过期的令牌，
+// 否则没有再被查询的令牌会一直留在集合中。创建失败不影响服务，过期令牌仍会在查询时被拒绝
+func (r *tokenRepositoryImpl) ensureExpiryIndex() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		log.Printf("Failed to create token expiry index: %v", err)
+	}
+}
+
+func (r *tokenRepositoryImpl) SaveTokens(ctx context.Context, tokens ...*entity.Token) error {
+	documents := make([]interface{}, len(tokens))
+	for i, token := range tokens {
+		documents[i] = token
+	}
+	_, err := r.collection.InsertMany(ctx, documents)
+	return err
+}
+
+func (r *tokenRepositoryImpl) FindToken(ctx context.Context, hash string) (*entity.Token, error) {
+	var token entity.Token
+	err := r.collection.FindOne(ctx, bson.M{"_id": hash}).Decode(&token)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *tokenRepositoryImpl) DeleteToken(ctx context.Context, hash string) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": hash})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (r *tokenRepositoryImpl) DeleteSession(ctx context.Context, sessionID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"session_id": sessionID})
+	return err
+}
+
+func (r *tokenRepositoryImpl) DeleteUserTokens(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -55,6 +55,30 @@ func (r *userRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.U
 	return &user, nil
 }
 
+func (r *userRepositoryImpl) FindByIDs(ctx context.Context, ids []string) ([]*entity.User, error) {
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+	if len(objectIDs) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objectIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*entity.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (r *userRepositoryImpl) UpdateOnlineStatus(ctx context.Context, id string, online bool) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -70,4 +94,14 @@ func (r *userRepositoryImpl) UpdateOnlineStatus(ctx context.Context, id string,
 	}
 	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
 	return err
+}
+
+func (r *userRepositoryImpl) MarkAllOffline(ctx context.Context) error {
+	_, err := r.collection.UpdateMany(ctx, bson.M{"online": true}, bson.M{
+		"$set": bson.M{
+			"online":     false,
+			"updated_at": time.Now(),
+		},
+	})
+	return err
 }
\ No newline at end of file
@@ -14,12 +14,14 @@ import (
 )
 
 type AuthUsecase struct {
-	userRepo repository.UserRepository
+	userRepo  repository.UserRepository
+	tokenRepo repository.TokenRepository
 }
 
-func NewAuthUsecase(userRepo repository.UserRepository) *AuthUsecase {
+func NewAuthUsecase(userRepo repository.UserRepository, tokenRepo repository.TokenRepository) *AuthUsecase {
 	return &AuthUsecase{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
 	}
 }
 
@@ -76,19 +78,31 @@ func (uc *AuthUsecase) Register(ctx context.Context, username, password, email s
 	return userID, nil
 }
 
-func (uc *AuthUsecase) Login(ctx context.Context, username, password string) (*entity.User, error) {
+// Login 校验用户名密码并为本次登录签发令牌
+func (uc *AuthUsecase) Login(ctx context.Context, username, password string) (*entity.User, *entity.TokenPair, error) {
 	user, err := uc.userRepo.FindByUsername(ctx, username)
 	if err != nil {
 		if err.Error() == "mongo: no documents in result" {
-			return nil, errors.New("invalid username or password")
+			return nil, nil, errors.New("invalid username or password")
 		}
-		return nil, errors.New("internal server error")
+		return nil, nil, errors.New("internal server error")
 	}
 
 	// 验证密码
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return nil, errors.New("invalid username or password")
+		return nil, nil, errors.New("invalid username or password")
+	}
+
+	// 每次登录是一个独立会话，登出时只吊销本会话的令牌
+	sessionID, err := generateToken()
+	if err != nil {
+		return nil, nil, errors.New("failed to issue token")
+	}
+	tokens, err := uc.issueTokens(ctx, user.ID, sessionID)
+	if err != nil {
+		log.Printf("Failed to issue tokens for user %s: %v", user.ID, err)
+		return nil, nil, errors.New("failed to issue token")
 	}
 
 	// 更新用户状态为在线
@@ -97,7 +111,7 @@ func (uc *AuthUsecase) Login(ctx context.Context, username, password string) (*e
 		// 记录警告，但不返回错误
 	}
 
-	return user, nil
+	return user, tokens, nil
 }
 
 func (uc *AuthUsecase) GetUserProfile(ctx context.Context, userID string) (*entity.User, error) {
@@ -111,7 +125,40 @@ func (uc *AuthUsecase) GetUserProfile(ctx context.Context, userID string) (*enti
 	return user, nil
 }
 
-func (uc *AuthUsecase) Logout(ctx context.Context, userID string) error {
+// maxUserBatch 单次批量查询的用户数上限
+const maxUserBatch = 200
+
+// GetUsersByIDs 批量查询用户，结果中不包含不存在的用户
+func (uc *AuthUsecase) GetUsersByIDs(ctx context.Context, userIDs []string) ([]*entity.User, error) {
+	if len(userIDs) > maxUserBatch {
+		return nil, errors.New("too many user ids")
+	}
+	users, err := uc.userRepo.FindByIDs(ctx, userIDs)
+	if err != nil {
+		log.Printf("Failed to find users: %v", err)
+		return nil, errors.New("internal server error")
+	}
+	return users, nil
+}
+
+// FindUserByUsername 按用户名查找用户
+func (uc *AuthUsecase) FindUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	user, err := uc.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if err.Error() == "mongo: no documents in result" {
+			return nil, errors.New("user not found")
+		}
+		return nil, errors.New("internal server error")
+	}
+	return user, nil
+}
+
+// Logout 吊销令牌并将用户标记为离线
+func (uc *AuthUsecase) Logout(ctx context.Context, userID, accessToken string) error {
+	if err := uc.revokeTokens(ctx, userID, accessToken); err != nil {
+		return err
+	}
+
 	err := uc.userRepo.UpdateOnlineStatus(ctx, userID, false)
 	if err != nil {
 		return errors.New("failed to update user status")
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"sync"
+
+	"snake-game/lobby/domain/entity"
+)
+
+// 每个订阅者的状态变化缓冲区大小
+const presenceBufferSize = 64
+
+// presenceSubscriber 关注一组用户状态变化的订阅者
+type presenceSubscriber struct {
+	presences chan *entity.Presence
+	userIDs   []string
+}
+
+// presenceBroadcaster 按用户向订阅者扇出状态变化，发布不会被慢消费者阻塞
+type presenceBroadcaster struct {
+	users map[string]map[*presenceSubscriber]struct{}
+	mutex sync.Mutex
+}
+
+func newPresenceBroadcaster() *presenceBroadcaster {
+	return &presenceBroadcaster{
+		users: make(map[string]map[*presenceSubscriber]struct{}),
+	}
+}
+
+// subscribe 注册订阅者
+func (b *presenceBroadcaster) subscribe(userIDs []string) *presenceSubscriber {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	sub := &presenceSubscriber{
+		presences: make(chan *entity.Presence, presenceBufferSize),
+		userIDs:   userIDs,
+	}
+	for _, userID := range userIDs {
+		if b.users[userID] == nil {
+			b.users[userID] = make(map[*presenceSubscriber]struct{})
+		}
+		b.users[userID][sub] = struct{}{}
+	}
+	return sub
+}
+
+// unsubscribe 移除订阅者并关闭其通道，可重复调用
+func (b *presenceBroadcaster) unsubscribe(sub *presenceSubscriber) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.removeLocked(sub)
+}
+
+// publish 投递状态变化；订阅者缓冲区已满说明其已严重落后，直接断开让其重新订阅
+func (b *presenceBroadcaster) publish(presence *entity.Presence) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for sub := range b.users[presence.UserID] {
+		copied := *presence
+		select {
+		case sub.presences <- &copied:
+		default:
+			b.removeLocked(sub)
+		}
+	}
+}
+
+func (b *presenceBroadcaster) removeLocked(sub *presenceSubscriber) {
+	removed := false
+	for _, userID := range sub.userIDs {
+		subs, exists := b.users[userID]
+		if !exists {
+			continue
+		}
+		if _, exists := subs[sub]; exists {
+			delete(subs, sub)
+			removed = true
+		}
+		if len(subs) == 0 {
+			delete(b.users, userID)
+		}
+	}
+	if removed {
+		close(sub.presences)
+	}
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"snake-game/lobby/domain/entity"
+	"snake-game/lobby/domain/repository"
+)
+
+const (
+	// PresenceTTL 超过该时间没有心跳的用户视为离线，客户端应以更短的间隔上报
+	PresenceTTL = 60 * time.Second
+	// presenceSweepInterval 检查心跳过期的间隔
+	presenceSweepInterval = 10 * time.Second
+	// maxPresenceBatch 单次查询或订阅的用户数上限
+	maxPresenceBatch = 500
+)
+
+type PresenceUsecase struct {
+	presenceRepo repository.PresenceRepository
+	userRepo     repository.UserRepository
+	broadcaster  *presenceBroadcaster
+	mutex        sync.Mutex // 串行化状态的读取和修改，保证发布的变化有序
+}
+
+func NewPresenceUsecase(presenceRepo repository.PresenceRepository, userRepo repository.UserRepository) *PresenceUsecase {
+	// 在线状态只保存在内存中，清除上次运行遗留的在线标记，客户端的下一次心跳会恢复
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := userRepo.MarkAllOffline(ctx); err != nil {
+		log.Printf("Failed to reset online status: %v", err)
+	}
+
+	uc := &PresenceUsecase{
+		presenceRepo: presenceRepo,
+		userRepo:     userRepo,
+		broadcaster:  newPresenceBroadcaster(),
+	}
+	go uc.expirePresence()
+	return uc
+}
+
+// Heartbeat 记录客户端心跳，用户重新上线时通知关注者
+func (uc *PresenceUsecase) Heartbeat(ctx context.Context, userID string, idle bool) (*entity.Presence, error) {
+	return uc.update(ctx, userID, func(presence *entity.Presence) bool {
+		presence.Online = true
+		presence.Idle = idle
+		presence.LastSeen = time.Now()
+		return true
+	})
+}
+
+// SetOffline 用户登出时立即离线，不再等待心跳过期
+func (uc *PresenceUsecase) SetOffline(ctx context.Context, userID string) error {
+	_, err := uc.update(ctx, userID, func(presence *entity.Presence) bool {
+		presence.Online = false
+		return true
+	})
+	return err
+}
+
+// UpdateActivity 记录其他服务上报的用户活动。expectedActivity、expectedRoomID 非空时
+// 只有当前活动与之一致才更新，例如匹配服务取消排队时不能清除房间服务已经设置的 in_room
+func (uc *PresenceUsecase) UpdateActivity(ctx context.Context, userID, activity, roomID, expectedActivity, expectedRoomID string) error {
+	switch activity {
+	case "", entity.PresenceInQueue:
+		roomID = ""
+	case entity.PresenceInRoom, entity.PresenceInGame:
+		if roomID == "" {
+			return errors.New("missing room id")
+		}
+	default:
+		return errors.New("invalid activity")
+	}
+
+	_, err := uc.update(ctx, userID, func(presence *entity.Presence) bool {
+		if expectedActivity != "" && presence.Activity != expectedActivity {
+			return false
+		}
+		if expectedRoomID != "" && presence.RoomID != expectedRoomID {
+			return false
+		}
+		presence.Activity = activity
+		presence.RoomID = roomID
+		return true
+	})
+	return err
+}
+
+// GetPresence 按请求顺序返回用户的在线状态，没有记录的用户视为离线
+func (uc *PresenceUsecase) GetPresence(ctx context.Context, userIDs []string) ([]*entity.Presence, error) {
+	if len(userIDs) > maxPresenceBatch {
+		return nil, errors.New("too many user ids")
+	}
+
+	presences := make([]*entity.Presence, len(userIDs))
+	for i, userID := range userIDs {
+		presence, err := uc.presenceRepo.GetPresence(ctx, userID)
+		if err != nil {
+			return nil, errors.New("failed to get presence")
+		}
+		if presence == nil {
+			presence = &entity.Presence{UserID: userID}
+		}
+		presences[i] = presence
+	}
+	return presences, nil
+}
+
+// WatchPresence 先推送用户的当前状态，之后推送状态变化。返回的取消函数必须在订阅方退出时调用，
+// 通道被关闭说明订阅方落后过多，应重新订阅
+func (uc *PresenceUsecase) WatchPresence(ctx context.Context, userIDs []string) (<-chan *entity.Presence, func(), error) {
+	if len(userIDs) > maxPresenceBatch {
+		return nil, nil, errors.New("too many user ids")
+	}
+
+	// 持有锁完成订阅和读取快照，保证快照之后的变化都能收到且顺序正确
+	uc.mutex.Lock()
+	defer uc.mutex.Unlock()
+
+	sub := uc.broadcaster.subscribe(userIDs)
+	cancel := func() {
+		uc.broadcaster.unsubscribe(sub)
+	}
+
+	events := make(chan *entity.Presence, len(userIDs)+presenceBufferSize)
+	for _, userID := range userIDs {
+		presence, err := uc.presenceRepo.GetPresence(ctx, userID)
+		if err != nil {
+			cancel()
+			return nil, nil, errors.New("failed to get presence")
+		}
+		if presence == nil {
+			presence = &entity.Presence{UserID: userID}
+		}
+		events <- presence
+	}
+
+	go func() {
+		defer close(events)
+		for presence := range sub.presences {
+			select {
+			case events <- presence:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, cancel, nil
+}
+
+// update 修改用户的在线状态。modify 返回 false 表示不做修改；
+// 对外展示的状态变化时通知关注者，上线或离线时同步用户表中的在线标记
+func (uc *PresenceUsecase) update(ctx context.Context, userID string, modify func(presence *entity.Presence) bool) (*entity.Presence, error) {
+	if userID == "" {
+		return nil, errors.New("missing user id")
+	}
+
+	uc.mutex.Lock()
+	presence, err := uc.presenceRepo.GetPresence(ctx, userID)
+	if err != nil {
+		uc.mutex.Unlock()
+		return nil, errors.New("failed to get presence")
+	}
+	if presence == nil {
+		presence = &entity.Presence{UserID: userID}
+	}
+	before := *presence
+
+	if !modify(presence) {
+		uc.mutex.Unlock()
+		return presence, nil
+	}
+	if err := uc.presenceRepo.SavePresence(ctx, presence); err != nil {
+		uc.mutex.Unlock()
+		return nil, errors.New("failed to save presence")
+	}
+	if presence.Status() != before.Status() || presence.RoomID != before.RoomID {
+		uc.broadcaster.publish(presence)
+	}
+	uc.mutex.Unlock()
+
+	if presence.Online != before.Online {
+		if err := uc.userRepo.UpdateOnlineStatus(ctx, userID, presence.Online); err != nil {
+			log.Printf("Failed to update online status of %s: %v", userID, err)
+		}
+	}
+	return presence, nil
+}
+
+// expirePresence 定期将心跳过期的用户标记为离线（如直接关闭了浏览器）
+func (uc *PresenceUsecase) expirePresence() {
+	ticker := time.NewTicker(presenceSweepInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		deadline := time.Now().Add(-PresenceTTL)
+		expired, err := uc.presenceRepo.FindExpired(ctx, deadline)
+		if err != nil {
+			log.Printf("Failed to find expired presence: %v", err)
+		}
+		for _, presence := range expired {
+			// 查询之后可能刚收到心跳，修改前重新检查
+			_, err := uc.update(ctx, presence.UserID, func(current *entity.Presence) bool {
+				if !current.Online || !current.LastSeen.Before(deadline) {
+					return false
+				}
+				current.Online = false
+				return true
+			})
+			if err != nil {
+				log.Printf("Failed to expire presence of %s: %v", presence.UserID, err)
+			}
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"snake-game/lobby/domain/entity"
+)
+
+// 令牌有效期
+const (
+	accessTokenTTL  = 30 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var errInvalidToken = errors.New("invalid or expired token")
+
+// issueTokens 为会话签发一对新的访问令牌和刷新令牌
+func (uc *AuthUsecase) issueTokens(ctx context.Context, userID, sessionID string) (*entity.TokenPair, error) {
+	accessToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = uc.tokenRepo.SaveTokens(ctx,
+		&entity.Token{
+			Hash:      hashToken(accessToken),
+			UserID:    userID,
+			Type:      entity.AccessToken,
+			SessionID: sessionID,
+			ExpiresAt: now.Add(accessTokenTTL),
+			CreatedAt: now,
+		},
+		&entity.Token{
+			Hash:      hashToken(refreshToken),
+			UserID:    userID,
+			Type:      entity.RefreshToken,
+			SessionID: sessionID,
+			ExpiresAt: now.Add(refreshTokenTTL),
+			CreatedAt: now,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.TokenPair{
+		UserID:       userID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    accessTokenTTL,
+	}, nil
+}
+
+// RefreshToken 用刷新令牌换取新的令牌。刷新令牌只能使用一次，
+// 换取成功后同一会话之前签发的令牌全部失效
+func (uc *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string) (*entity.TokenPair, error) {
+	token, err := uc.findToken(ctx, refreshToken, entity.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// 并发使用同一个刷新令牌时只有一个请求能删除成功
+	deleted, err := uc.tokenRepo.DeleteToken(ctx, token.Hash)
+	if err != nil {
+		return nil, errors.New("internal server error")
+	}
+	if !deleted {
+		return nil, errInvalidToken
+	}
+	if err := uc.tokenRepo.DeleteSession(ctx, token.SessionID); err != nil {
+		log.Printf("Failed to revoke session %s: %v", token.SessionID, err)
+	}
+
+	pair, err := uc.issueTokens(ctx, token.UserID, token.SessionID)
+	if err != nil {
+		return nil, errors.New("failed to issue token")
+	}
+	return pair, nil
+}
+
+// ValidateToken 校验访问令牌，返回令牌所属的用户ID
+func (uc *AuthUsecase) ValidateToken(ctx context.Context, accessToken string) (string, error) {
+	token, err := uc.findToken(ctx, accessToken, entity.AccessToken)
+	if err != nil {
+		return "", err
+	}
+	return token.UserID, nil
+}
+
+// findToken 查找指定类型的有效令牌，过期的令牌会被顺带删除
+func (uc *AuthUsecase) findToken(ctx context.Context, plaintext, tokenType string) (*entity.Token, error) {
+	if plaintext == "" {
+		return nil, errInvalidToken
+	}
+
+	token, err := uc.tokenRepo.FindToken(ctx, hashToken(plaintext))
+	if err != nil {
+		return nil, errors.New("internal server error")
+	}
+	if token == nil || token.Type != tokenType {
+		return nil, errInvalidToken
+	}
+	if time.Now().After(token.ExpiresAt) {
+		uc.tokenRepo.DeleteToken(ctx, token.Hash)
+		return nil, errInvalidToken
+	}
+	return token, nil
+}
+
+// revokeTokens 登出时吊销令牌：提供访问令牌时只吊销其所在会话，否则吊销该用户的所有会话
+func (uc *AuthUsecase) revokeTokens(ctx context.Context, userID, accessToken string) error {
+	if accessToken == "" {
+		return uc.tokenRepo.DeleteUserTokens(ctx, userID)
+	}
+
+	token, err := uc.findToken(ctx, accessToken, entity.AccessToken)
+	if err != nil {
+		// 令牌已失效，无需吊销
+		return nil
+	}
+	if token.UserID != userID {
+		return errors.New("token does not belong to user")
+	}
+	return uc.tokenRepo.DeleteSession(ctx, token.SessionID)
+}
+
+// generateToken 生成 256 位随机令牌
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken 计算令牌摘要，数据库中只保存摘要
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
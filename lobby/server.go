@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"google.golang.org/grpc"
+	"snake-game/auth"
+	"snake-game/healthcheck"
 	grpc_handler "snake-game/lobby/internal/delivery/grpc"
 	"snake-game/lobby/internal/repository"
 	"snake-game/lobby/internal/usecase"
@@ -29,12 +31,15 @@ func main() {
 
 	// 初始化仓库层
 	userRepo := repository.NewUserRepository()
+	tokenRepo := repository.NewTokenRepository()
+	presenceRepo := repository.NewPresenceMemoryRepository()
 
 	// 初始化业务逻辑层
-	authUsecase := usecase.NewAuthUsecase(userRepo)
+	authUsecase := usecase.NewAuthUsecase(userRepo, tokenRepo)
+	presenceUsecase := usecase.NewPresenceUsecase(presenceRepo, userRepo)
 
 	// 初始化通信层
-	lobbyHandler := grpc_handler.NewLobbyHandler(authUsecase)
+	lobbyHandler := grpc_handler.NewLobbyHandler(authUsecase, presenceUsecase)
 
 	// 启动 gRPC 服务器
 	lis, err := net.Listen("tcp", ":50051")
@@ -42,9 +47,13 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	// 识别调用方，handler 据此校验操作权限
+	s := grpc.NewServer(auth.ServerOptions(authUsecase)...)
 	pb.RegisterLobbyServiceServer(s, lobbyHandler)
 
+	// 标准健康检查，网关据此判断服务是否可用
+	healthcheck.Register(s, "lobby_service.LobbyService", mongodb.Ping)
+
 	log.Println("Lobby service is running on :50051")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
@@ -1,8 +1,28 @@
 package entity
 
+import "time"
+
 type Player struct {
-	ID       string `bson:"_id,omitempty"`
-	Username string `bson:"username"`
-	Rating   int32  `bson:"rating"`
-	Status   string `bson:"status"` // waiting, matched, playing
-}
\ No newline at end of file
+	ID       string    `bson:"_id,omitempty"`
+	Username string    `bson:"username"`
+	Rating   int32     `bson:"rating"`
+	Status   string    `bson:"status"` // idle, waiting, matched, playing
+	RoomID   string    `bson:"room_id,omitempty"` // 匹配成功后分配的房间
+	JoinedAt time.Time `bson:"joined_at"`         // 进入匹配队列的时间，恢复队列时保留排队时长
+}
+
+// Match 一次成功的匹配
+type Match struct {
+	RoomID  string
+	Players []*Player
+}
+
+// MatchEvent 匹配进度事件
+type MatchEvent struct {
+	Type           string        // queued, match_found, match_cancelled
+	QueuePosition  int           // 在排队玩家中的位置，从 1 开始
+	EstimatedWait  time.Duration // 预计剩余等待时间，小于 0 表示暂无估计
+	WaitingPlayers int
+	Match          *Match // 仅 match_found 时设置
+	Message        string
+}
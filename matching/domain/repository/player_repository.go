@@ -6,8 +6,11 @@ import (
 )
 
 type PlayerRepository interface {
-	CreatePlayer(ctx context.Context, player *entity.Player) error
+	SavePlayer(ctx context.Context, player *entity.Player) error
 	UpdatePlayerStatus(ctx context.Context, id string, status string) error
+	MarkMatched(ctx context.Context, ids []string, roomID string) error
 	GetPlayer(ctx context.Context, id string) (*entity.Player, error)
+	GetPlayersByStatus(ctx context.Context, status string) ([]*entity.Player, error)
+	GetOnlinePlayers(ctx context.Context) ([]*entity.Player, error)
 	DeletePlayer(ctx context.Context, id string) error
-}
\ No newline at end of file
+}
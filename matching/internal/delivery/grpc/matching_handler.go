@@ -2,7 +2,13 @@ package grpc
 
 import (
 	"context"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"snake-game/auth"
+	"snake-game/matching/domain/entity"
 	"snake-game/matching/internal/usecase"
 	pb "snake-game/proto"
 )
@@ -20,7 +26,11 @@ func NewMatchingHandler(usecase *usecase.MatchingUsecase) *MatchingHandler {
 
 // FindMatch 寻找匹配
 func (h *MatchingHandler) FindMatch(ctx context.Context, req *pb.FindMatchRequest) (*pb.FindMatchResponse, error) {
-	roomID, err := h.usecase.FindMatch(ctx, req.PlayerId, req.Username, req.Rating)
+	if err := auth.Authorize(ctx, req.PlayerId); err != nil {
+		return nil, err
+	}
+
+	match, err := h.usecase.FindMatch(ctx, req.PlayerId)
 	if err != nil {
 		return &pb.FindMatchResponse{
 			Success: false,
@@ -28,16 +38,28 @@ func (h *MatchingHandler) FindMatch(ctx context.Context, req *pb.FindMatchReques
 		}, nil
 	}
 
+	if match == nil {
+		// 仍在排队，客户端再次调用以继续等待
+		return &pb.FindMatchResponse{
+			Success: true,
+			Message: "Still searching for a match",
+		}, nil
+	}
+
 	return &pb.FindMatchResponse{
 		Success: true,
 		Message: "Match found successfully",
-		RoomId:  roomID,
-		Players: []*pb.PlayerInfo{}, // 根据实际需求填充玩家信息
+		RoomId:  match.RoomID,
+		Players: toPbPlayers(match.Players),
 	}, nil
 }
 
 // CancelMatch 取消匹配
 func (h *MatchingHandler) CancelMatch(ctx context.Context, req *pb.CancelMatchRequest) (*pb.CancelMatchResponse, error) {
+	if err := auth.Authorize(ctx, req.PlayerId); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.CancelMatch(ctx, req.PlayerId)
 	if err != nil {
 		return &pb.CancelMatchResponse{
@@ -76,13 +98,22 @@ func (h *MatchingHandler) GetOnlinePlayers(ctx context.Context, req *pb.GetOnlin
 		}, nil
 	}
 
-	// 转换域实体到协议缓冲区消息
-	pbPlayers := make([]*pb.PlayerInfo, len(players))
+	// 在线状态以大厅服务的心跳为准
+	playerIDs := make([]string, len(players))
 	for i, player := range players {
-		pbPlayers[i] = &pb.PlayerInfo{
-			PlayerId: player.ID,
-			Username: player.Username,
-			Rating:   player.Rating,
+		playerIDs[i] = player.ID
+	}
+	presences := h.usecase.GetPresence(ctx, playerIDs)
+
+	// 在线玩家列表对所有人可见，只给出状态，不透露所在的房间
+	pbPlayers := toPbPlayers(players)
+	for _, pbPlayer := range pbPlayers {
+		if presence, ok := presences[pbPlayer.PlayerId]; ok {
+			pbPlayer.Presence = &pb.Presence{
+				UserId:   presence.UserId,
+				Status:   presence.Status,
+				LastSeen: presence.LastSeen,
+			}
 		}
 	}
 
@@ -91,4 +122,57 @@ func (h *MatchingHandler) GetOnlinePlayers(ctx context.Context, req *pb.GetOnlin
 		Message: "Players retrieved successfully",
 		Players: pbPlayers,
 	}, nil
+}
+
+// WatchMatch 加入匹配队列并推送匹配进度
+func (h *MatchingHandler) WatchMatch(req *pb.WatchMatchRequest, stream pb.MatchingService_WatchMatchServer) error {
+	if err := auth.Authorize(stream.Context(), req.PlayerId); err != nil {
+		return err
+	}
+
+	events, err := h.usecase.WatchMatch(stream.Context(), req.PlayerId)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for event := range events {
+		if err := stream.Send(toPbMatchEvent(event)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toPbMatchEvent 转换匹配事件
+func toPbMatchEvent(event *entity.MatchEvent) *pb.MatchEvent {
+	estimate := int32(-1)
+	if event.EstimatedWait >= 0 {
+		estimate = int32((event.EstimatedWait + time.Second - 1) / time.Second)
+	}
+
+	pbEvent := &pb.MatchEvent{
+		Type:                 event.Type,
+		QueuePosition:        int32(event.QueuePosition),
+		EstimatedWaitSeconds: estimate,
+		WaitingPlayers:       int32(event.WaitingPlayers),
+		Message:              event.Message,
+	}
+	if event.Match != nil {
+		pbEvent.RoomId = event.Match.RoomID
+		pbEvent.Players = toPbPlayers(event.Match.Players)
+	}
+	return pbEvent
+}
+
+// toPbPlayers 转换域实体到协议缓冲区消息
+func toPbPlayers(players []*entity.Player) []*pb.PlayerInfo {
+	pbPlayers := make([]*pb.PlayerInfo, len(players))
+	for i, player := range players {
+		pbPlayers[i] = &pb.PlayerInfo{
+			PlayerId: player.ID,
+			Username: player.Username,
+			Rating:   player.Rating,
+		}
+	}
+	return pbPlayers
 }
\ No newline at end of file
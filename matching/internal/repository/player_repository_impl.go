@@ -6,60 +6,127 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"snake-game/matching/domain/entity"
 	"snake-game/mongodb"
 )
 
 type playerRepositoryImpl struct {
 	collection *mongo.Collection
+	users      *mongo.Collection
 }
 
 func NewPlayerRepository() *playerRepositoryImpl {
 	return &playerRepositoryImpl{
 		collection: mongodb.DB.Collection("players"), // 使用专门的players集合
+		users:      mongodb.DB.Collection(mongodb.UserCollection),
 	}
 }
 
-func (r *playerRepositoryImpl) CreatePlayer(ctx context.Context, player *entity.Player) error {
-	_, err := r.collection.InsertOne(ctx, player)
+// SavePlayer 插入或覆盖玩家的匹配记录（_id 为用户ID字符串）
+func (r *playerRepositoryImpl) SavePlayer(ctx context.Context, player *entity.Player) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": player.ID}, player, options.Replace().SetUpsert(true))
 	return err
 }
 
 func (r *playerRepositoryImpl) UpdatePlayerStatus(ctx context.Context, id string, status string) error {
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return err
+	update := bson.M{
+		"$set": bson.M{
+			"status": status,
+		},
 	}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
 
+// MarkMatched 将一组玩家标记为已匹配并记录房间
+func (r *playerRepositoryImpl) MarkMatched(ctx context.Context, ids []string, roomID string) error {
 	update := bson.M{
 		"$set": bson.M{
-			"status": status,
+			"status":  "matched",
+			"room_id": roomID,
 		},
 	}
-	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	_, err := r.collection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, update)
 	return err
 }
 
 func (r *playerRepositoryImpl) GetPlayer(ctx context.Context, id string) (*entity.Player, error) {
-	objectID, err := primitive.ObjectIDFromHex(id)
+	var player entity.Player
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&player)
 	if err != nil {
 		return nil, err
 	}
+	return &player, nil
+}
 
-	var player entity.Player
-	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&player)
+// GetPlayersByStatus 查询指定状态的玩家，按入队时间排序
+func (r *playerRepositoryImpl) GetPlayersByStatus(ctx context.Context, status string) ([]*entity.Player, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"status": status}, options.Find().SetSort(bson.M{"joined_at": 1}))
 	if err != nil {
 		return nil, err
 	}
-	return &player, nil
+	defer cursor.Close(ctx)
+
+	players := make([]*entity.Player, 0)
+	if err := cursor.All(ctx, &players); err != nil {
+		return nil, err
+	}
+	return players, nil
 }
 
-func (r *playerRepositoryImpl) DeletePlayer(ctx context.Context, id string) error {
-	objectID, err := primitive.ObjectIDFromHex(id)
+// GetOnlinePlayers 查询在线用户，评分取自最近一次匹配记录
+func (r *playerRepositoryImpl) GetOnlinePlayers(ctx context.Context) ([]*entity.Player, error) {
+	cursor, err := r.users.Find(ctx, bson.M{"online": true}, options.Find().SetProjection(bson.M{"username": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []struct {
+		ID       primitive.ObjectID `bson:"_id"`
+		Username string             `bson:"username"`
+	}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	players := make([]*entity.Player, len(users))
+	ids := make([]string, len(users))
+	for i, user := range users {
+		players[i] = &entity.Player{
+			ID:       user.ID.Hex(),
+			Username: user.Username,
+			Status:   "idle",
+		}
+		ids[i] = players[i].ID
+	}
+
+	// 补充匹配记录中的评分和状态
+	recordCursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer recordCursor.Close(ctx)
 
-	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	var records []*entity.Player
+	if err := recordCursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	known := make(map[string]*entity.Player, len(records))
+	for _, record := range records {
+		known[record.ID] = record
+	}
+	for _, player := range players {
+		if record, exists := known[player.ID]; exists {
+			player.Rating = record.Rating
+			player.Status = record.Status
+		}
+	}
+	return players, nil
+}
+
+func (r *playerRepositoryImpl) DeletePlayer(ctx context.Context, id string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	return err
-}
\ No newline at end of file
+}
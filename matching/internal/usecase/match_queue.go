@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"snake-game/matching/domain/entity"
+)
+
+// 匹配票据的状态
+const (
+	ticketWaiting = "waiting" // 排队中
+	ticketForming = "forming" // 已选中，正在创建房间
+	ticketMatched = "matched" // 匹配成功，等待玩家取走结果
+)
+
+// 没有等待者的票据保留多久（客户端轮询间隙或服务重启后重连）
+const ticketIdleTimeout = 30 * time.Second
+
+var errMatchCancelled = errors.New("match cancelled")
+
+// ticket 单个玩家的匹配票据
+type ticket struct {
+	player    *entity.Player
+	state     string
+	match     *entity.Match   // 匹配成功后设置，取消时为空
+	done      chan struct{}   // 匹配成功或取消时关闭
+	waiters   int             // 正在等待结果的 FindMatch/WatchMatch 调用数
+	idleSince time.Time       // 最后一个等待者离开的时间
+	blocked   map[string]bool // 与该玩家存在屏蔽关系（任一方向）的玩家，不会被匹配到一起
+}
+
+// matchQueue 内存中的匹配队列，按玩家ID索引
+type matchQueue struct {
+	tickets map[string]*ticket
+	avgWait time.Duration // 近期成功匹配的平均等待时间，用于估计剩余等待
+	mutex   sync.Mutex
+}
+
+func newMatchQueue() *matchQueue {
+	return &matchQueue{
+		tickets: make(map[string]*ticket),
+	}
+}
+
+// join 为玩家取得票据：已在队列中则复用（保留排队时长），否则新建。返回票据及是否新建
+func (q *matchQueue) join(player *entity.Player, blocked map[string]bool) (*ticket, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if t, exists := q.tickets[player.ID]; exists {
+		if t.state == ticketWaiting {
+			t.player.Username = player.Username
+			t.player.Rating = player.Rating
+			t.blocked = blocked
+		}
+		t.waiters++
+		return t, false
+	}
+
+	t := &ticket{
+		player:  player,
+		state:   ticketWaiting,
+		done:    make(chan struct{}),
+		waiters: 1,
+		blocked: blocked,
+	}
+	q.tickets[player.ID] = t
+	return t, true
+}
+
+// restore 恢复服务重启前仍在排队的玩家，等待客户端重新连接
+func (q *matchQueue) restore(player *entity.Player, now time.Time) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if _, exists := q.tickets[player.ID]; exists {
+		return
+	}
+	q.tickets[player.ID] = &ticket{
+		player:    player,
+		state:     ticketWaiting,
+		done:      make(chan struct{}),
+		idleSince: now,
+	}
+}
+
+// leave 等待者离开；匹配结果已被取走时移除票据
+func (q *matchQueue) leave(t *ticket, now time.Time) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	t.waiters--
+	if t.waiters == 0 {
+		t.idleSince = now
+	}
+	if t.state == ticketMatched && q.tickets[t.player.ID] == t {
+		delete(q.tickets, t.player.ID)
+	}
+}
+
+// cancel 取消排队，正在创建房间的票据不能取消
+func (q *matchQueue) cancel(playerID string) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	t, exists := q.tickets[playerID]
+	if !exists {
+		return nil
+	}
+	if t.state == ticketForming {
+		return errors.New("match is being formed")
+	}
+	if t.state == ticketWaiting {
+		close(t.done)
+	}
+	delete(q.tickets, playerID)
+	return nil
+}
+
+// takeMatches 从队列中选出可以成局的玩家组，选中的票据进入 forming 状态。
+// 等待最久的玩家优先，其可接受的评分差随等待时间扩大
+func (q *matchQueue) takeMatches(now time.Time, config MatchConfig) [][]*ticket {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	waiting := make([]*ticket, 0, len(q.tickets))
+	for _, t := range q.tickets {
+		if t.state == ticketWaiting {
+			waiting = append(waiting, t)
+		}
+	}
+	sort.Slice(waiting, func(i, j int) bool {
+		if !waiting[i].player.JoinedAt.Equal(waiting[j].player.JoinedAt) {
+			return waiting[i].player.JoinedAt.Before(waiting[j].player.JoinedAt)
+		}
+		return waiting[i].player.ID < waiting[j].player.ID
+	})
+
+	groups := make([][]*ticket, 0)
+	taken := make(map[*ticket]bool)
+	for _, anchor := range waiting {
+		if taken[anchor] {
+			continue
+		}
+		window := config.ratingWindow(now.Sub(anchor.player.JoinedAt))
+
+		candidates := make([]*ticket, 0)
+		for _, other := range waiting {
+			if other == anchor || taken[other] {
+				continue
+			}
+			if ratingDiff(anchor, other) <= window {
+				candidates = append(candidates, other)
+			}
+		}
+		if len(candidates) < config.MatchSize-1 {
+			continue
+		}
+
+		// 评分最接近的优先，相同则等待更久的优先（waiting 已按入队时间排序）
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return ratingDiff(anchor, candidates[i]) < ratingDiff(anchor, candidates[j])
+		})
+
+		// 跳过与已选玩家存在屏蔽关系的候选者
+		group := []*ticket{anchor}
+		for _, candidate := range candidates {
+			if len(group) == config.MatchSize {
+				break
+			}
+			if compatible(group, candidate) {
+				group = append(group, candidate)
+			}
+		}
+		if len(group) < config.MatchSize {
+			continue
+		}
+
+		for _, t := range group {
+			taken[t] = true
+			t.state = ticketForming
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// complete 记录匹配结果并唤醒等待者；没有等待者的票据留给客户端下次轮询取走
+func (q *matchQueue) complete(group []*ticket, match *entity.Match, now time.Time) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, t := range group {
+		// 指数滑动平均，越近的匹配权重越大
+		waited := now.Sub(t.player.JoinedAt)
+		if q.avgWait == 0 {
+			q.avgWait = waited
+		} else {
+			q.avgWait = (q.avgWait*4 + waited) / 5
+		}
+
+		t.state = ticketMatched
+		t.match = match
+		t.player.Status = "matched"
+		t.player.RoomID = match.RoomID
+		if t.waiters == 0 {
+			t.idleSince = now
+		}
+		close(t.done)
+	}
+}
+
+// requeue 创建房间失败时把玩家放回队列，保留原有排队时长
+func (q *matchQueue) requeue(group []*ticket) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, t := range group {
+		t.state = ticketWaiting
+	}
+}
+
+// expire 移除长时间无人等待的票据，返回被移除的排队中玩家
+func (q *matchQueue) expire(now time.Time) []string {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	expired := make([]string, 0)
+	for playerID, t := range q.tickets {
+		if t.waiters > 0 || t.state == ticketForming || now.Sub(t.idleSince) < ticketIdleTimeout {
+			continue
+		}
+		if t.state == ticketWaiting {
+			close(t.done)
+			expired = append(expired, playerID)
+		}
+		delete(q.tickets, playerID)
+	}
+	return expired
+}
+
+// waitingCount 返回仍在排队（含正在成局）的玩家数
+func (q *matchQueue) waitingCount() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	count := 0
+	for _, t := range q.tickets {
+		if t.state != ticketMatched {
+			count++
+		}
+	}
+	return count
+}
+
+// progress 返回票据的排队位置、排队人数和预计剩余等待时间（小于 0 表示暂无估计）
+func (q *matchQueue) progress(t *ticket, now time.Time) (int, int, time.Duration) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	position, waiting := 0, 0
+	for _, other := range q.tickets {
+		if other.state == ticketMatched {
+			continue
+		}
+		waiting++
+		if other.player.JoinedAt.Before(t.player.JoinedAt) ||
+			(other.player.JoinedAt.Equal(t.player.JoinedAt) && other.player.ID <= t.player.ID) {
+			position++
+		}
+	}
+
+	estimate := time.Duration(-1)
+	if q.avgWait > 0 {
+		estimate = q.avgWait - now.Sub(t.player.JoinedAt)
+		if estimate < 0 {
+			estimate = 0
+		}
+	}
+	return position, waiting, estimate
+}
+
+// status 返回玩家在队列中的状态，不在队列中返回空
+func (q *matchQueue) status(playerID string) string {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if t, exists := q.tickets[playerID]; exists {
+		return t.state
+	}
+	return ""
+}
+
+// compatible 候选者与组内所有玩家之间都没有屏蔽关系。双方都检查，
+// 一方的屏蔽信息过期（如服务重启后恢复的票据）时仍能避开
+func compatible(group []*ticket, candidate *ticket) bool {
+	for _, t := range group {
+		if t.blocked[candidate.player.ID] || candidate.blocked[t.player.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+func ratingDiff(a, b *ticket) int32 {
+	diff := a.player.Rating - b.player.Rating
+	if diff < 0 {
+		return -diff
+	}
+	return diff
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"snake-game/matching/domain/entity"
+)
+
+var testConfig = MatchConfig{
+	MatchSize:       2,
+	RatingWindow:    100,
+	WindowGrowth:    50,
+	MaxRatingWindow: 400,
+}
+
+// queuePlayer 描述测试中入队的玩家，waited 为截至撮合时已排队的时长
+type queuePlayer struct {
+	id     string
+	rating int32
+	waited time.Duration
+}
+
+func newTestQueue(now time.Time, players []queuePlayer) *matchQueue {
+	q := newMatchQueue()
+	for _, p := range players {
+		q.join(&entity.Player{
+			ID:       p.id,
+			Rating:   p.rating,
+			Status:   "waiting",
+			JoinedAt: now.Add(-p.waited),
+		}, nil)
+	}
+	return q
+}
+
+// groupIDs 返回每组玩家ID，组内按ID排序
+func groupIDs(groups [][]*ticket) [][]string {
+	result := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		ids := make([]string, 0, len(group))
+		for _, t := range group {
+			ids = append(ids, t.player.ID)
+		}
+		sort.Strings(ids)
+		result = append(result, ids)
+	}
+	return result
+}
+
+func TestRatingWindow(t *testing.T) {
+	tests := []struct {
+		waited time.Duration
+		want   int32
+	}{
+		{0, 100},
+		{999 * time.Millisecond, 100},
+		{time.Second, 150},
+		{4 * time.Second, 300},
+		{6 * time.Second, 400},
+		{time.Minute, 400},
+	}
+	for _, tt := range tests {
+		if got := testConfig.ratingWindow(tt.waited); got != tt.want {
+			t.Errorf("ratingWindow(%v) = %d, want %d", tt.waited, got, tt.want)
+		}
+	}
+}
+
+func TestTakeMatchesWindowGrows(t *testing.T) {
+	tests := []struct {
+		name    string
+		players []queuePlayer
+		want    [][]string
+	}{
+		{
+			name: "within the initial window",
+			players: []queuePlayer{
+				{id: "a", rating: 1000},
+				{id: "b", rating: 1080},
+			},
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name: "too far apart right after joining",
+			players: []queuePlayer{
+				{id: "a", rating: 1000},
+				{id: "b", rating: 1300},
+			},
+			want: [][]string{},
+		},
+		{
+			name: "window has grown enough",
+			players: []queuePlayer{
+				{id: "a", rating: 1000, waited: 4 * time.Second},
+				{id: "b", rating: 1300},
+			},
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name: "the longer wait decides the window",
+			players: []queuePlayer{
+				{id: "a", rating: 1000},
+				{id: "b", rating: 1300, waited: 4 * time.Second},
+			},
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name: "window stops at the maximum",
+			players: []queuePlayer{
+				{id: "a", rating: 1000, waited: time.Hour},
+				{id: "b", rating: 1500},
+			},
+			want: [][]string{},
+		},
+		{
+			name: "closest rating is picked first",
+			players: []queuePlayer{
+				{id: "a", rating: 1000, waited: 10 * time.Second},
+				{id: "b", rating: 1350, waited: 9 * time.Second},
+				{id: "c", rating: 1020},
+			},
+			want: [][]string{{"a", "c"}},
+		},
+	}
+
+	now := time.Now()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newTestQueue(now, tt.players)
+			got := groupIDs(q.takeMatches(now, testConfig))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("takeMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTakeMatchesNoPlayerTwice(t *testing.T) {
+	now := time.Now()
+	config := testConfig
+	config.MatchSize = 3
+
+	// 评分相近、排队时长各不相同，任意三人都可以成局
+	players := make([]queuePlayer, 0, 11)
+	for i := 0; i < 11; i++ {
+		players = append(players, queuePlayer{
+			id:     fmt.Sprintf("p%02d", i),
+			rating: 1000 + int32(i%4)*10,
+			waited: time.Duration(i) * time.Second,
+		})
+	}
+	q := newTestQueue(now, players)
+
+	groups := q.takeMatches(now, config)
+	if len(groups) != 3 {
+		t.Fatalf("got %d groups, want 3", len(groups))
+	}
+	seen := make(map[string]bool)
+	for _, group := range groups {
+		if len(group) != config.MatchSize {
+			t.Errorf("group size = %d, want %d", len(group), config.MatchSize)
+		}
+		for _, member := range group {
+			if seen[member.player.ID] {
+				t.Errorf("%s matched twice", member.player.ID)
+			}
+			seen[member.player.ID] = true
+			if member.state != ticketForming {
+				t.Errorf("%s: state = %q, want %q", member.player.ID, member.state, ticketForming)
+			}
+		}
+	}
+
+	// 正在成局的玩家不会在下一轮被再次选中，剩下的两人不足以成局
+	if again := q.takeMatches(now, config); len(again) != 0 {
+		t.Errorf("second round = %v, want no groups", groupIDs(again))
+	}
+
+	// 创建房间失败后放回队列，可以重新成局
+	q.requeue(groups[0])
+	again := q.takeMatches(now, config)
+	if len(again) != 1 {
+		t.Fatalf("after requeue got %d groups, want 1", len(again))
+	}
+	for _, member := range again[0] {
+		if seen[member.player.ID] && !containsTicket(groups[0], member) {
+			t.Errorf("%s from another forming group matched again", member.player.ID)
+		}
+	}
+}
+
+func TestTakeMatchesSkipsBlockedPlayers(t *testing.T) {
+	now := time.Now()
+	q := newMatchQueue()
+	q.join(&entity.Player{ID: "a", Rating: 1000, JoinedAt: now.Add(-2 * time.Second)}, map[string]bool{"b": true})
+	q.join(&entity.Player{ID: "b", Rating: 1000, JoinedAt: now.Add(-time.Second)}, nil)
+	q.join(&entity.Player{ID: "c", Rating: 1050, JoinedAt: now}, nil)
+
+	got := groupIDs(q.takeMatches(now, testConfig))
+	want := [][]string{{"a", "c"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("takeMatches() = %v, want %v", got, want)
+	}
+}
+
+func containsTicket(group []*ticket, target *ticket) bool {
+	for _, t := range group {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
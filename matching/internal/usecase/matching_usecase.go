@@ -3,65 +3,445 @@ package usecase
 import (
 	"context"
 	"errors"
-	"fmt"
+	"log"
+	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"snake-game/auth"
 	"snake-game/matching/domain/entity"
 	"snake-game/matching/domain/repository"
+	pb "snake-game/proto"
 )
 
+// 单次 FindMatch 最长等待时间，超时后客户端应再次调用以继续排队
+const findMatchWait = 8 * time.Second
+
+// 排行榜服务不可用时使用的评分，与排行榜的初始评分一致
+const fallbackRating = 1500
+
+// activityInQueue 排队中的玩家在大厅服务中的活动
+const activityInQueue = "in_queue"
+
+// MatchConfig 匹配规则配置
+type MatchConfig struct {
+	MatchSize       int           // 每局玩家数
+	RatingWindow    int32         // 初始可接受的评分差
+	WindowGrowth    int32         // 每等待一秒评分差扩大的幅度
+	MaxRatingWindow int32         // 评分差上限
+	Interval        time.Duration // 撮合间隔
+}
+
+// ratingWindow 根据等待时长计算可接受的评分差
+func (c MatchConfig) ratingWindow(waited time.Duration) int32 {
+	window := c.RatingWindow + int32(waited.Seconds())*c.WindowGrowth
+	if window > c.MaxRatingWindow {
+		return c.MaxRatingWindow
+	}
+	return window
+}
+
 type MatchingUsecase struct {
-	playerRepo repository.PlayerRepository
+	playerRepo        repository.PlayerRepository
+	roomClient        pb.RoomServiceClient
+	leaderboardClient pb.LeaderboardServiceClient
+	friendsClient     pb.FriendsServiceClient
+	lobbyClient       pb.LobbyServiceClient
+	config            MatchConfig
+	queue             *matchQueue
+}
+
+func NewMatchingUsecase(playerRepo repository.PlayerRepository, config MatchConfig) *MatchingUsecase {
+	// 连接到房间服务，匹配成功后为玩家创建房间
+	conn, err := grpc.Dial("localhost:50053", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
+	if err != nil {
+		log.Printf("Failed to connect to room service: %v", err)
+		return nil
+	}
+
+	// 连接到排行榜服务，玩家评分以排行榜为准
+	leaderboardConn, err := grpc.Dial("localhost:50054", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
+	if err != nil {
+		log.Printf("Failed to connect to leaderboard service: %v", err)
+		return nil
+	}
+
+	// 连接到好友服务，互相屏蔽的玩家不会被匹配到一起
+	friendsConn, err := grpc.Dial("localhost:50056", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
+	if err != nil {
+		log.Printf("Failed to connect to friends service: %v", err)
+		return nil
+	}
+
+	// 连接到大厅服务，上报排队状态并查询玩家的在线状态
+	lobbyConn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
+	if err != nil {
+		log.Printf("Failed to connect to lobby service: %v", err)
+		return nil
+	}
+
+	uc := &MatchingUsecase{
+		playerRepo:        playerRepo,
+		roomClient:        pb.NewRoomServiceClient(conn),
+		leaderboardClient: pb.NewLeaderboardServiceClient(leaderboardConn),
+		friendsClient:     pb.NewFriendsServiceClient(friendsConn),
+		lobbyClient:       pb.NewLobbyServiceClient(lobbyConn),
+		config:            config,
+		queue:             newMatchQueue(),
+	}
+	uc.recoverQueue()
+	go uc.runMatchmaker()
+	return uc
 }
 
-func NewMatchingUsecase(playerRepo repository.PlayerRepository) *MatchingUsecase {
-	return &MatchingUsecase{
-		playerRepo: playerRepo,
+// recoverQueue 从数据库恢复服务重启前仍在排队的玩家
+func (uc *MatchingUsecase) recoverQueue() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	players, err := uc.playerRepo.GetPlayersByStatus(ctx, "waiting")
+	if err != nil {
+		log.Printf("Failed to recover matching queue: %v", err)
+		return
+	}
+	now := time.Now()
+	for _, player := range players {
+		uc.queue.restore(player, now)
+	}
+	if len(players) > 0 {
+		log.Printf("Recovered %d players into matching queue", len(players))
 	}
 }
 
-func (uc *MatchingUsecase) FindMatch(ctx context.Context, playerID, username string, rating int32) (string, error) {
-	// 创建玩家并设置为等待匹配状态
+// FindMatch 将玩家加入匹配队列并等待结果。已在队列中的玩家继续原有排队，
+// 在等待时间内没有匹配到时返回 nil，客户端应再次调用
+func (uc *MatchingUsecase) FindMatch(ctx context.Context, playerID string) (*entity.Match, error) {
+	t, err := uc.enqueue(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer uc.queue.leave(t, time.Now())
+
+	// 不超过调用方的截止时间，留出返回响应的余量
+	wait := findMatchWait
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline) - time.Second; remaining < wait {
+			wait = remaining
+		}
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-t.done:
+		if t.match == nil {
+			return nil, errMatchCancelled
+		}
+		return t.match, nil
+	case <-timer.C:
+		return nil, nil
+	case <-ctx.Done():
+		return nil, nil
+	}
+}
+
+// WatchMatch 将玩家加入匹配队列并持续推送排队进度。匹配成功或被取消时推送最终事件后关闭通道，
+// ctx 结束时直接关闭（票据保留一段时间，重新订阅可继续原有排队）
+func (uc *MatchingUsecase) WatchMatch(ctx context.Context, playerID string) (<-chan *entity.MatchEvent, error) {
+	t, err := uc.enqueue(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *entity.MatchEvent, 1)
+	go func() {
+		defer close(events)
+		defer uc.queue.leave(t, time.Now())
+
+		ticker := time.NewTicker(uc.config.Interval)
+		defer ticker.Stop()
+
+		event := uc.progressEvent(t)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case events <- event:
+			}
+			if event.Type != "queued" {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.done:
+				event = resultEvent(t)
+			case <-ticker.C:
+				event = uc.progressEvent(t)
+			}
+		}
+	}()
+	return events, nil
+}
+
+// enqueue 为玩家取得匹配票据，新票据会持久化以便服务重启后恢复。
+// 用户名和评分分别从大厅服务和排行榜服务读取，不信任客户端上报的值。调用方结束等待时必须调用 queue.leave
+func (uc *MatchingUsecase) enqueue(ctx context.Context, playerID string) (*ticket, error) {
+	username, err := uc.playerUsername(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
 	player := &entity.Player{
 		ID:       playerID,
 		Username: username,
-		Rating:   rating,
+		Rating:   uc.playerRating(ctx, playerID),
 		Status:   "waiting",
+		JoinedAt: time.Now(),
+	}
+
+	t, created := uc.queue.join(player, uc.blockedPlayers(ctx, playerID))
+	if created {
+		err := uc.playerRepo.SavePlayer(ctx, player)
+		if err != nil {
+			uc.queue.leave(t, time.Now())
+			uc.queue.cancel(playerID)
+			return nil, errors.New("failed to add player to matching queue")
+		}
+		uc.reportActivity(ctx, playerID, activityInQueue, "")
+	}
+	return t, nil
+}
+
+// playerUsername 从大厅服务读取玩家的用户名，排队信息会展示给对手
+func (uc *MatchingUsecase) playerUsername(ctx context.Context, playerID string) (string, error) {
+	resp, err := uc.lobbyClient.GetUsersByIds(ctx, &pb.GetUsersByIdsRequest{UserIds: []string{playerID}})
+	if err == nil && !resp.Success {
+		err = errors.New(resp.Message)
+	}
+	if err != nil {
+		log.Printf("Failed to get username of player %s: %v", playerID, err)
+		return "", errors.New("failed to get player profile")
+	}
+	if len(resp.Users) == 0 {
+		return "", errors.New("player not found")
 	}
+	return resp.Users[0].Username, nil
+}
+
+// playerRating 从排行榜服务读取玩家评分，失败时使用初始评分，避免排行榜故障阻塞匹配
+func (uc *MatchingUsecase) playerRating(ctx context.Context, playerID string) int32 {
+	resp, err := uc.leaderboardClient.GetRating(ctx, &pb.GetRatingRequest{UserId: playerID})
+	if err == nil && !resp.Success {
+		err = errors.New(resp.Message)
+	}
+	if err != nil {
+		log.Printf("Failed to get rating of player %s, using default: %v", playerID, err)
+		return fallbackRating
+	}
+	return resp.Rating
+}
+
+// blockedPlayers 从好友服务读取与玩家存在屏蔽关系的玩家，失败时不做限制，避免好友服务故障阻塞匹配
+func (uc *MatchingUsecase) blockedPlayers(ctx context.Context, playerID string) map[string]bool {
+	blocked := make(map[string]bool)
+
+	resp, err := uc.friendsClient.GetBlockRelations(ctx, &pb.GetBlockRelationsRequest{UserId: playerID})
+	if err == nil && !resp.Success {
+		err = errors.New(resp.Message)
+	}
+	if err != nil {
+		log.Printf("Failed to get block relations of player %s: %v", playerID, err)
+		return blocked
+	}
+
+	for _, id := range resp.BlockedUserIds {
+		blocked[id] = true
+	}
+	for _, id := range resp.BlockedByUserIds {
+		blocked[id] = true
+	}
+	return blocked
+}
 
-	err := uc.playerRepo.CreatePlayer(ctx, player)
+// reportActivity 向大厅服务上报玩家的排队状态，失败只记录日志。
+// 清除时 expected 为 in_queue，匹配成功后房间服务上报的 in_room 不会被覆盖
+func (uc *MatchingUsecase) reportActivity(ctx context.Context, playerID, activity, expected string) {
+	resp, err := uc.lobbyClient.UpdateActivity(ctx, &pb.UpdateActivityRequest{
+		UserId:           playerID,
+		Activity:         activity,
+		ExpectedActivity: expected,
+	})
+	if err == nil && !resp.Success {
+		err = errors.New(resp.Message)
+	}
 	if err != nil {
-		return "", errors.New("failed to add player to matching queue")
+		log.Printf("Failed to report activity of player %s: %v", playerID, err)
 	}
+}
 
-	// 这里应该实现实际的匹配逻辑
-	// 简化实现：直接创建一个房间ID并返回
-	roomID := fmt.Sprintf("room_%s", playerID[:8])
+// GetPresence 从大厅服务批量查询玩家的在线状态，键为玩家ID。
+// 查询失败时返回空结果，不影响玩家列表
+func (uc *MatchingUsecase) GetPresence(ctx context.Context, playerIDs []string) map[string]*pb.Presence {
+	presences := make(map[string]*pb.Presence)
+	if len(playerIDs) == 0 {
+		return presences
+	}
 
-	// 更新玩家状态为已匹配
-	err = uc.playerRepo.UpdatePlayerStatus(ctx, playerID, "matched")
+	resp, err := uc.lobbyClient.GetPresence(ctx, &pb.GetPresenceRequest{UserIds: playerIDs})
+	if err == nil && !resp.Success {
+		err = errors.New(resp.Message)
+	}
 	if err != nil {
-		return "", errors.New("failed to update player status")
+		log.Printf("Failed to get presence of players: %v", err)
+		return presences
+	}
+
+	for _, presence := range resp.Presences {
+		presences[presence.UserId] = presence
 	}
+	return presences
+}
 
-	return roomID, nil
+// progressEvent 生成排队进度事件
+func (uc *MatchingUsecase) progressEvent(t *ticket) *entity.MatchEvent {
+	position, waiting, estimate := uc.queue.progress(t, time.Now())
+	return &entity.MatchEvent{
+		Type:           "queued",
+		QueuePosition:  position,
+		EstimatedWait:  estimate,
+		WaitingPlayers: waiting,
+	}
+}
+
+// resultEvent 生成匹配结束事件，调用方需确认 t.done 已关闭
+func resultEvent(t *ticket) *entity.MatchEvent {
+	if t.match == nil {
+		return &entity.MatchEvent{
+			Type:    "match_cancelled",
+			Message: errMatchCancelled.Error(),
+		}
+	}
+	return &entity.MatchEvent{
+		Type:    "match_found",
+		Match:   t.match,
+		Message: "Match found successfully",
+	}
 }
 
 func (uc *MatchingUsecase) CancelMatch(ctx context.Context, playerID string) error {
+	if err := uc.queue.cancel(playerID); err != nil {
+		return err
+	}
+
 	err := uc.playerRepo.UpdatePlayerStatus(ctx, playerID, "idle")
 	if err != nil {
 		return errors.New("failed to cancel match")
 	}
+	uc.reportActivity(ctx, playerID, "", activityInQueue)
 	return nil
 }
 
 func (uc *MatchingUsecase) GetWaitingPlayers(ctx context.Context) (int32, error) {
-	// 这里应该查询数据库中状态为"waiting"的玩家数量
-	// 为了简化，暂时返回一个示例值
-	return 0, nil
+	return int32(uc.queue.waitingCount()), nil
 }
 
 func (uc *MatchingUsecase) GetOnlinePlayers(ctx context.Context) ([]*entity.Player, error) {
-	// 这里应该查询数据库中在线的玩家
-	// 为了简化，暂时返回空数组
-	return []*entity.Player{}, nil
-}
\ No newline at end of file
+	players, err := uc.playerRepo.GetOnlinePlayers(ctx)
+	if err != nil {
+		return nil, errors.New("failed to get online players")
+	}
+
+	// 队列中的状态比数据库记录更新
+	for _, player := range players {
+		if status := uc.queue.status(player.ID); status != "" {
+			player.Status = status
+		}
+	}
+	return players, nil
+}
+
+// runMatchmaker 按固定间隔撮合队列中的玩家
+func (uc *MatchingUsecase) runMatchmaker() {
+	ticker := time.NewTicker(uc.config.Interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		for _, group := range uc.queue.takeMatches(now, uc.config) {
+			uc.formMatch(group)
+		}
+
+		expired := uc.queue.expire(now)
+		if len(expired) > 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			for _, playerID := range expired {
+				if err := uc.playerRepo.UpdatePlayerStatus(ctx, playerID, "idle"); err != nil {
+					log.Printf("Failed to expire matching ticket of %s: %v", playerID, err)
+				}
+				uc.reportActivity(ctx, playerID, "", activityInQueue)
+			}
+			cancel()
+		}
+	}
+}
+
+// formMatch 为一组玩家创建房间并通知等待者，失败时放回队列
+func (uc *MatchingUsecase) formMatch(group []*ticket) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	players := make([]*entity.Player, len(group))
+	playerIDs := make([]string, len(group))
+	for i, t := range group {
+		players[i] = t.player
+		playerIDs[i] = t.player.ID
+	}
+
+	roomID, err := uc.createRoom(ctx, players)
+	if err != nil {
+		log.Printf("Failed to create room for match %v: %v", playerIDs, err)
+		uc.queue.requeue(group)
+		return
+	}
+
+	if err := uc.playerRepo.MarkMatched(ctx, playerIDs, roomID); err != nil {
+		log.Printf("Failed to persist match %s: %v", roomID, err)
+	}
+	uc.queue.complete(group, &entity.Match{RoomID: roomID, Players: players}, time.Now())
+}
+
+// createRoom 通过房间服务创建房间，第一位玩家为房主，其余玩家依次加入。
+// 任一玩家加入失败时已加入的玩家会离开房间
+func (uc *MatchingUsecase) createRoom(ctx context.Context, players []*entity.Player) (string, error) {
+	resp, err := uc.roomClient.CreateRoom(ctx, &pb.CreateRoomRequest{
+		UserId:     players[0].ID,
+		RoomName:   "匹配对局",
+		MaxPlayers: int32(len(players)),
+	})
+	if err != nil {
+		return "", err
+	}
+	if !resp.Success {
+		return "", errors.New(resp.Message)
+	}
+
+	for i, player := range players[1:] {
+		joinResp, err := uc.roomClient.JoinRoom(ctx, &pb.JoinRoomRequest{
+			RoomId: resp.RoomId,
+			UserId: player.ID,
+		})
+		if err == nil && !joinResp.Success {
+			err = errors.New(joinResp.Message)
+		}
+		if err != nil {
+			for _, joined := range players[:i+1] {
+				uc.roomClient.LeaveRoom(ctx, &pb.LeaveRoomRequest{RoomId: resp.RoomId, UserId: joined.ID})
+			}
+			return "", err
+		}
+	}
+	return resp.RoomId, nil
+}
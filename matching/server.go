@@ -4,8 +4,12 @@ import (
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
+	"snake-game/auth"
+	"snake-game/healthcheck"
 	grpc_handler "snake-game/matching/internal/delivery/grpc"
 	"snake-game/matching/internal/repository"
 	"snake-game/matching/internal/usecase"
@@ -30,8 +34,20 @@ func main() {
 	// 初始化仓库层
 	playerRepo := repository.NewPlayerRepository()
 
+	// 匹配规则，可通过环境变量调整
+	config := usecase.MatchConfig{
+		MatchSize:       envInt("MATCH_SIZE", 2),
+		RatingWindow:    int32(envInt("MATCH_RATING_WINDOW", 100)),
+		WindowGrowth:    int32(envInt("MATCH_WINDOW_GROWTH", 20)),
+		MaxRatingWindow: int32(envInt("MATCH_MAX_RATING_WINDOW", 1000)),
+		Interval:        time.Second,
+	}
+	if config.MatchSize < 2 {
+		log.Fatalf("MATCH_SIZE must be at least 2")
+	}
+
 	// 初始化业务逻辑层
-	matchingUsecase := usecase.NewMatchingUsecase(playerRepo)
+	matchingUsecase := usecase.NewMatchingUsecase(playerRepo, config)
 
 	// 初始化通信层
 	matchingHandler := grpc_handler.NewMatchingHandler(matchingUsecase)
@@ -42,11 +58,28 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	// 识别调用方，handler 据此校验操作权限
+	s := grpc.NewServer(auth.ServerOptions(auth.NewLobbyValidator())...)
 	pb.RegisterMatchingServiceServer(s, matchingHandler)
 
+	// 标准健康检查，网关据此判断服务是否可用
+	healthcheck.Register(s, "matching_service.MatchingService", mongodb.Ping)
+
 	log.Println("Matching service is running on :50052")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
+}
+
+// envInt 读取非负整数环境变量，未设置时返回默认值
+func envInt(name string, defaultValue int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Fatalf("Invalid %s: %s", name, value)
+	}
+	return n
 }
\ No newline at end of file
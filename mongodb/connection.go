@@ -22,6 +22,12 @@ const (
 	LeaderboardCollection = "leaderboards"
 	MessageCollection = "messages"
 	GameStateCollection = "game_states"
+	RatingCollection  = "ratings"
+	RatedMatchCollection = "rated_matches"
+	ReplayCollection  = "replays"
+	TokenCollection   = "tokens"
+	BlockCollection   = "blocks"
+	InviteCollection  = "invites"
 )
 
 // Connect 连接到 MongoDB
@@ -57,4 +63,11 @@ func Disconnect() error {
 	defer cancel()
 
 	return Client.Disconnect(ctx)
-}
\ No newline at end of file
+}
+// Ping 检查数据库是否可用，用于健康检查
+func Ping(ctx context.Context) error {
+	if Client == nil {
+		return fmt.Errorf("MongoDB is not connected")
+	}
+	return Client.Ping(ctx, nil)
+}
@@ -48,20 +48,22 @@ type GameOptions struct {
 
 // GameRecord 游戏记录模型
 type GameRecord struct {
-	ID          primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
-	RoomID      primitive.ObjectID   `bson:"room_id" json:"room_id"`
-	Players     []PlayerGameResult   `bson:"players" json:"players"`
-	WinnerID    primitive.ObjectID   `bson:"winner_id" json:"winner_id"`
+	ID          string               `bson:"_id" json:"id"` // 对局ID
+	RoomID      string               `bson:"room_id" json:"room_id"`
+	Players     []PlayerGameResult   `bson:"players" json:"players"` // 按名次排序
+	WinnerID    string               `bson:"winner_id" json:"winner_id"` // 平局为空
 	Scores      map[string]int       `bson:"scores" json:"scores"`
 	GameTime    time.Duration        `bson:"game_time" json:"game_time"`
-	CreatedAt   time.Time            `bson:"created_at" json:"created_at"`
+	StartedAt   time.Time            `bson:"started_at" json:"started_at"`
+	CreatedAt   time.Time            `bson:"created_at" json:"created_at"` // 对局结束时间
 }
 
 // PlayerGameResult 玩家游戏结果
 type PlayerGameResult struct {
-	PlayerID primitive.ObjectID `bson:"player_id" json:"player_id"`
-	Score    int                `bson:"score" json:"score"`
-	Rank     int                `bson:"rank" json:"rank"`
+	PlayerID string `bson:"player_id" json:"player_id"`
+	Score    int    `bson:"score" json:"score"`
+	Rank     int    `bson:"rank" json:"rank"`
+	Length   int    `bson:"length" json:"length"`
 }
 
 // Leaderboard 排行榜模型
@@ -84,6 +84,7 @@ type User struct {
 	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
 	Online        bool                   `protobuf:"varint,4,opt,name=online,proto3" json:"online,omitempty"`
 	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Presence      *Presence              `protobuf:"bytes,6,opt,name=presence,proto3" json:"presence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -153,18 +154,95 @@ func (x *User) GetCreatedAt() int64 {
 	return 0
 }
 
+func (x *User) GetPresence() *Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return nil
+}
+
+// 用户的实时在线状态，由客户端心跳和各服务上报的活动决定
+type Presence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                      // online, idle, in_queue, in_room, in_game, offline
+	RoomId        string                 `protobuf:"bytes,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`        // in_room、in_game 时所在的房间
+	LastSeen      int64                  `protobuf:"varint,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"` // 最近一次心跳的时间，从未上线为 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Presence) Reset() {
+	*x = Presence{}
+	mi := &file_proto_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Presence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Presence) ProtoMessage() {}
+
+func (x *Presence) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Presence.ProtoReflect.Descriptor instead.
+func (*Presence) Descriptor() ([]byte, []int) {
+	return file_proto_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Presence) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Presence) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Presence) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *Presence) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
 type PlayerInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
 	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
 	Rating        int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	Presence      *Presence              `protobuf:"bytes,4,opt,name=presence,proto3" json:"presence,omitempty"` // 仅在线玩家列表中设置
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PlayerInfo) Reset() {
 	*x = PlayerInfo{}
-	mi := &file_proto_common_proto_msgTypes[1]
+	mi := &file_proto_common_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -176,7 +254,7 @@ func (x *PlayerInfo) String() string {
 func (*PlayerInfo) ProtoMessage() {}
 
 func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_common_proto_msgTypes[1]
+	mi := &file_proto_common_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -189,7 +267,7 @@ func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerInfo.ProtoReflect.Descriptor instead.
 func (*PlayerInfo) Descriptor() ([]byte, []int) {
-	return file_proto_common_proto_rawDescGZIP(), []int{1}
+	return file_proto_common_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *PlayerInfo) GetPlayerId() string {
@@ -213,6 +291,13 @@ func (x *PlayerInfo) GetRating() int32 {
 	return 0
 }
 
+func (x *PlayerInfo) GetPresence() *Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return nil
+}
+
 type Position struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
@@ -223,7 +308,7 @@ type Position struct {
 
 func (x *Position) Reset() {
 	*x = Position{}
-	mi := &file_proto_common_proto_msgTypes[2]
+	mi := &file_proto_common_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -235,7 +320,7 @@ func (x *Position) String() string {
 func (*Position) ProtoMessage() {}
 
 func (x *Position) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_common_proto_msgTypes[2]
+	mi := &file_proto_common_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -248,7 +333,7 @@ func (x *Position) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Position.ProtoReflect.Descriptor instead.
 func (*Position) Descriptor() ([]byte, []int) {
-	return file_proto_common_proto_rawDescGZIP(), []int{2}
+	return file_proto_common_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Position) GetX() int32 {
@@ -274,7 +359,7 @@ type SnakeSegment struct {
 
 func (x *SnakeSegment) Reset() {
 	*x = SnakeSegment{}
-	mi := &file_proto_common_proto_msgTypes[3]
+	mi := &file_proto_common_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -286,7 +371,7 @@ func (x *SnakeSegment) String() string {
 func (*SnakeSegment) ProtoMessage() {}
 
 func (x *SnakeSegment) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_common_proto_msgTypes[3]
+	mi := &file_proto_common_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,7 +384,7 @@ func (x *SnakeSegment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SnakeSegment.ProtoReflect.Descriptor instead.
 func (*SnakeSegment) Descriptor() ([]byte, []int) {
-	return file_proto_common_proto_rawDescGZIP(), []int{3}
+	return file_proto_common_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SnakeSegment) GetPosition() *Position {
@@ -316,13 +401,14 @@ type GameSnake struct {
 	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
 	Length        int32                  `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
 	Score         int32                  `protobuf:"varint,5,opt,name=score,proto3" json:"score,omitempty"`
+	Alive         bool                   `protobuf:"varint,6,opt,name=alive,proto3" json:"alive,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GameSnake) Reset() {
 	*x = GameSnake{}
-	mi := &file_proto_common_proto_msgTypes[4]
+	mi := &file_proto_common_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -334,7 +420,7 @@ func (x *GameSnake) String() string {
 func (*GameSnake) ProtoMessage() {}
 
 func (x *GameSnake) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_common_proto_msgTypes[4]
+	mi := &file_proto_common_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,7 +433,7 @@ func (x *GameSnake) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameSnake.ProtoReflect.Descriptor instead.
 func (*GameSnake) Descriptor() ([]byte, []int) {
-	return file_proto_common_proto_rawDescGZIP(), []int{4}
+	return file_proto_common_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GameSnake) GetPlayerId() string {
@@ -385,6 +471,114 @@ func (x *GameSnake) GetScore() int32 {
 	return 0
 }
 
+func (x *GameSnake) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+// 游戏选项，未设置（零值）的字段使用服务端默认值
+type GameOptions struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BoardWidth       int32                  `protobuf:"varint,1,opt,name=board_width,json=boardWidth,proto3" json:"board_width,omitempty"`
+	BoardHeight      int32                  `protobuf:"varint,2,opt,name=board_height,json=boardHeight,proto3" json:"board_height,omitempty"`
+	FoodCount        int32                  `protobuf:"varint,3,opt,name=food_count,json=foodCount,proto3" json:"food_count,omitempty"`                        // 场上保持的食物数量
+	TickIntervalMs   int32                  `protobuf:"varint,4,opt,name=tick_interval_ms,json=tickIntervalMs,proto3" json:"tick_interval_ms,omitempty"`       // 帧间隔（毫秒），越小蛇越快
+	WallMode         string                 `protobuf:"bytes,5,opt,name=wall_mode,json=wallMode,proto3" json:"wall_mode,omitempty"`                            // solid: 撞到边界死亡, wrap: 从对边穿出
+	WallEnabled      bool                   `protobuf:"varint,6,opt,name=wall_enabled,json=wallEnabled,proto3" json:"wall_enabled,omitempty"`                  // 是否在场内生成障碍墙
+	MaxScore         int32                  `protobuf:"varint,7,opt,name=max_score,json=maxScore,proto3" json:"max_score,omitempty"`                           // 达到该分数立即获胜，0 表示不限制
+	TimeLimitSeconds int32                  `protobuf:"varint,8,opt,name=time_limit_seconds,json=timeLimitSeconds,proto3" json:"time_limit_seconds,omitempty"` // 对局时长上限，0 表示不限制
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GameOptions) Reset() {
+	*x = GameOptions{}
+	mi := &file_proto_common_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameOptions) ProtoMessage() {}
+
+func (x *GameOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_common_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameOptions.ProtoReflect.Descriptor instead.
+func (*GameOptions) Descriptor() ([]byte, []int) {
+	return file_proto_common_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GameOptions) GetBoardWidth() int32 {
+	if x != nil {
+		return x.BoardWidth
+	}
+	return 0
+}
+
+func (x *GameOptions) GetBoardHeight() int32 {
+	if x != nil {
+		return x.BoardHeight
+	}
+	return 0
+}
+
+func (x *GameOptions) GetFoodCount() int32 {
+	if x != nil {
+		return x.FoodCount
+	}
+	return 0
+}
+
+func (x *GameOptions) GetTickIntervalMs() int32 {
+	if x != nil {
+		return x.TickIntervalMs
+	}
+	return 0
+}
+
+func (x *GameOptions) GetWallMode() string {
+	if x != nil {
+		return x.WallMode
+	}
+	return ""
+}
+
+func (x *GameOptions) GetWallEnabled() bool {
+	if x != nil {
+		return x.WallEnabled
+	}
+	return false
+}
+
+func (x *GameOptions) GetMaxScore() int32 {
+	if x != nil {
+		return x.MaxScore
+	}
+	return 0
+}
+
+func (x *GameOptions) GetTimeLimitSeconds() int32 {
+	if x != nil {
+		return x.TimeLimitSeconds
+	}
+	return 0
+}
+
 type Message struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -400,7 +594,7 @@ type Message struct {
 
 func (x *Message) Reset() {
 	*x = Message{}
-	mi := &file_proto_common_proto_msgTypes[5]
+	mi := &file_proto_common_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -412,7 +606,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_common_proto_msgTypes[5]
+	mi := &file_proto_common_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -425,7 +619,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_proto_common_proto_rawDescGZIP(), []int{5}
+	return file_proto_common_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Message) GetId() string {
@@ -489,7 +683,7 @@ type LeaderboardEntry struct {
 
 func (x *LeaderboardEntry) Reset() {
 	*x = LeaderboardEntry{}
-	mi := &file_proto_common_proto_msgTypes[6]
+	mi := &file_proto_common_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -501,7 +695,7 @@ func (x *LeaderboardEntry) String() string {
 func (*LeaderboardEntry) ProtoMessage() {}
 
 func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_common_proto_msgTypes[6]
+	mi := &file_proto_common_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,7 +708,7 @@ func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
 func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
-	return file_proto_common_proto_rawDescGZIP(), []int{6}
+	return file_proto_common_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *LeaderboardEntry) GetUserId() string {
@@ -550,14 +744,16 @@ type FriendInfo struct {
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
 	Online        bool                   `protobuf:"varint,3,opt,name=online,proto3" json:"online,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // accepted, pending, blocked
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                      // accepted, pending, blocked
+	PlayingRoomId string                 `protobuf:"bytes,5,opt,name=playing_room_id,json=playingRoomId,proto3" json:"playing_room_id,omitempty"` // 正在进行的对局所在房间，为空表示不在对局中，可据此观战
+	Presence      *Presence              `protobuf:"bytes,6,opt,name=presence,proto3" json:"presence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FriendInfo) Reset() {
 	*x = FriendInfo{}
-	mi := &file_proto_common_proto_msgTypes[7]
+	mi := &file_proto_common_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -569,7 +765,7 @@ func (x *FriendInfo) String() string {
 func (*FriendInfo) ProtoMessage() {}
 
 func (x *FriendInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_common_proto_msgTypes[7]
+	mi := &file_proto_common_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,7 +778,7 @@ func (x *FriendInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FriendInfo.ProtoReflect.Descriptor instead.
 func (*FriendInfo) Descriptor() ([]byte, []int) {
-	return file_proto_common_proto_rawDescGZIP(), []int{7}
+	return file_proto_common_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *FriendInfo) GetUserId() string {
@@ -613,34 +809,67 @@ func (x *FriendInfo) GetStatus() string {
 	return ""
 }
 
+func (x *FriendInfo) GetPlayingRoomId() string {
+	if x != nil {
+		return x.PlayingRoomId
+	}
+	return ""
+}
+
+func (x *FriendInfo) GetPresence() *Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return nil
+}
+
 var File_proto_common_proto protoreflect.FileDescriptor
 
 const file_proto_common_proto_rawDesc = "" +
 	"\n" +
-	"\x12proto/common.proto\x12\x06common\"\x7f\n" +
+	"\x12proto/common.proto\x12\x06common\"\xad\x01\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
 	"\x05email\x18\x03 \x01(\tR\x05email\x12\x16\n" +
 	"\x06online\x18\x04 \x01(\bR\x06online\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"]\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12,\n" +
+	"\bpresence\x18\x06 \x01(\v2\x10.common.PresenceR\bpresence\"q\n" +
+	"\bPresence\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x17\n" +
+	"\aroom_id\x18\x03 \x01(\tR\x06roomId\x12\x1b\n" +
+	"\tlast_seen\x18\x04 \x01(\x03R\blastSeen\"\x8b\x01\n" +
 	"\n" +
 	"PlayerInfo\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
-	"\x06rating\x18\x03 \x01(\x05R\x06rating\"&\n" +
+	"\x06rating\x18\x03 \x01(\x05R\x06rating\x12,\n" +
+	"\bpresence\x18\x04 \x01(\v2\x10.common.PresenceR\bpresence\"&\n" +
 	"\bPosition\x12\f\n" +
 	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
 	"\x01y\x18\x02 \x01(\x05R\x01y\"<\n" +
 	"\fSnakeSegment\x12,\n" +
-	"\bposition\x18\x01 \x01(\v2\x10.common.PositionR\bposition\"\x9e\x01\n" +
+	"\bposition\x18\x01 \x01(\v2\x10.common.PositionR\bposition\"\xb4\x01\n" +
 	"\tGameSnake\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x120\n" +
 	"\bsegments\x18\x02 \x03(\v2\x14.common.SnakeSegmentR\bsegments\x12\x14\n" +
 	"\x05color\x18\x03 \x01(\tR\x05color\x12\x16\n" +
 	"\x06length\x18\x04 \x01(\x05R\x06length\x12\x14\n" +
-	"\x05score\x18\x05 \x01(\x05R\x05score\"\xc5\x01\n" +
+	"\x05score\x18\x05 \x01(\x05R\x05score\x12\x14\n" +
+	"\x05alive\x18\x06 \x01(\bR\x05alive\"\xa5\x02\n" +
+	"\vGameOptions\x12\x1f\n" +
+	"\vboard_width\x18\x01 \x01(\x05R\n" +
+	"boardWidth\x12!\n" +
+	"\fboard_height\x18\x02 \x01(\x05R\vboardHeight\x12\x1d\n" +
+	"\n" +
+	"food_count\x18\x03 \x01(\x05R\tfoodCount\x12(\n" +
+	"\x10tick_interval_ms\x18\x04 \x01(\x05R\x0etickIntervalMs\x12\x1b\n" +
+	"\twall_mode\x18\x05 \x01(\tR\bwallMode\x12!\n" +
+	"\fwall_enabled\x18\x06 \x01(\bR\vwallEnabled\x12\x1b\n" +
+	"\tmax_score\x18\a \x01(\x05R\bmaxScore\x12,\n" +
+	"\x12time_limit_seconds\x18\b \x01(\x05R\x10timeLimitSeconds\"\xc5\x01\n" +
 	"\aMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x12\x1b\n" +
@@ -654,13 +883,15 @@ const file_proto_common_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
 	"\x05score\x18\x03 \x01(\x05R\x05score\x12\x12\n" +
-	"\x04rank\x18\x04 \x01(\x05R\x04rank\"q\n" +
+	"\x04rank\x18\x04 \x01(\x05R\x04rank\"\xc7\x01\n" +
 	"\n" +
 	"FriendInfo\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
 	"\x06online\x18\x03 \x01(\bR\x06online\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status*<\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12&\n" +
+	"\x0fplaying_room_id\x18\x05 \x01(\tR\rplayingRoomId\x12,\n" +
+	"\bpresence\x18\x06 \x01(\v2\x10.common.PresenceR\bpresence*<\n" +
 	"\tDirection\x12\b\n" +
 	"\x04NONE\x10\x00\x12\x06\n" +
 	"\x02UP\x10\x01\x12\b\n" +
@@ -681,26 +912,31 @@ func file_proto_common_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_common_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_common_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_common_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_proto_common_proto_goTypes = []any{
 	(Direction)(0),           // 0: common.Direction
 	(*User)(nil),             // 1: common.User
-	(*PlayerInfo)(nil),       // 2: common.PlayerInfo
-	(*Position)(nil),         // 3: common.Position
-	(*SnakeSegment)(nil),     // 4: common.SnakeSegment
-	(*GameSnake)(nil),        // 5: common.GameSnake
-	(*Message)(nil),          // 6: common.Message
-	(*LeaderboardEntry)(nil), // 7: common.LeaderboardEntry
-	(*FriendInfo)(nil),       // 8: common.FriendInfo
+	(*Presence)(nil),         // 2: common.Presence
+	(*PlayerInfo)(nil),       // 3: common.PlayerInfo
+	(*Position)(nil),         // 4: common.Position
+	(*SnakeSegment)(nil),     // 5: common.SnakeSegment
+	(*GameSnake)(nil),        // 6: common.GameSnake
+	(*GameOptions)(nil),      // 7: common.GameOptions
+	(*Message)(nil),          // 8: common.Message
+	(*LeaderboardEntry)(nil), // 9: common.LeaderboardEntry
+	(*FriendInfo)(nil),       // 10: common.FriendInfo
 }
 var file_proto_common_proto_depIdxs = []int32{
-	3, // 0: common.SnakeSegment.position:type_name -> common.Position
-	4, // 1: common.GameSnake.segments:type_name -> common.SnakeSegment
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 0: common.User.presence:type_name -> common.Presence
+	2, // 1: common.PlayerInfo.presence:type_name -> common.Presence
+	4, // 2: common.SnakeSegment.position:type_name -> common.Position
+	5, // 3: common.GameSnake.segments:type_name -> common.SnakeSegment
+	2, // 4: common.FriendInfo.presence:type_name -> common.Presence
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_proto_common_proto_init() }
@@ -714,7 +950,7 @@ func file_proto_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_common_proto_rawDesc), len(file_proto_common_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -550,6 +550,1262 @@ func (x *RespondFriendRequestResponse) GetMessage() string {
 	return ""
 }
 
+type ListFriendRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Direction     string                 `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`                // incoming（收到的，默认）或 outgoing（发出的）
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`                         // 从 1 开始
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 为 0 时使用默认值
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFriendRequestsRequest) Reset() {
+	*x = ListFriendRequestsRequest{}
+	mi := &file_proto_friends_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFriendRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFriendRequestsRequest) ProtoMessage() {}
+
+func (x *ListFriendRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFriendRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListFriendRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListFriendRequestsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFriendRequestsRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ListFriendRequestsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFriendRequestsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type FriendRequestInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 对方的用户ID
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // 过期后请求自动失效
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRequestInfo) Reset() {
+	*x = FriendRequestInfo{}
+	mi := &file_proto_friends_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRequestInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRequestInfo) ProtoMessage() {}
+
+func (x *FriendRequestInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRequestInfo.ProtoReflect.Descriptor instead.
+func (*FriendRequestInfo) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FriendRequestInfo) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FriendRequestInfo) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *FriendRequestInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *FriendRequestInfo) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type ListFriendRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Requests      []*FriendRequestInfo   `protobuf:"bytes,3,rep,name=requests,proto3" json:"requests,omitempty"` // 最新的在前
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFriendRequestsResponse) Reset() {
+	*x = ListFriendRequestsResponse{}
+	mi := &file_proto_friends_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFriendRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFriendRequestsResponse) ProtoMessage() {}
+
+func (x *ListFriendRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFriendRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListFriendRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListFriendRequestsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListFriendRequestsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListFriendRequestsResponse) GetRequests() []*FriendRequestInfo {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *ListFriendRequestsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CancelFriendRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetUserId  string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelFriendRequestRequest) Reset() {
+	*x = CancelFriendRequestRequest{}
+	mi := &file_proto_friends_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelFriendRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelFriendRequestRequest) ProtoMessage() {}
+
+func (x *CancelFriendRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelFriendRequestRequest.ProtoReflect.Descriptor instead.
+func (*CancelFriendRequestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CancelFriendRequestRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelFriendRequestRequest) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+type CancelFriendRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelFriendRequestResponse) Reset() {
+	*x = CancelFriendRequestResponse{}
+	mi := &file_proto_friends_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelFriendRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelFriendRequestResponse) ProtoMessage() {}
+
+func (x *CancelFriendRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelFriendRequestResponse.ProtoReflect.Descriptor instead.
+func (*CancelFriendRequestResponse) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelFriendRequestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelFriendRequestResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetUserId  string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_proto_friends_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BlockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BlockUserRequest) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+type BlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserResponse) Reset() {
+	*x = BlockUserResponse{}
+	mi := &file_proto_friends_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserResponse) ProtoMessage() {}
+
+func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserResponse.ProtoReflect.Descriptor instead.
+func (*BlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BlockUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BlockUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetUserId  string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_proto_friends_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnblockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnblockUserRequest) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+type UnblockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserResponse) Reset() {
+	*x = UnblockUserResponse{}
+	mi := &file_proto_friends_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserResponse) ProtoMessage() {}
+
+func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnblockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UnblockUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UnblockUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListBlockedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedRequest) Reset() {
+	*x = ListBlockedRequest{}
+	mi := &file_proto_friends_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedRequest) ProtoMessage() {}
+
+func (x *ListBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListBlockedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListBlockedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Users         []*FriendInfo          `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"` // status 为 blocked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse) Reset() {
+	*x = ListBlockedResponse{}
+	mi := &file_proto_friends_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse) ProtoMessage() {}
+
+func (x *ListBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListBlockedResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListBlockedResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListBlockedResponse) GetUsers() []*FriendInfo {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GetBlockRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRelationsRequest) Reset() {
+	*x = GetBlockRelationsRequest{}
+	mi := &file_proto_friends_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRelationsRequest) ProtoMessage() {}
+
+func (x *GetBlockRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRelationsRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetBlockRelationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetBlockRelationsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	BlockedUserIds   []string               `protobuf:"bytes,3,rep,name=blocked_user_ids,json=blockedUserIds,proto3" json:"blocked_user_ids,omitempty"`         // 用户屏蔽的人
+	BlockedByUserIds []string               `protobuf:"bytes,4,rep,name=blocked_by_user_ids,json=blockedByUserIds,proto3" json:"blocked_by_user_ids,omitempty"` // 屏蔽了该用户的人
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetBlockRelationsResponse) Reset() {
+	*x = GetBlockRelationsResponse{}
+	mi := &file_proto_friends_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRelationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRelationsResponse) ProtoMessage() {}
+
+func (x *GetBlockRelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRelationsResponse.ProtoReflect.Descriptor instead.
+func (*GetBlockRelationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetBlockRelationsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetBlockRelationsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetBlockRelationsResponse) GetBlockedUserIds() []string {
+	if x != nil {
+		return x.BlockedUserIds
+	}
+	return nil
+}
+
+func (x *GetBlockRelationsResponse) GetBlockedByUserIds() []string {
+	if x != nil {
+		return x.BlockedByUserIds
+	}
+	return nil
+}
+
+type WatchFriendPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchFriendPresenceRequest) Reset() {
+	*x = WatchFriendPresenceRequest{}
+	mi := &file_proto_friends_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchFriendPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchFriendPresenceRequest) ProtoMessage() {}
+
+func (x *WatchFriendPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchFriendPresenceRequest.ProtoReflect.Descriptor instead.
+func (*WatchFriendPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WatchFriendPresenceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type InviteToRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetUserId  string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	RoomId        string                 `protobuf:"bytes,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteToRoomRequest) Reset() {
+	*x = InviteToRoomRequest{}
+	mi := &file_proto_friends_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteToRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteToRoomRequest) ProtoMessage() {}
+
+func (x *InviteToRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteToRoomRequest.ProtoReflect.Descriptor instead.
+func (*InviteToRoomRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *InviteToRoomRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *InviteToRoomRequest) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+func (x *InviteToRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type InviteToRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	InviteId      string                 `protobuf:"bytes,3,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteToRoomResponse) Reset() {
+	*x = InviteToRoomResponse{}
+	mi := &file_proto_friends_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteToRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteToRoomResponse) ProtoMessage() {}
+
+func (x *InviteToRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteToRoomResponse.ProtoReflect.Descriptor instead.
+func (*InviteToRoomResponse) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *InviteToRoomResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *InviteToRoomResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InviteToRoomResponse) GetInviteId() string {
+	if x != nil {
+		return x.InviteId
+	}
+	return ""
+}
+
+type RoomInvite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InviteId      string                 `protobuf:"bytes,1,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`
+	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	RoomName      string                 `protobuf:"bytes,3,opt,name=room_name,json=roomName,proto3" json:"room_name,omitempty"`
+	FromUserId    string                 `protobuf:"bytes,4,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	FromUsername  string                 `protobuf:"bytes,5,opt,name=from_username,json=fromUsername,proto3" json:"from_username,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // 过期后邀请自动失效
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomInvite) Reset() {
+	*x = RoomInvite{}
+	mi := &file_proto_friends_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomInvite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomInvite) ProtoMessage() {}
+
+func (x *RoomInvite) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomInvite.ProtoReflect.Descriptor instead.
+func (*RoomInvite) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RoomInvite) GetInviteId() string {
+	if x != nil {
+		return x.InviteId
+	}
+	return ""
+}
+
+func (x *RoomInvite) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RoomInvite) GetRoomName() string {
+	if x != nil {
+		return x.RoomName
+	}
+	return ""
+}
+
+func (x *RoomInvite) GetFromUserId() string {
+	if x != nil {
+		return x.FromUserId
+	}
+	return ""
+}
+
+func (x *RoomInvite) GetFromUsername() string {
+	if x != nil {
+		return x.FromUsername
+	}
+	return ""
+}
+
+func (x *RoomInvite) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *RoomInvite) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type ListInvitesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesRequest) Reset() {
+	*x = ListInvitesRequest{}
+	mi := &file_proto_friends_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesRequest) ProtoMessage() {}
+
+func (x *ListInvitesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListInvitesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListInvitesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Invites       []*RoomInvite          `protobuf:"bytes,3,rep,name=invites,proto3" json:"invites,omitempty"` // 最新的在前
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesResponse) Reset() {
+	*x = ListInvitesResponse{}
+	mi := &file_proto_friends_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesResponse) ProtoMessage() {}
+
+func (x *ListInvitesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListInvitesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListInvitesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListInvitesResponse) GetInvites() []*RoomInvite {
+	if x != nil {
+		return x.Invites
+	}
+	return nil
+}
+
+type RespondInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	InviteId      string                 `protobuf:"bytes,2,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`
+	Accepted      bool                   `protobuf:"varint,3,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondInviteRequest) Reset() {
+	*x = RespondInviteRequest{}
+	mi := &file_proto_friends_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondInviteRequest) ProtoMessage() {}
+
+func (x *RespondInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondInviteRequest.ProtoReflect.Descriptor instead.
+func (*RespondInviteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RespondInviteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RespondInviteRequest) GetInviteId() string {
+	if x != nil {
+		return x.InviteId
+	}
+	return ""
+}
+
+func (x *RespondInviteRequest) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+type RespondInviteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RoomId        string                 `protobuf:"bytes,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // 接受成功时为加入的房间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondInviteResponse) Reset() {
+	*x = RespondInviteResponse{}
+	mi := &file_proto_friends_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondInviteResponse) ProtoMessage() {}
+
+func (x *RespondInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondInviteResponse.ProtoReflect.Descriptor instead.
+func (*RespondInviteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RespondInviteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RespondInviteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RespondInviteResponse) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type WatchInvitesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchInvitesRequest) Reset() {
+	*x = WatchInvitesRequest{}
+	mi := &file_proto_friends_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchInvitesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchInvitesRequest) ProtoMessage() {}
+
+func (x *WatchInvitesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_friends_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchInvitesRequest.ProtoReflect.Descriptor instead.
+func (*WatchInvitesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_friends_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *WatchInvitesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 var File_proto_friends_proto protoreflect.FileDescriptor
 
 const file_proto_friends_proto_rawDesc = "" +
@@ -585,14 +1841,111 @@ const file_proto_friends_proto_rawDesc = "" +
 	"\baccepted\x18\x03 \x01(\bR\baccepted\"R\n" +
 	"\x1cRespondFriendRequestResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xf9\x03\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x83\x01\n" +
+	"\x19ListFriendRequestsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x86\x01\n" +
+	"\x11FriendRequestInfo\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"\xa6\x01\n" +
+	"\x1aListFriendRequestsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12>\n" +
+	"\brequests\x18\x03 \x03(\v2\".friends_service.FriendRequestInfoR\brequests\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"[\n" +
+	"\x1aCancelFriendRequestRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\"Q\n" +
+	"\x1bCancelFriendRequestResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"Q\n" +
+	"\x10BlockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\"G\n" +
+	"\x11BlockUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"S\n" +
+	"\x12UnblockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\"I\n" +
+	"\x13UnblockUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"-\n" +
+	"\x12ListBlockedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"s\n" +
+	"\x13ListBlockedResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
+	"\x05users\x18\x03 \x03(\v2\x12.common.FriendInfoR\x05users\"3\n" +
+	"\x18GetBlockRelationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xa8\x01\n" +
+	"\x19GetBlockRelationsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
+	"\x10blocked_user_ids\x18\x03 \x03(\tR\x0eblockedUserIds\x12-\n" +
+	"\x13blocked_by_user_ids\x18\x04 \x03(\tR\x10blockedByUserIds\"5\n" +
+	"\x1aWatchFriendPresenceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"m\n" +
+	"\x13InviteToRoomRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\x12\x17\n" +
+	"\aroom_id\x18\x03 \x01(\tR\x06roomId\"g\n" +
+	"\x14InviteToRoomResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tinvite_id\x18\x03 \x01(\tR\binviteId\"\xe4\x01\n" +
+	"\n" +
+	"RoomInvite\x12\x1b\n" +
+	"\tinvite_id\x18\x01 \x01(\tR\binviteId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x12\x1b\n" +
+	"\troom_name\x18\x03 \x01(\tR\broomName\x12 \n" +
+	"\ffrom_user_id\x18\x04 \x01(\tR\n" +
+	"fromUserId\x12#\n" +
+	"\rfrom_username\x18\x05 \x01(\tR\ffromUsername\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\"-\n" +
+	"\x12ListInvitesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x80\x01\n" +
+	"\x13ListInvitesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x125\n" +
+	"\ainvites\x18\x03 \x03(\v2\x1b.friends_service.RoomInviteR\ainvites\"h\n" +
+	"\x14RespondInviteRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tinvite_id\x18\x02 \x01(\tR\binviteId\x12\x1a\n" +
+	"\baccepted\x18\x03 \x01(\bR\baccepted\"d\n" +
+	"\x15RespondInviteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\aroom_id\x18\x03 \x01(\tR\x06roomId\".\n" +
+	"\x13WatchInvitesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId2\x92\f\n" +
 	"\x0eFriendsService\x12R\n" +
 	"\tAddFriend\x12!.friends_service.AddFriendRequest\x1a\".friends_service.AddFriendResponse\x12[\n" +
 	"\fRemoveFriend\x12$.friends_service.RemoveFriendRequest\x1a%.friends_service.RemoveFriendResponse\x12U\n" +
 	"\n" +
 	"GetFriends\x12\".friends_service.GetFriendsRequest\x1a#.friends_service.GetFriendsResponse\x12j\n" +
 	"\x11SendFriendRequest\x12).friends_service.SendFriendRequestRequest\x1a*.friends_service.SendFriendRequestResponse\x12s\n" +
-	"\x14RespondFriendRequest\x12,.friends_service.RespondFriendRequestRequest\x1a-.friends_service.RespondFriendRequestResponseB\tZ\a./protob\x06proto3"
+	"\x14RespondFriendRequest\x12,.friends_service.RespondFriendRequestRequest\x1a-.friends_service.RespondFriendRequestResponse\x12m\n" +
+	"\x12ListFriendRequests\x12*.friends_service.ListFriendRequestsRequest\x1a+.friends_service.ListFriendRequestsResponse\x12p\n" +
+	"\x13CancelFriendRequest\x12+.friends_service.CancelFriendRequestRequest\x1a,.friends_service.CancelFriendRequestResponse\x12R\n" +
+	"\tBlockUser\x12!.friends_service.BlockUserRequest\x1a\".friends_service.BlockUserResponse\x12X\n" +
+	"\vUnblockUser\x12#.friends_service.UnblockUserRequest\x1a$.friends_service.UnblockUserResponse\x12X\n" +
+	"\vListBlocked\x12#.friends_service.ListBlockedRequest\x1a$.friends_service.ListBlockedResponse\x12j\n" +
+	"\x11GetBlockRelations\x12).friends_service.GetBlockRelationsRequest\x1a*.friends_service.GetBlockRelationsResponse\x12V\n" +
+	"\x13WatchFriendPresence\x12+.friends_service.WatchFriendPresenceRequest\x1a\x10.common.Presence0\x01\x12[\n" +
+	"\fInviteToRoom\x12$.friends_service.InviteToRoomRequest\x1a%.friends_service.InviteToRoomResponse\x12X\n" +
+	"\vListInvites\x12#.friends_service.ListInvitesRequest\x1a$.friends_service.ListInvitesResponse\x12^\n" +
+	"\rRespondInvite\x12%.friends_service.RespondInviteRequest\x1a&.friends_service.RespondInviteResponse\x12S\n" +
+	"\fWatchInvites\x12$.friends_service.WatchInvitesRequest\x1a\x1b.friends_service.RoomInvite0\x01B\tZ\a./protob\x06proto3"
 
 var (
 	file_proto_friends_proto_rawDescOnce sync.Once
@@ -606,7 +1959,7 @@ func file_proto_friends_proto_rawDescGZIP() []byte {
 	return file_proto_friends_proto_rawDescData
 }
 
-var file_proto_friends_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_friends_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_proto_friends_proto_goTypes = []any{
 	(*AddFriendRequest)(nil),             // 0: friends_service.AddFriendRequest
 	(*AddFriendResponse)(nil),            // 1: friends_service.AddFriendResponse
@@ -618,25 +1971,73 @@ var file_proto_friends_proto_goTypes = []any{
 	(*SendFriendRequestResponse)(nil),    // 7: friends_service.SendFriendRequestResponse
 	(*RespondFriendRequestRequest)(nil),  // 8: friends_service.RespondFriendRequestRequest
 	(*RespondFriendRequestResponse)(nil), // 9: friends_service.RespondFriendRequestResponse
-	(*FriendInfo)(nil),                   // 10: common.FriendInfo
+	(*ListFriendRequestsRequest)(nil),    // 10: friends_service.ListFriendRequestsRequest
+	(*FriendRequestInfo)(nil),            // 11: friends_service.FriendRequestInfo
+	(*ListFriendRequestsResponse)(nil),   // 12: friends_service.ListFriendRequestsResponse
+	(*CancelFriendRequestRequest)(nil),   // 13: friends_service.CancelFriendRequestRequest
+	(*CancelFriendRequestResponse)(nil),  // 14: friends_service.CancelFriendRequestResponse
+	(*BlockUserRequest)(nil),             // 15: friends_service.BlockUserRequest
+	(*BlockUserResponse)(nil),            // 16: friends_service.BlockUserResponse
+	(*UnblockUserRequest)(nil),           // 17: friends_service.UnblockUserRequest
+	(*UnblockUserResponse)(nil),          // 18: friends_service.UnblockUserResponse
+	(*ListBlockedRequest)(nil),           // 19: friends_service.ListBlockedRequest
+	(*ListBlockedResponse)(nil),          // 20: friends_service.ListBlockedResponse
+	(*GetBlockRelationsRequest)(nil),     // 21: friends_service.GetBlockRelationsRequest
+	(*GetBlockRelationsResponse)(nil),    // 22: friends_service.GetBlockRelationsResponse
+	(*WatchFriendPresenceRequest)(nil),   // 23: friends_service.WatchFriendPresenceRequest
+	(*InviteToRoomRequest)(nil),          // 24: friends_service.InviteToRoomRequest
+	(*InviteToRoomResponse)(nil),         // 25: friends_service.InviteToRoomResponse
+	(*RoomInvite)(nil),                   // 26: friends_service.RoomInvite
+	(*ListInvitesRequest)(nil),           // 27: friends_service.ListInvitesRequest
+	(*ListInvitesResponse)(nil),          // 28: friends_service.ListInvitesResponse
+	(*RespondInviteRequest)(nil),         // 29: friends_service.RespondInviteRequest
+	(*RespondInviteResponse)(nil),        // 30: friends_service.RespondInviteResponse
+	(*WatchInvitesRequest)(nil),          // 31: friends_service.WatchInvitesRequest
+	(*FriendInfo)(nil),                   // 32: common.FriendInfo
+	(*Presence)(nil),                     // 33: common.Presence
 }
 var file_proto_friends_proto_depIdxs = []int32{
-	10, // 0: friends_service.GetFriendsResponse.friends:type_name -> common.FriendInfo
-	0,  // 1: friends_service.FriendsService.AddFriend:input_type -> friends_service.AddFriendRequest
-	2,  // 2: friends_service.FriendsService.RemoveFriend:input_type -> friends_service.RemoveFriendRequest
-	4,  // 3: friends_service.FriendsService.GetFriends:input_type -> friends_service.GetFriendsRequest
-	6,  // 4: friends_service.FriendsService.SendFriendRequest:input_type -> friends_service.SendFriendRequestRequest
-	8,  // 5: friends_service.FriendsService.RespondFriendRequest:input_type -> friends_service.RespondFriendRequestRequest
-	1,  // 6: friends_service.FriendsService.AddFriend:output_type -> friends_service.AddFriendResponse
-	3,  // 7: friends_service.FriendsService.RemoveFriend:output_type -> friends_service.RemoveFriendResponse
-	5,  // 8: friends_service.FriendsService.GetFriends:output_type -> friends_service.GetFriendsResponse
-	7,  // 9: friends_service.FriendsService.SendFriendRequest:output_type -> friends_service.SendFriendRequestResponse
-	9,  // 10: friends_service.FriendsService.RespondFriendRequest:output_type -> friends_service.RespondFriendRequestResponse
-	6,  // [6:11] is the sub-list for method output_type
-	1,  // [1:6] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	32, // 0: friends_service.GetFriendsResponse.friends:type_name -> common.FriendInfo
+	11, // 1: friends_service.ListFriendRequestsResponse.requests:type_name -> friends_service.FriendRequestInfo
+	32, // 2: friends_service.ListBlockedResponse.users:type_name -> common.FriendInfo
+	26, // 3: friends_service.ListInvitesResponse.invites:type_name -> friends_service.RoomInvite
+	0,  // 4: friends_service.FriendsService.AddFriend:input_type -> friends_service.AddFriendRequest
+	2,  // 5: friends_service.FriendsService.RemoveFriend:input_type -> friends_service.RemoveFriendRequest
+	4,  // 6: friends_service.FriendsService.GetFriends:input_type -> friends_service.GetFriendsRequest
+	6,  // 7: friends_service.FriendsService.SendFriendRequest:input_type -> friends_service.SendFriendRequestRequest
+	8,  // 8: friends_service.FriendsService.RespondFriendRequest:input_type -> friends_service.RespondFriendRequestRequest
+	10, // 9: friends_service.FriendsService.ListFriendRequests:input_type -> friends_service.ListFriendRequestsRequest
+	13, // 10: friends_service.FriendsService.CancelFriendRequest:input_type -> friends_service.CancelFriendRequestRequest
+	15, // 11: friends_service.FriendsService.BlockUser:input_type -> friends_service.BlockUserRequest
+	17, // 12: friends_service.FriendsService.UnblockUser:input_type -> friends_service.UnblockUserRequest
+	19, // 13: friends_service.FriendsService.ListBlocked:input_type -> friends_service.ListBlockedRequest
+	21, // 14: friends_service.FriendsService.GetBlockRelations:input_type -> friends_service.GetBlockRelationsRequest
+	23, // 15: friends_service.FriendsService.WatchFriendPresence:input_type -> friends_service.WatchFriendPresenceRequest
+	24, // 16: friends_service.FriendsService.InviteToRoom:input_type -> friends_service.InviteToRoomRequest
+	27, // 17: friends_service.FriendsService.ListInvites:input_type -> friends_service.ListInvitesRequest
+	29, // 18: friends_service.FriendsService.RespondInvite:input_type -> friends_service.RespondInviteRequest
+	31, // 19: friends_service.FriendsService.WatchInvites:input_type -> friends_service.WatchInvitesRequest
+	1,  // 20: friends_service.FriendsService.AddFriend:output_type -> friends_service.AddFriendResponse
+	3,  // 21: friends_service.FriendsService.RemoveFriend:output_type -> friends_service.RemoveFriendResponse
+	5,  // 22: friends_service.FriendsService.GetFriends:output_type -> friends_service.GetFriendsResponse
+	7,  // 23: friends_service.FriendsService.SendFriendRequest:output_type -> friends_service.SendFriendRequestResponse
+	9,  // 24: friends_service.FriendsService.RespondFriendRequest:output_type -> friends_service.RespondFriendRequestResponse
+	12, // 25: friends_service.FriendsService.ListFriendRequests:output_type -> friends_service.ListFriendRequestsResponse
+	14, // 26: friends_service.FriendsService.CancelFriendRequest:output_type -> friends_service.CancelFriendRequestResponse
+	16, // 27: friends_service.FriendsService.BlockUser:output_type -> friends_service.BlockUserResponse
+	18, // 28: friends_service.FriendsService.UnblockUser:output_type -> friends_service.UnblockUserResponse
+	20, // 29: friends_service.FriendsService.ListBlocked:output_type -> friends_service.ListBlockedResponse
+	22, // 30: friends_service.FriendsService.GetBlockRelations:output_type -> friends_service.GetBlockRelationsResponse
+	33, // 31: friends_service.FriendsService.WatchFriendPresence:output_type -> common.Presence
+	25, // 32: friends_service.FriendsService.InviteToRoom:output_type -> friends_service.InviteToRoomResponse
+	28, // 33: friends_service.FriendsService.ListInvites:output_type -> friends_service.ListInvitesResponse
+	30, // 34: friends_service.FriendsService.RespondInvite:output_type -> friends_service.RespondInviteResponse
+	26, // 35: friends_service.FriendsService.WatchInvites:output_type -> friends_service.RoomInvite
+	20, // [20:36] is the sub-list for method output_type
+	4,  // [4:20] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_proto_friends_proto_init() }
@@ -651,7 +2052,7 @@ func file_proto_friends_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_friends_proto_rawDesc), len(file_proto_friends_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,17 @@ const (
 	FriendsService_GetFriends_FullMethodName           = "/friends_service.FriendsService/GetFriends"
 	FriendsService_SendFriendRequest_FullMethodName    = "/friends_service.FriendsService/SendFriendRequest"
 	FriendsService_RespondFriendRequest_FullMethodName = "/friends_service.FriendsService/RespondFriendRequest"
+	FriendsService_ListFriendRequests_FullMethodName   = "/friends_service.FriendsService/ListFriendRequests"
+	FriendsService_CancelFriendRequest_FullMethodName  = "/friends_service.FriendsService/CancelFriendRequest"
+	FriendsService_BlockUser_FullMethodName            = "/friends_service.FriendsService/BlockUser"
+	FriendsService_UnblockUser_FullMethodName          = "/friends_service.FriendsService/UnblockUser"
+	FriendsService_ListBlocked_FullMethodName          = "/friends_service.FriendsService/ListBlocked"
+	FriendsService_GetBlockRelations_FullMethodName    = "/friends_service.FriendsService/GetBlockRelations"
+	FriendsService_WatchFriendPresence_FullMethodName  = "/friends_service.FriendsService/WatchFriendPresence"
+	FriendsService_InviteToRoom_FullMethodName         = "/friends_service.FriendsService/InviteToRoom"
+	FriendsService_ListInvites_FullMethodName          = "/friends_service.FriendsService/ListInvites"
+	FriendsService_RespondInvite_FullMethodName        = "/friends_service.FriendsService/RespondInvite"
+	FriendsService_WatchInvites_FullMethodName         = "/friends_service.FriendsService/WatchInvites"
 )
 
 // FriendsServiceClient is the client API for FriendsService service.
@@ -42,6 +53,28 @@ type FriendsServiceClient interface {
 	SendFriendRequest(ctx context.Context, in *SendFriendRequestRequest, opts ...grpc.CallOption) (*SendFriendRequestResponse, error)
 	// 回应好友请求
 	RespondFriendRequest(ctx context.Context, in *RespondFriendRequestRequest, opts ...grpc.CallOption) (*RespondFriendRequestResponse, error)
+	// 分页列出收到或发出的好友请求，已过期的请求不会返回
+	ListFriendRequests(ctx context.Context, in *ListFriendRequestsRequest, opts ...grpc.CallOption) (*ListFriendRequestsResponse, error)
+	// 发送方撤回尚未处理的好友请求
+	CancelFriendRequest(ctx context.Context, in *CancelFriendRequestRequest, opts ...grpc.CallOption) (*CancelFriendRequestResponse, error)
+	// 屏蔽用户，同时解除双方的好友关系和待处理请求
+	BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error)
+	// 取消屏蔽
+	UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error)
+	// 获取用户屏蔽的人
+	ListBlocked(ctx context.Context, in *ListBlockedRequest, opts ...grpc.CallOption) (*ListBlockedResponse, error)
+	// 获取用户的双向屏蔽关系，仅供内部服务使用
+	GetBlockRelations(ctx context.Context, in *GetBlockRelationsRequest, opts ...grpc.CallOption) (*GetBlockRelationsResponse, error)
+	// 先推送好友的当前在线状态，之后推送状态变化
+	WatchFriendPresence(ctx context.Context, in *WatchFriendPresenceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Presence], error)
+	// 邀请好友加入自己所在的等待中房间
+	InviteToRoom(ctx context.Context, in *InviteToRoomRequest, opts ...grpc.CallOption) (*InviteToRoomResponse, error)
+	// 获取用户收到的未过期房间邀请
+	ListInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesResponse, error)
+	// 回应房间邀请，接受时代为加入房间
+	RespondInvite(ctx context.Context, in *RespondInviteRequest, opts ...grpc.CallOption) (*RespondInviteResponse, error)
+	// 先推送未过期的邀请，之后推送新收到的邀请
+	WatchInvites(ctx context.Context, in *WatchInvitesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomInvite], error)
 }
 
 type friendsServiceClient struct {
@@ -102,6 +135,134 @@ func (c *friendsServiceClient) RespondFriendRequest(ctx context.Context, in *Res
 	return out, nil
 }
 
+func (c *friendsServiceClient) ListFriendRequests(ctx context.Context, in *ListFriendRequestsRequest, opts ...grpc.CallOption) (*ListFriendRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFriendRequestsResponse)
+	err := c.cc.Invoke(ctx, FriendsService_ListFriendRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendsServiceClient) CancelFriendRequest(ctx context.Context, in *CancelFriendRequestRequest, opts ...grpc.CallOption) (*CancelFriendRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelFriendRequestResponse)
+	err := c.cc.Invoke(ctx, FriendsService_CancelFriendRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendsServiceClient) BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockUserResponse)
+	err := c.cc.Invoke(ctx, FriendsService_BlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendsServiceClient) UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnblockUserResponse)
+	err := c.cc.Invoke(ctx, FriendsService_UnblockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendsServiceClient) ListBlocked(ctx context.Context, in *ListBlockedRequest, opts ...grpc.CallOption) (*ListBlockedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlockedResponse)
+	err := c.cc.Invoke(ctx, FriendsService_ListBlocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendsServiceClient) GetBlockRelations(ctx context.Context, in *GetBlockRelationsRequest, opts ...grpc.CallOption) (*GetBlockRelationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlockRelationsResponse)
+	err := c.cc.Invoke(ctx, FriendsService_GetBlockRelations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendsServiceClient) WatchFriendPresence(ctx context.Context, in *WatchFriendPresenceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Presence], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FriendsService_ServiceDesc.Streams[0], FriendsService_WatchFriendPresence_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchFriendPresenceRequest, Presence]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FriendsService_WatchFriendPresenceClient = grpc.ServerStreamingClient[Presence]
+
+func (c *friendsServiceClient) InviteToRoom(ctx context.Context, in *InviteToRoomRequest, opts ...grpc.CallOption) (*InviteToRoomResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InviteToRoomResponse)
+	err := c.cc.Invoke(ctx, FriendsService_InviteToRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendsServiceClient) ListInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInvitesResponse)
+	err := c.cc.Invoke(ctx, FriendsService_ListInvites_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendsServiceClient) RespondInvite(ctx context.Context, in *RespondInviteRequest, opts ...grpc.CallOption) (*RespondInviteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespondInviteResponse)
+	err := c.cc.Invoke(ctx, FriendsService_RespondInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *friendsServiceClient) WatchInvites(ctx context.Context, in *WatchInvitesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomInvite], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FriendsService_ServiceDesc.Streams[1], FriendsService_WatchInvites_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchInvitesRequest, RoomInvite]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FriendsService_WatchInvitesClient = grpc.ServerStreamingClient[RoomInvite]
+
 // FriendsServiceServer is the server API for FriendsService service.
 // All implementations must embed UnimplementedFriendsServiceServer
 // for forward compatibility.
@@ -118,6 +279,28 @@ type FriendsServiceServer interface {
 	SendFriendRequest(context.Context, *SendFriendRequestRequest) (*SendFriendRequestResponse, error)
 	// 回应好友请求
 	RespondFriendRequest(context.Context, *RespondFriendRequestRequest) (*RespondFriendRequestResponse, error)
+	// 分页列出收到或发出的好友请求，已过期的请求不会返回
+	ListFriendRequests(context.Context, *ListFriendRequestsRequest) (*ListFriendRequestsResponse, error)
+	// 发送方撤回尚未处理的好友请求
+	CancelFriendRequest(context.Context, *CancelFriendRequestRequest) (*CancelFriendRequestResponse, error)
+	// 屏蔽用户，同时解除双方的好友关系和待处理请求
+	BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error)
+	// 取消屏蔽
+	UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error)
+	// 获取用户屏蔽的人
+	ListBlocked(context.Context, *ListBlockedRequest) (*ListBlockedResponse, error)
+	// 获取用户的双向屏蔽关系，仅供内部服务使用
+	GetBlockRelations(context.Context, *GetBlockRelationsRequest) (*GetBlockRelationsResponse, error)
+	// 先推送好友的当前在线状态，之后推送状态变化
+	WatchFriendPresence(*WatchFriendPresenceRequest, grpc.ServerStreamingServer[Presence]) error
+	// 邀请好友加入自己所在的等待中房间
+	InviteToRoom(context.Context, *InviteToRoomRequest) (*InviteToRoomResponse, error)
+	// 获取用户收到的未过期房间邀请
+	ListInvites(context.Context, *ListInvitesRequest) (*ListInvitesResponse, error)
+	// 回应房间邀请，接受时代为加入房间
+	RespondInvite(context.Context, *RespondInviteRequest) (*RespondInviteResponse, error)
+	// 先推送未过期的邀请，之后推送新收到的邀请
+	WatchInvites(*WatchInvitesRequest, grpc.ServerStreamingServer[RoomInvite]) error
 	mustEmbedUnimplementedFriendsServiceServer()
 }
 
@@ -143,6 +326,39 @@ func (UnimplementedFriendsServiceServer) SendFriendRequest(context.Context, *Sen
 func (UnimplementedFriendsServiceServer) RespondFriendRequest(context.Context, *RespondFriendRequestRequest) (*RespondFriendRequestResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RespondFriendRequest not implemented")
 }
+func (UnimplementedFriendsServiceServer) ListFriendRequests(context.Context, *ListFriendRequestsRequest) (*ListFriendRequestsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFriendRequests not implemented")
+}
+func (UnimplementedFriendsServiceServer) CancelFriendRequest(context.Context, *CancelFriendRequestRequest) (*CancelFriendRequestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelFriendRequest not implemented")
+}
+func (UnimplementedFriendsServiceServer) BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedFriendsServiceServer) UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnblockUser not implemented")
+}
+func (UnimplementedFriendsServiceServer) ListBlocked(context.Context, *ListBlockedRequest) (*ListBlockedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBlocked not implemented")
+}
+func (UnimplementedFriendsServiceServer) GetBlockRelations(context.Context, *GetBlockRelationsRequest) (*GetBlockRelationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlockRelations not implemented")
+}
+func (UnimplementedFriendsServiceServer) WatchFriendPresence(*WatchFriendPresenceRequest, grpc.ServerStreamingServer[Presence]) error {
+	return status.Error(codes.Unimplemented, "method WatchFriendPresence not implemented")
+}
+func (UnimplementedFriendsServiceServer) InviteToRoom(context.Context, *InviteToRoomRequest) (*InviteToRoomResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InviteToRoom not implemented")
+}
+func (UnimplementedFriendsServiceServer) ListInvites(context.Context, *ListInvitesRequest) (*ListInvitesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListInvites not implemented")
+}
+func (UnimplementedFriendsServiceServer) RespondInvite(context.Context, *RespondInviteRequest) (*RespondInviteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RespondInvite not implemented")
+}
+func (UnimplementedFriendsServiceServer) WatchInvites(*WatchInvitesRequest, grpc.ServerStreamingServer[RoomInvite]) error {
+	return status.Error(codes.Unimplemented, "method WatchInvites not implemented")
+}
 func (UnimplementedFriendsServiceServer) mustEmbedUnimplementedFriendsServiceServer() {}
 func (UnimplementedFriendsServiceServer) testEmbeddedByValue()                        {}
 
@@ -254,6 +470,190 @@ func _FriendsService_RespondFriendRequest_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FriendsService_ListFriendRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFriendRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendsServiceServer).ListFriendRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendsService_ListFriendRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendsServiceServer).ListFriendRequests(ctx, req.(*ListFriendRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendsService_CancelFriendRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelFriendRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendsServiceServer).CancelFriendRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendsService_CancelFriendRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendsServiceServer).CancelFriendRequest(ctx, req.(*CancelFriendRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendsService_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendsServiceServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendsService_BlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendsServiceServer).BlockUser(ctx, req.(*BlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendsService_UnblockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendsServiceServer).UnblockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendsService_UnblockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendsServiceServer).UnblockUser(ctx, req.(*UnblockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendsService_ListBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendsServiceServer).ListBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendsService_ListBlocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendsServiceServer).ListBlocked(ctx, req.(*ListBlockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendsService_GetBlockRelations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRelationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendsServiceServer).GetBlockRelations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendsService_GetBlockRelations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendsServiceServer).GetBlockRelations(ctx, req.(*GetBlockRelationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendsService_WatchFriendPresence_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchFriendPresenceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FriendsServiceServer).WatchFriendPresence(m, &grpc.GenericServerStream[WatchFriendPresenceRequest, Presence]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FriendsService_WatchFriendPresenceServer = grpc.ServerStreamingServer[Presence]
+
+func _FriendsService_InviteToRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteToRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendsServiceServer).InviteToRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendsService_InviteToRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendsServiceServer).InviteToRoom(ctx, req.(*InviteToRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendsService_ListInvites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendsServiceServer).ListInvites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendsService_ListInvites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendsServiceServer).ListInvites(ctx, req.(*ListInvitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendsService_RespondInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FriendsServiceServer).RespondInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FriendsService_RespondInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FriendsServiceServer).RespondInvite(ctx, req.(*RespondInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FriendsService_WatchInvites_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInvitesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FriendsServiceServer).WatchInvites(m, &grpc.GenericServerStream[WatchInvitesRequest, RoomInvite]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FriendsService_WatchInvitesServer = grpc.ServerStreamingServer[RoomInvite]
+
 // FriendsService_ServiceDesc is the grpc.ServiceDesc for FriendsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,7 +681,54 @@ var FriendsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RespondFriendRequest",
 			Handler:    _FriendsService_RespondFriendRequest_Handler,
 		},
+		{
+			MethodName: "ListFriendRequests",
+			Handler:    _FriendsService_ListFriendRequests_Handler,
+		},
+		{
+			MethodName: "CancelFriendRequest",
+			Handler:    _FriendsService_CancelFriendRequest_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _FriendsService_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnblockUser",
+			Handler:    _FriendsService_UnblockUser_Handler,
+		},
+		{
+			MethodName: "ListBlocked",
+			Handler:    _FriendsService_ListBlocked_Handler,
+		},
+		{
+			MethodName: "GetBlockRelations",
+			Handler:    _FriendsService_GetBlockRelations_Handler,
+		},
+		{
+			MethodName: "InviteToRoom",
+			Handler:    _FriendsService_InviteToRoom_Handler,
+		},
+		{
+			MethodName: "ListInvites",
+			Handler:    _FriendsService_ListInvites_Handler,
+		},
+		{
+			MethodName: "RespondInvite",
+			Handler:    _FriendsService_RespondInvite_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchFriendPresence",
+			Handler:       _FriendsService_WatchFriendPresence_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchInvites",
+			Handler:       _FriendsService_WatchInvites_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/friends.proto",
 }
@@ -31,6 +31,9 @@ type GameUpdate struct {
 	EliminatedPlayers []string               `protobuf:"bytes,5,rep,name=eliminated_players,json=eliminatedPlayers,proto3" json:"eliminated_players,omitempty"`
 	WinnerPlayerId    string                 `protobuf:"bytes,6,opt,name=winner_player_id,json=winnerPlayerId,proto3" json:"winner_player_id,omitempty"`
 	Status            string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Options           *GameOptions           `protobuf:"bytes,8,opt,name=options,proto3" json:"options,omitempty"`
+	Tick              int64                  `protobuf:"varint,9,opt,name=tick,proto3" json:"tick,omitempty"`
+	SpectatorCount    int32                  `protobuf:"varint,10,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -114,6 +117,139 @@ func (x *GameUpdate) GetStatus() string {
 	return ""
 }
 
+func (x *GameUpdate) GetOptions() *GameOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *GameUpdate) GetTick() int64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *GameUpdate) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
+type CreateGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Options       *GameOptions           `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	PlayerIds     []string               `protobuf:"bytes,3,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"` // 为空时创建空棋盘，由玩家自行加入
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGameRequest) Reset() {
+	*x = CreateGameRequest{}
+	mi := &file_proto_game_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameRequest) ProtoMessage() {}
+
+func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
+func (*CreateGameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateGameRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *CreateGameRequest) GetOptions() *GameOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *CreateGameRequest) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+type CreateGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGameResponse) Reset() {
+	*x = CreateGameResponse{}
+	mi := &file_proto_game_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameResponse) ProtoMessage() {}
+
+func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameResponse.ProtoReflect.Descriptor instead.
+func (*CreateGameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateGameResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateGameResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type JoinGameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
@@ -124,7 +260,7 @@ type JoinGameRequest struct {
 
 func (x *JoinGameRequest) Reset() {
 	*x = JoinGameRequest{}
-	mi := &file_proto_game_proto_msgTypes[1]
+	mi := &file_proto_game_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -136,7 +272,7 @@ func (x *JoinGameRequest) String() string {
 func (*JoinGameRequest) ProtoMessage() {}
 
 func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_game_proto_msgTypes[1]
+	mi := &file_proto_game_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -149,7 +285,7 @@ func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinGameRequest.ProtoReflect.Descriptor instead.
 func (*JoinGameRequest) Descriptor() ([]byte, []int) {
-	return file_proto_game_proto_rawDescGZIP(), []int{1}
+	return file_proto_game_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *JoinGameRequest) GetRoomId() string {
@@ -179,7 +315,7 @@ type JoinGameResponse struct {
 
 func (x *JoinGameResponse) Reset() {
 	*x = JoinGameResponse{}
-	mi := &file_proto_game_proto_msgTypes[2]
+	mi := &file_proto_game_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -191,7 +327,7 @@ func (x *JoinGameResponse) String() string {
 func (*JoinGameResponse) ProtoMessage() {}
 
 func (x *JoinGameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_game_proto_msgTypes[2]
+	mi := &file_proto_game_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,7 +340,7 @@ func (x *JoinGameResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinGameResponse.ProtoReflect.Descriptor instead.
 func (*JoinGameResponse) Descriptor() ([]byte, []int) {
-	return file_proto_game_proto_rawDescGZIP(), []int{2}
+	return file_proto_game_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *JoinGameResponse) GetSuccess() bool {
@@ -252,7 +388,7 @@ type LeaveGameRequest struct {
 
 func (x *LeaveGameRequest) Reset() {
 	*x = LeaveGameRequest{}
-	mi := &file_proto_game_proto_msgTypes[3]
+	mi := &file_proto_game_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -264,7 +400,7 @@ func (x *LeaveGameRequest) String() string {
 func (*LeaveGameRequest) ProtoMessage() {}
 
 func (x *LeaveGameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_game_proto_msgTypes[3]
+	mi := &file_proto_game_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -277,7 +413,7 @@ func (x *LeaveGameRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaveGameRequest.ProtoReflect.Descriptor instead.
 func (*LeaveGameRequest) Descriptor() ([]byte, []int) {
-	return file_proto_game_proto_rawDescGZIP(), []int{3}
+	return file_proto_game_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *LeaveGameRequest) GetRoomId() string {
@@ -304,7 +440,7 @@ type LeaveGameResponse struct {
 
 func (x *LeaveGameResponse) Reset() {
 	*x = LeaveGameResponse{}
-	mi := &file_proto_game_proto_msgTypes[4]
+	mi := &file_proto_game_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -316,7 +452,7 @@ func (x *LeaveGameResponse) String() string {
 func (*LeaveGameResponse) ProtoMessage() {}
 
 func (x *LeaveGameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_game_proto_msgTypes[4]
+	mi := &file_proto_game_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -329,7 +465,7 @@ func (x *LeaveGameResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaveGameResponse.ProtoReflect.Descriptor instead.
 func (*LeaveGameResponse) Descriptor() ([]byte, []int) {
-	return file_proto_game_proto_rawDescGZIP(), []int{4}
+	return file_proto_game_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *LeaveGameResponse) GetSuccess() bool {
@@ -357,7 +493,7 @@ type MoveRequest struct {
 
 func (x *MoveRequest) Reset() {
 	*x = MoveRequest{}
-	mi := &file_proto_game_proto_msgTypes[5]
+	mi := &file_proto_game_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -369,7 +505,7 @@ func (x *MoveRequest) String() string {
 func (*MoveRequest) ProtoMessage() {}
 
 func (x *MoveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_game_proto_msgTypes[5]
+	mi := &file_proto_game_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -382,7 +518,7 @@ func (x *MoveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveRequest.ProtoReflect.Descriptor instead.
 func (*MoveRequest) Descriptor() ([]byte, []int) {
-	return file_proto_game_proto_rawDescGZIP(), []int{5}
+	return file_proto_game_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *MoveRequest) GetRoomId() string {
@@ -416,7 +552,7 @@ type MoveResponse struct {
 
 func (x *MoveResponse) Reset() {
 	*x = MoveResponse{}
-	mi := &file_proto_game_proto_msgTypes[6]
+	mi := &file_proto_game_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -428,7 +564,7 @@ func (x *MoveResponse) String() string {
 func (*MoveResponse) ProtoMessage() {}
 
 func (x *MoveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_game_proto_msgTypes[6]
+	mi := &file_proto_game_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,7 +577,7 @@ func (x *MoveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveResponse.ProtoReflect.Descriptor instead.
 func (*MoveResponse) Descriptor() ([]byte, []int) {
-	return file_proto_game_proto_rawDescGZIP(), []int{6}
+	return file_proto_game_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *MoveResponse) GetSuccess() bool {
@@ -467,7 +603,7 @@ type GetGameStateRequest struct {
 
 func (x *GetGameStateRequest) Reset() {
 	*x = GetGameStateRequest{}
-	mi := &file_proto_game_proto_msgTypes[7]
+	mi := &file_proto_game_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -479,7 +615,7 @@ func (x *GetGameStateRequest) String() string {
 func (*GetGameStateRequest) ProtoMessage() {}
 
 func (x *GetGameStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_game_proto_msgTypes[7]
+	mi := &file_proto_game_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +628,7 @@ func (x *GetGameStateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGameStateRequest.ProtoReflect.Descriptor instead.
 func (*GetGameStateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_game_proto_rawDescGZIP(), []int{7}
+	return file_proto_game_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetGameStateRequest) GetRoomId() string {
@@ -503,20 +639,22 @@ func (x *GetGameStateRequest) GetRoomId() string {
 }
 
 type GetGameStateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Snakes        []*GameSnake           `protobuf:"bytes,3,rep,name=snakes,proto3" json:"snakes,omitempty"`
-	Foods         []*Position            `protobuf:"bytes,4,rep,name=foods,proto3" json:"foods,omitempty"`
-	Walls         []*Position            `protobuf:"bytes,5,rep,name=walls,proto3" json:"walls,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Snakes         []*GameSnake           `protobuf:"bytes,3,rep,name=snakes,proto3" json:"snakes,omitempty"`
+	Foods          []*Position            `protobuf:"bytes,4,rep,name=foods,proto3" json:"foods,omitempty"`
+	Walls          []*Position            `protobuf:"bytes,5,rep,name=walls,proto3" json:"walls,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Options        *GameOptions           `protobuf:"bytes,7,opt,name=options,proto3" json:"options,omitempty"`
+	SpectatorCount int32                  `protobuf:"varint,8,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetGameStateResponse) Reset() {
 	*x = GetGameStateResponse{}
-	mi := &file_proto_game_proto_msgTypes[8]
+	mi := &file_proto_game_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -528,7 +666,7 @@ func (x *GetGameStateResponse) String() string {
 func (*GetGameStateResponse) ProtoMessage() {}
 
 func (x *GetGameStateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_game_proto_msgTypes[8]
+	mi := &file_proto_game_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,7 +679,7 @@ func (x *GetGameStateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGameStateResponse.ProtoReflect.Descriptor instead.
 func (*GetGameStateResponse) Descriptor() ([]byte, []int) {
-	return file_proto_game_proto_rawDescGZIP(), []int{8}
+	return file_proto_game_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetGameStateResponse) GetSuccess() bool {
@@ -586,6 +724,20 @@ func (x *GetGameStateResponse) GetStatus() string {
 	return ""
 }
 
+func (x *GetGameStateResponse) GetOptions() *GameOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *GetGameStateResponse) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
 type SubscribeGameUpdatesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
@@ -596,7 +748,7 @@ type SubscribeGameUpdatesRequest struct {
 
 func (x *SubscribeGameUpdatesRequest) Reset() {
 	*x = SubscribeGameUpdatesRequest{}
-	mi := &file_proto_game_proto_msgTypes[9]
+	mi := &file_proto_game_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -608,7 +760,7 @@ func (x *SubscribeGameUpdatesRequest) String() string {
 func (*SubscribeGameUpdatesRequest) ProtoMessage() {}
 
 func (x *SubscribeGameUpdatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_game_proto_msgTypes[9]
+	mi := &file_proto_game_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -621,7 +773,7 @@ func (x *SubscribeGameUpdatesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeGameUpdatesRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeGameUpdatesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_game_proto_rawDescGZIP(), []int{9}
+	return file_proto_game_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SubscribeGameUpdatesRequest) GetRoomId() string {
@@ -638,60 +790,1075 @@ func (x *SubscribeGameUpdatesRequest) GetPlayerId() string {
 	return ""
 }
 
-var File_proto_game_proto protoreflect.FileDescriptor
+type MatchPlayerResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Score         int32                  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	Placement     int32                  `protobuf:"varint,3,opt,name=placement,proto3" json:"placement,omitempty"` // 名次，1 为第一名，并列名次相同
+	Length        int32                  `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_game_proto_rawDesc = "" +
-	"\n" +
-	"\x10proto/game.proto\x12\fgame_service\x1a\x12proto/common.proto\"\x8c\x02\n" +
-	"\n" +
-	"GameUpdate\x12\x12\n" +
-	"\x04type\x18\x01 \x01(\tR\x04type\x12)\n" +
-	"\x06snakes\x18\x02 \x03(\v2\x11.common.GameSnakeR\x06snakes\x12&\n" +
-	"\x05foods\x18\x03 \x03(\v2\x10.common.PositionR\x05foods\x12&\n" +
-	"\x05walls\x18\x04 \x03(\v2\x10.common.PositionR\x05walls\x12-\n" +
-	"\x12eliminated_players\x18\x05 \x03(\tR\x11eliminatedPlayers\x12(\n" +
-	"\x10winner_player_id\x18\x06 \x01(\tR\x0ewinnerPlayerId\x12\x16\n" +
-	"\x06status\x18\a \x01(\tR\x06status\"G\n" +
-	"\x0fJoinGameRequest\x12\x17\n" +
-	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
-	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"\xd0\x01\n" +
-	"\x10JoinGameResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
-	"\x0einitial_snakes\x18\x03 \x03(\v2\x11.common.GameSnakeR\rinitialSnakes\x12&\n" +
-	"\x05foods\x18\x04 \x03(\v2\x10.common.PositionR\x05foods\x12&\n" +
-	"\x05walls\x18\x05 \x03(\v2\x10.common.PositionR\x05walls\"H\n" +
-	"\x10LeaveGameRequest\x12\x17\n" +
-	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
-	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"G\n" +
-	"\x11LeaveGameResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"t\n" +
-	"\vMoveRequest\x12\x17\n" +
-	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
-	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12/\n" +
-	"\tdirection\x18\x03 \x01(\x0e2\x11.common.DirectionR\tdirection\"B\n" +
-	"\fMoveResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\".\n" +
-	"\x13GetGameStateRequest\x12\x17\n" +
-	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"\xdd\x01\n" +
-	"\x14GetGameStateResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
-	"\x06snakes\x18\x03 \x03(\v2\x11.common.GameSnakeR\x06snakes\x12&\n" +
-	"\x05foods\x18\x04 \x03(\v2\x10.common.PositionR\x05foods\x12&\n" +
-	"\x05walls\x18\x05 \x03(\v2\x10.common.PositionR\x05walls\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\"S\n" +
-	"\x1bSubscribeGameUpdatesRequest\x12\x17\n" +
-	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
-	"\tplayer_id\x18\x02 \x01(\tR\bplayerId2\x9b\x03\n" +
-	"\vGameService\x12I\n" +
+func (x *MatchPlayerResult) Reset() {
+	*x = MatchPlayerResult{}
+	mi := &file_proto_game_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchPlayerResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchPlayerResult) ProtoMessage() {}
+
+func (x *MatchPlayerResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchPlayerResult.ProtoReflect.Descriptor instead.
+func (*MatchPlayerResult) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MatchPlayerResult) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *MatchPlayerResult) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *MatchPlayerResult) GetPlacement() int32 {
+	if x != nil {
+		return x.Placement
+	}
+	return 0
+}
+
+func (x *MatchPlayerResult) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type MatchRecord struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MatchId        string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	RoomId         string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Players        []*MatchPlayerResult   `protobuf:"bytes,3,rep,name=players,proto3" json:"players,omitempty"`                                       // 按名次排序
+	WinnerPlayerId string                 `protobuf:"bytes,4,opt,name=winner_player_id,json=winnerPlayerId,proto3" json:"winner_player_id,omitempty"` // 平局为空
+	DurationMs     int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	StartedAt      int64                  `protobuf:"varint,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt        int64                  `protobuf:"varint,7,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MatchRecord) Reset() {
+	*x = MatchRecord{}
+	mi := &file_proto_game_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchRecord) ProtoMessage() {}
+
+func (x *MatchRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchRecord.ProtoReflect.Descriptor instead.
+func (*MatchRecord) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MatchRecord) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetPlayers() []*MatchPlayerResult {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *MatchRecord) GetWinnerPlayerId() string {
+	if x != nil {
+		return x.WinnerPlayerId
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetEndedAt() int64 {
+	if x != nil {
+		return x.EndedAt
+	}
+	return 0
+}
+
+type GetMatchHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                         // 从 1 开始
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 为 0 时使用默认值
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMatchHistoryRequest) Reset() {
+	*x = GetMatchHistoryRequest{}
+	mi := &file_proto_game_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMatchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMatchHistoryRequest) ProtoMessage() {}
+
+func (x *GetMatchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMatchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetMatchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetMatchHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetMatchHistoryRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetMatchHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetMatchHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Matches       []*MatchRecord         `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMatchHistoryResponse) Reset() {
+	*x = GetMatchHistoryResponse{}
+	mi := &file_proto_game_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMatchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMatchHistoryResponse) ProtoMessage() {}
+
+func (x *GetMatchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMatchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetMatchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetMatchHistoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetMatchHistoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetMatchHistoryResponse) GetMatches() []*MatchRecord {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *GetMatchHistoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMatchRequest) Reset() {
+	*x = GetMatchRequest{}
+	mi := &file_proto_game_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMatchRequest) ProtoMessage() {}
+
+func (x *GetMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMatchRequest.ProtoReflect.Descriptor instead.
+func (*GetMatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type GetMatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Match         *MatchRecord           `protobuf:"bytes,3,opt,name=match,proto3" json:"match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMatchResponse) Reset() {
+	*x = GetMatchResponse{}
+	mi := &file_proto_game_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMatchResponse) ProtoMessage() {}
+
+func (x *GetMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMatchResponse.ProtoReflect.Descriptor instead.
+func (*GetMatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetMatchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetMatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetMatchResponse) GetMatch() *MatchRecord {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+type ReplayInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tick          int64                  `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"` // 在该帧结算之后、下一帧结算之前生效
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`  // move, join, leave
+	PlayerId      string                 `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Direction     Direction              `protobuf:"varint,4,opt,name=direction,proto3,enum=common.Direction" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayInput) Reset() {
+	*x = ReplayInput{}
+	mi := &file_proto_game_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayInput) ProtoMessage() {}
+
+func (x *ReplayInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayInput.ProtoReflect.Descriptor instead.
+func (*ReplayInput) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReplayInput) GetTick() int64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *ReplayInput) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ReplayInput) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *ReplayInput) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_NONE
+}
+
+type Replay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Seed          int64                  `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	HeadOnRule    string                 `protobuf:"bytes,4,opt,name=head_on_rule,json=headOnRule,proto3" json:"head_on_rule,omitempty"`
+	InitialState  *GameUpdate            `protobuf:"bytes,5,opt,name=initial_state,json=initialState,proto3" json:"initial_state,omitempty"`
+	Inputs        []*ReplayInput         `protobuf:"bytes,6,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	FinalTick     int64                  `protobuf:"varint,7,opt,name=final_tick,json=finalTick,proto3" json:"final_tick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Replay) Reset() {
+	*x = Replay{}
+	mi := &file_proto_game_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Replay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Replay) ProtoMessage() {}
+
+func (x *Replay) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Replay.ProtoReflect.Descriptor instead.
+func (*Replay) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Replay) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *Replay) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *Replay) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *Replay) GetHeadOnRule() string {
+	if x != nil {
+		return x.HeadOnRule
+	}
+	return ""
+}
+
+func (x *Replay) GetInitialState() *GameUpdate {
+	if x != nil {
+		return x.InitialState
+	}
+	return nil
+}
+
+func (x *Replay) GetInputs() []*ReplayInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Replay) GetFinalTick() int64 {
+	if x != nil {
+		return x.FinalTick
+	}
+	return 0
+}
+
+type GetReplayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReplayRequest) Reset() {
+	*x = GetReplayRequest{}
+	mi := &file_proto_game_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReplayRequest) ProtoMessage() {}
+
+func (x *GetReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReplayRequest.ProtoReflect.Descriptor instead.
+func (*GetReplayRequest) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetReplayRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type GetReplayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Replay        *Replay                `protobuf:"bytes,3,opt,name=replay,proto3" json:"replay,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReplayResponse) Reset() {
+	*x = GetReplayResponse{}
+	mi := &file_proto_game_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReplayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReplayResponse) ProtoMessage() {}
+
+func (x *GetReplayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReplayResponse.ProtoReflect.Descriptor instead.
+func (*GetReplayResponse) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetReplayResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetReplayResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetReplayResponse) GetReplay() *Replay {
+	if x != nil {
+		return x.Replay
+	}
+	return nil
+}
+
+type StreamReplayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Speed         float64                `protobuf:"fixed64,2,opt,name=speed,proto3" json:"speed,omitempty"` // 播放倍速，0 为原速，最大 16 倍
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamReplayRequest) Reset() {
+	*x = StreamReplayRequest{}
+	mi := &file_proto_game_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamReplayRequest) ProtoMessage() {}
+
+func (x *StreamReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamReplayRequest.ProtoReflect.Descriptor instead.
+func (*StreamReplayRequest) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StreamReplayRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *StreamReplayRequest) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type SpectateGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	SpectatorId   string                 `protobuf:"bytes,2,opt,name=spectator_id,json=spectatorId,proto3" json:"spectator_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectateGameRequest) Reset() {
+	*x = SpectateGameRequest{}
+	mi := &file_proto_game_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectateGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectateGameRequest) ProtoMessage() {}
+
+func (x *SpectateGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectateGameRequest.ProtoReflect.Descriptor instead.
+func (*SpectateGameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SpectateGameRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *SpectateGameRequest) GetSpectatorId() string {
+	if x != nil {
+		return x.SpectatorId
+	}
+	return ""
+}
+
+type GetActiveGamesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerIds     []string               `protobuf:"bytes,1,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActiveGamesRequest) Reset() {
+	*x = GetActiveGamesRequest{}
+	mi := &file_proto_game_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveGamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveGamesRequest) ProtoMessage() {}
+
+func (x *GetActiveGamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveGamesRequest.ProtoReflect.Descriptor instead.
+func (*GetActiveGamesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetActiveGamesRequest) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+type ActiveGame struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId       string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	RoomId         string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	SpectatorCount int32                  `protobuf:"varint,3,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ActiveGame) Reset() {
+	*x = ActiveGame{}
+	mi := &file_proto_game_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveGame) ProtoMessage() {}
+
+func (x *ActiveGame) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveGame.ProtoReflect.Descriptor instead.
+func (*ActiveGame) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ActiveGame) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *ActiveGame) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *ActiveGame) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
+type GetActiveGamesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Games         []*ActiveGame          `protobuf:"bytes,3,rep,name=games,proto3" json:"games,omitempty"` // 只包含正在对局中的玩家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActiveGamesResponse) Reset() {
+	*x = GetActiveGamesResponse{}
+	mi := &file_proto_game_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveGamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveGamesResponse) ProtoMessage() {}
+
+func (x *GetActiveGamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_game_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveGamesResponse.ProtoReflect.Descriptor instead.
+func (*GetActiveGamesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_game_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetActiveGamesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetActiveGamesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetActiveGamesResponse) GetGames() []*ActiveGame {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+var File_proto_game_proto protoreflect.FileDescriptor
+
+const file_proto_game_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/game.proto\x12\fgame_service\x1a\x12proto/common.proto\"\xf8\x02\n" +
+	"\n" +
+	"GameUpdate\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12)\n" +
+	"\x06snakes\x18\x02 \x03(\v2\x11.common.GameSnakeR\x06snakes\x12&\n" +
+	"\x05foods\x18\x03 \x03(\v2\x10.common.PositionR\x05foods\x12&\n" +
+	"\x05walls\x18\x04 \x03(\v2\x10.common.PositionR\x05walls\x12-\n" +
+	"\x12eliminated_players\x18\x05 \x03(\tR\x11eliminatedPlayers\x12(\n" +
+	"\x10winner_player_id\x18\x06 \x01(\tR\x0ewinnerPlayerId\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12-\n" +
+	"\aoptions\x18\b \x01(\v2\x13.common.GameOptionsR\aoptions\x12\x12\n" +
+	"\x04tick\x18\t \x01(\x03R\x04tick\x12'\n" +
+	"\x0fspectator_count\x18\n" +
+	" \x01(\x05R\x0espectatorCount\"z\n" +
+	"\x11CreateGameRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12-\n" +
+	"\aoptions\x18\x02 \x01(\v2\x13.common.GameOptionsR\aoptions\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x03 \x03(\tR\tplayerIds\"H\n" +
+	"\x12CreateGameResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"G\n" +
+	"\x0fJoinGameRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"\xd0\x01\n" +
+	"\x10JoinGameResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
+	"\x0einitial_snakes\x18\x03 \x03(\v2\x11.common.GameSnakeR\rinitialSnakes\x12&\n" +
+	"\x05foods\x18\x04 \x03(\v2\x10.common.PositionR\x05foods\x12&\n" +
+	"\x05walls\x18\x05 \x03(\v2\x10.common.PositionR\x05walls\"H\n" +
+	"\x10LeaveGameRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"G\n" +
+	"\x11LeaveGameResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"t\n" +
+	"\vMoveRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12/\n" +
+	"\tdirection\x18\x03 \x01(\x0e2\x11.common.DirectionR\tdirection\"B\n" +
+	"\fMoveResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\".\n" +
+	"\x13GetGameStateRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"\xb5\x02\n" +
+	"\x14GetGameStateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
+	"\x06snakes\x18\x03 \x03(\v2\x11.common.GameSnakeR\x06snakes\x12&\n" +
+	"\x05foods\x18\x04 \x03(\v2\x10.common.PositionR\x05foods\x12&\n" +
+	"\x05walls\x18\x05 \x03(\v2\x10.common.PositionR\x05walls\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12-\n" +
+	"\aoptions\x18\a \x01(\v2\x13.common.GameOptionsR\aoptions\x12'\n" +
+	"\x0fspectator_count\x18\b \x01(\x05R\x0espectatorCount\"S\n" +
+	"\x1bSubscribeGameUpdatesRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"|\n" +
+	"\x11MatchPlayerResult\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x05R\x05score\x12\x1c\n" +
+	"\tplacement\x18\x03 \x01(\x05R\tplacement\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\x05R\x06length\"\x81\x02\n" +
+	"\vMatchRecord\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x129\n" +
+	"\aplayers\x18\x03 \x03(\v2\x1f.game_service.MatchPlayerResultR\aplayers\x12(\n" +
+	"\x10winner_player_id\x18\x04 \x01(\tR\x0ewinnerPlayerId\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\x03R\tstartedAt\x12\x19\n" +
+	"\bended_at\x18\a \x01(\x03R\aendedAt\"b\n" +
+	"\x16GetMatchHistoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x98\x01\n" +
+	"\x17GetMatchHistoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x123\n" +
+	"\amatches\x18\x03 \x03(\v2\x19.game_service.MatchRecordR\amatches\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\",\n" +
+	"\x0fGetMatchRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\"w\n" +
+	"\x10GetMatchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
+	"\x05match\x18\x03 \x01(\v2\x19.game_service.MatchRecordR\x05match\"\x83\x01\n" +
+	"\vReplayInput\x12\x12\n" +
+	"\x04tick\x18\x01 \x01(\x03R\x04tick\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1b\n" +
+	"\tplayer_id\x18\x03 \x01(\tR\bplayerId\x12/\n" +
+	"\tdirection\x18\x04 \x01(\x0e2\x11.common.DirectionR\tdirection\"\x83\x02\n" +
+	"\x06Replay\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x12\x12\n" +
+	"\x04seed\x18\x03 \x01(\x03R\x04seed\x12 \n" +
+	"\fhead_on_rule\x18\x04 \x01(\tR\n" +
+	"headOnRule\x12=\n" +
+	"\rinitial_state\x18\x05 \x01(\v2\x18.game_service.GameUpdateR\finitialState\x121\n" +
+	"\x06inputs\x18\x06 \x03(\v2\x19.game_service.ReplayInputR\x06inputs\x12\x1d\n" +
+	"\n" +
+	"final_tick\x18\a \x01(\x03R\tfinalTick\"-\n" +
+	"\x10GetReplayRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\"u\n" +
+	"\x11GetReplayResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\x06replay\x18\x03 \x01(\v2\x14.game_service.ReplayR\x06replay\"F\n" +
+	"\x13StreamReplayRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x14\n" +
+	"\x05speed\x18\x02 \x01(\x01R\x05speed\"Q\n" +
+	"\x13SpectateGameRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12!\n" +
+	"\fspectator_id\x18\x02 \x01(\tR\vspectatorId\"6\n" +
+	"\x15GetActiveGamesRequest\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x01 \x03(\tR\tplayerIds\"k\n" +
+	"\n" +
+	"ActiveGame\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x12'\n" +
+	"\x0fspectator_count\x18\x03 \x01(\x05R\x0espectatorCount\"|\n" +
+	"\x16GetActiveGamesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\x05games\x18\x03 \x03(\v2\x18.game_service.ActiveGameR\x05games2\xe0\a\n" +
+	"\vGameService\x12O\n" +
+	"\n" +
+	"CreateGame\x12\x1f.game_service.CreateGameRequest\x1a .game_service.CreateGameResponse\x12I\n" +
 	"\bJoinGame\x12\x1d.game_service.JoinGameRequest\x1a\x1e.game_service.JoinGameResponse\x12L\n" +
 	"\tLeaveGame\x12\x1e.game_service.LeaveGameRequest\x1a\x1f.game_service.LeaveGameResponse\x12=\n" +
 	"\x04Move\x12\x19.game_service.MoveRequest\x1a\x1a.game_service.MoveResponse\x12U\n" +
 	"\fGetGameState\x12!.game_service.GetGameStateRequest\x1a\".game_service.GetGameStateResponse\x12]\n" +
-	"\x14SubscribeGameUpdates\x12).game_service.SubscribeGameUpdatesRequest\x1a\x18.game_service.GameUpdate0\x01B\tZ\a./protob\x06proto3"
+	"\x14SubscribeGameUpdates\x12).game_service.SubscribeGameUpdatesRequest\x1a\x18.game_service.GameUpdate0\x01\x12^\n" +
+	"\x0fGetMatchHistory\x12$.game_service.GetMatchHistoryRequest\x1a%.game_service.GetMatchHistoryResponse\x12I\n" +
+	"\bGetMatch\x12\x1d.game_service.GetMatchRequest\x1a\x1e.game_service.GetMatchResponse\x12L\n" +
+	"\tGetReplay\x12\x1e.game_service.GetReplayRequest\x1a\x1f.game_service.GetReplayResponse\x12M\n" +
+	"\fStreamReplay\x12!.game_service.StreamReplayRequest\x1a\x18.game_service.GameUpdate0\x01\x12M\n" +
+	"\fSpectateGame\x12!.game_service.SpectateGameRequest\x1a\x18.game_service.GameUpdate0\x01\x12[\n" +
+	"\x0eGetActiveGames\x12#.game_service.GetActiveGamesRequest\x1a$.game_service.GetActiveGamesResponseB\tZ\a./protob\x06proto3"
 
 var (
 	file_proto_game_proto_rawDescOnce sync.Once
@@ -705,48 +1872,91 @@ func file_proto_game_proto_rawDescGZIP() []byte {
 	return file_proto_game_proto_rawDescData
 }
 
-var file_proto_game_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_game_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_proto_game_proto_goTypes = []any{
 	(*GameUpdate)(nil),                  // 0: game_service.GameUpdate
-	(*JoinGameRequest)(nil),             // 1: game_service.JoinGameRequest
-	(*JoinGameResponse)(nil),            // 2: game_service.JoinGameResponse
-	(*LeaveGameRequest)(nil),            // 3: game_service.LeaveGameRequest
-	(*LeaveGameResponse)(nil),           // 4: game_service.LeaveGameResponse
-	(*MoveRequest)(nil),                 // 5: game_service.MoveRequest
-	(*MoveResponse)(nil),                // 6: game_service.MoveResponse
-	(*GetGameStateRequest)(nil),         // 7: game_service.GetGameStateRequest
-	(*GetGameStateResponse)(nil),        // 8: game_service.GetGameStateResponse
-	(*SubscribeGameUpdatesRequest)(nil), // 9: game_service.SubscribeGameUpdatesRequest
-	(*GameSnake)(nil),                   // 10: common.GameSnake
-	(*Position)(nil),                    // 11: common.Position
-	(Direction)(0),                      // 12: common.Direction
+	(*CreateGameRequest)(nil),           // 1: game_service.CreateGameRequest
+	(*CreateGameResponse)(nil),          // 2: game_service.CreateGameResponse
+	(*JoinGameRequest)(nil),             // 3: game_service.JoinGameRequest
+	(*JoinGameResponse)(nil),            // 4: game_service.JoinGameResponse
+	(*LeaveGameRequest)(nil),            // 5: game_service.LeaveGameRequest
+	(*LeaveGameResponse)(nil),           // 6: game_service.LeaveGameResponse
+	(*MoveRequest)(nil),                 // 7: game_service.MoveRequest
+	(*MoveResponse)(nil),                // 8: game_service.MoveResponse
+	(*GetGameStateRequest)(nil),         // 9: game_service.GetGameStateRequest
+	(*GetGameStateResponse)(nil),        // 10: game_service.GetGameStateResponse
+	(*SubscribeGameUpdatesRequest)(nil), // 11: game_service.SubscribeGameUpdatesRequest
+	(*MatchPlayerResult)(nil),           // 12: game_service.MatchPlayerResult
+	(*MatchRecord)(nil),                 // 13: game_service.MatchRecord
+	(*GetMatchHistoryRequest)(nil),      // 14: game_service.GetMatchHistoryRequest
+	(*GetMatchHistoryResponse)(nil),     // 15: game_service.GetMatchHistoryResponse
+	(*GetMatchRequest)(nil),             // 16: game_service.GetMatchRequest
+	(*GetMatchResponse)(nil),            // 17: game_service.GetMatchResponse
+	(*ReplayInput)(nil),                 // 18: game_service.ReplayInput
+	(*Replay)(nil),                      // 19: game_service.Replay
+	(*GetReplayRequest)(nil),            // 20: game_service.GetReplayRequest
+	(*GetReplayResponse)(nil),           // 21: game_service.GetReplayResponse
+	(*StreamReplayRequest)(nil),         // 22: game_service.StreamReplayRequest
+	(*SpectateGameRequest)(nil),         // 23: game_service.SpectateGameRequest
+	(*GetActiveGamesRequest)(nil),       // 24: game_service.GetActiveGamesRequest
+	(*ActiveGame)(nil),                  // 25: game_service.ActiveGame
+	(*GetActiveGamesResponse)(nil),      // 26: game_service.GetActiveGamesResponse
+	(*GameSnake)(nil),                   // 27: common.GameSnake
+	(*Position)(nil),                    // 28: common.Position
+	(*GameOptions)(nil),                 // 29: common.GameOptions
+	(Direction)(0),                      // 30: common.Direction
 }
 var file_proto_game_proto_depIdxs = []int32{
-	10, // 0: game_service.GameUpdate.snakes:type_name -> common.GameSnake
-	11, // 1: game_service.GameUpdate.foods:type_name -> common.Position
-	11, // 2: game_service.GameUpdate.walls:type_name -> common.Position
-	10, // 3: game_service.JoinGameResponse.initial_snakes:type_name -> common.GameSnake
-	11, // 4: game_service.JoinGameResponse.foods:type_name -> common.Position
-	11, // 5: game_service.JoinGameResponse.walls:type_name -> common.Position
-	12, // 6: game_service.MoveRequest.direction:type_name -> common.Direction
-	10, // 7: game_service.GetGameStateResponse.snakes:type_name -> common.GameSnake
-	11, // 8: game_service.GetGameStateResponse.foods:type_name -> common.Position
-	11, // 9: game_service.GetGameStateResponse.walls:type_name -> common.Position
-	1,  // 10: game_service.GameService.JoinGame:input_type -> game_service.JoinGameRequest
-	3,  // 11: game_service.GameService.LeaveGame:input_type -> game_service.LeaveGameRequest
-	5,  // 12: game_service.GameService.Move:input_type -> game_service.MoveRequest
-	7,  // 13: game_service.GameService.GetGameState:input_type -> game_service.GetGameStateRequest
-	9,  // 14: game_service.GameService.SubscribeGameUpdates:input_type -> game_service.SubscribeGameUpdatesRequest
-	2,  // 15: game_service.GameService.JoinGame:output_type -> game_service.JoinGameResponse
-	4,  // 16: game_service.GameService.LeaveGame:output_type -> game_service.LeaveGameResponse
-	6,  // 17: game_service.GameService.Move:output_type -> game_service.MoveResponse
-	8,  // 18: game_service.GameService.GetGameState:output_type -> game_service.GetGameStateResponse
-	0,  // 19: game_service.GameService.SubscribeGameUpdates:output_type -> game_service.GameUpdate
-	15, // [15:20] is the sub-list for method output_type
-	10, // [10:15] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	27, // 0: game_service.GameUpdate.snakes:type_name -> common.GameSnake
+	28, // 1: game_service.GameUpdate.foods:type_name -> common.Position
+	28, // 2: game_service.GameUpdate.walls:type_name -> common.Position
+	29, // 3: game_service.GameUpdate.options:type_name -> common.GameOptions
+	29, // 4: game_service.CreateGameRequest.options:type_name -> common.GameOptions
+	27, // 5: game_service.JoinGameResponse.initial_snakes:type_name -> common.GameSnake
+	28, // 6: game_service.JoinGameResponse.foods:type_name -> common.Position
+	28, // 7: game_service.JoinGameResponse.walls:type_name -> common.Position
+	30, // 8: game_service.MoveRequest.direction:type_name -> common.Direction
+	27, // 9: game_service.GetGameStateResponse.snakes:type_name -> common.GameSnake
+	28, // 10: game_service.GetGameStateResponse.foods:type_name -> common.Position
+	28, // 11: game_service.GetGameStateResponse.walls:type_name -> common.Position
+	29, // 12: game_service.GetGameStateResponse.options:type_name -> common.GameOptions
+	12, // 13: game_service.MatchRecord.players:type_name -> game_service.MatchPlayerResult
+	13, // 14: game_service.GetMatchHistoryResponse.matches:type_name -> game_service.MatchRecord
+	13, // 15: game_service.GetMatchResponse.match:type_name -> game_service.MatchRecord
+	30, // 16: game_service.ReplayInput.direction:type_name -> common.Direction
+	0,  // 17: game_service.Replay.initial_state:type_name -> game_service.GameUpdate
+	18, // 18: game_service.Replay.inputs:type_name -> game_service.ReplayInput
+	19, // 19: game_service.GetReplayResponse.replay:type_name -> game_service.Replay
+	25, // 20: game_service.GetActiveGamesResponse.games:type_name -> game_service.ActiveGame
+	1,  // 21: game_service.GameService.CreateGame:input_type -> game_service.CreateGameRequest
+	3,  // 22: game_service.GameService.JoinGame:input_type -> game_service.JoinGameRequest
+	5,  // 23: game_service.GameService.LeaveGame:input_type -> game_service.LeaveGameRequest
+	7,  // 24: game_service.GameService.Move:input_type -> game_service.MoveRequest
+	9,  // 25: game_service.GameService.GetGameState:input_type -> game_service.GetGameStateRequest
+	11, // 26: game_service.GameService.SubscribeGameUpdates:input_type -> game_service.SubscribeGameUpdatesRequest
+	14, // 27: game_service.GameService.GetMatchHistory:input_type -> game_service.GetMatchHistoryRequest
+	16, // 28: game_service.GameService.GetMatch:input_type -> game_service.GetMatchRequest
+	20, // 29: game_service.GameService.GetReplay:input_type -> game_service.GetReplayRequest
+	22, // 30: game_service.GameService.StreamReplay:input_type -> game_service.StreamReplayRequest
+	23, // 31: game_service.GameService.SpectateGame:input_type -> game_service.SpectateGameRequest
+	24, // 32: game_service.GameService.GetActiveGames:input_type -> game_service.GetActiveGamesRequest
+	2,  // 33: game_service.GameService.CreateGame:output_type -> game_service.CreateGameResponse
+	4,  // 34: game_service.GameService.JoinGame:output_type -> game_service.JoinGameResponse
+	6,  // 35: game_service.GameService.LeaveGame:output_type -> game_service.LeaveGameResponse
+	8,  // 36: game_service.GameService.Move:output_type -> game_service.MoveResponse
+	10, // 37: game_service.GameService.GetGameState:output_type -> game_service.GetGameStateResponse
+	0,  // 38: game_service.GameService.SubscribeGameUpdates:output_type -> game_service.GameUpdate
+	15, // 39: game_service.GameService.GetMatchHistory:output_type -> game_service.GetMatchHistoryResponse
+	17, // 40: game_service.GameService.GetMatch:output_type -> game_service.GetMatchResponse
+	21, // 41: game_service.GameService.GetReplay:output_type -> game_service.GetReplayResponse
+	0,  // 42: game_service.GameService.StreamReplay:output_type -> game_service.GameUpdate
+	0,  // 43: game_service.GameService.SpectateGame:output_type -> game_service.GameUpdate
+	26, // 44: game_service.GameService.GetActiveGames:output_type -> game_service.GetActiveGamesResponse
+	33, // [33:45] is the sub-list for method output_type
+	21, // [21:33] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_proto_game_proto_init() }
@@ -761,7 +1971,7 @@ func file_proto_game_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_game_proto_rawDesc), len(file_proto_game_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
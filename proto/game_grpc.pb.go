@@ -19,11 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
+	GameService_CreateGame_FullMethodName           = "/game_service.GameService/CreateGame"
 	GameService_JoinGame_FullMethodName             = "/game_service.GameService/JoinGame"
 	GameService_LeaveGame_FullMethodName            = "/game_service.GameService/LeaveGame"
 	GameService_Move_FullMethodName                 = "/game_service.GameService/Move"
 	GameService_GetGameState_FullMethodName         = "/game_service.GameService/GetGameState"
 	GameService_SubscribeGameUpdates_FullMethodName = "/game_service.GameService/SubscribeGameUpdates"
+	GameService_GetMatchHistory_FullMethodName      = "/game_service.GameService/GetMatchHistory"
+	GameService_GetMatch_FullMethodName             = "/game_service.GameService/GetMatch"
+	GameService_GetReplay_FullMethodName            = "/game_service.GameService/GetReplay"
+	GameService_StreamReplay_FullMethodName         = "/game_service.GameService/StreamReplay"
+	GameService_SpectateGame_FullMethodName         = "/game_service.GameService/SpectateGame"
+	GameService_GetActiveGames_FullMethodName       = "/game_service.GameService/GetActiveGames"
 )
 
 // GameServiceClient is the client API for GameService service.
@@ -32,6 +39,8 @@ const (
 //
 // 游戏服务
 type GameServiceClient interface {
+	// 按房间配置和玩家名单创建并开始游戏
+	CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*CreateGameResponse, error)
 	// 加入游戏
 	JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error)
 	// 离开游戏
@@ -42,6 +51,18 @@ type GameServiceClient interface {
 	GetGameState(ctx context.Context, in *GetGameStateRequest, opts ...grpc.CallOption) (*GetGameStateResponse, error)
 	// 订阅游戏状态更新
 	SubscribeGameUpdates(ctx context.Context, in *SubscribeGameUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameUpdate], error)
+	// 分页获取玩家的历史对局，最近的在前
+	GetMatchHistory(ctx context.Context, in *GetMatchHistoryRequest, opts ...grpc.CallOption) (*GetMatchHistoryResponse, error)
+	// 获取单局对局记录
+	GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*GetMatchResponse, error)
+	// 获取对局回放数据（种子、开局状态和输入记录）
+	GetReplay(ctx context.Context, in *GetReplayRequest, opts ...grpc.CallOption) (*GetReplayResponse, error)
+	// 重新模拟对局并按原始（或加速的）帧率推送回放帧
+	StreamReplay(ctx context.Context, in *StreamReplayRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameUpdate], error)
+	// 以观战者身份订阅游戏更新（不放置蛇），更新会延迟送达
+	SpectateGame(ctx context.Context, in *SpectateGameRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameUpdate], error)
+	// 查询玩家当前所在的进行中对局
+	GetActiveGames(ctx context.Context, in *GetActiveGamesRequest, opts ...grpc.CallOption) (*GetActiveGamesResponse, error)
 }
 
 type gameServiceClient struct {
@@ -52,6 +73,16 @@ func NewGameServiceClient(cc grpc.ClientConnInterface) GameServiceClient {
 	return &gameServiceClient{cc}
 }
 
+func (c *gameServiceClient) CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*CreateGameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateGameResponse)
+	err := c.cc.Invoke(ctx, GameService_CreateGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gameServiceClient) JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(JoinGameResponse)
@@ -111,12 +142,92 @@ func (c *gameServiceClient) SubscribeGameUpdates(ctx context.Context, in *Subscr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GameService_SubscribeGameUpdatesClient = grpc.ServerStreamingClient[GameUpdate]
 
+func (c *gameServiceClient) GetMatchHistory(ctx context.Context, in *GetMatchHistoryRequest, opts ...grpc.CallOption) (*GetMatchHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMatchHistoryResponse)
+	err := c.cc.Invoke(ctx, GameService_GetMatchHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*GetMatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMatchResponse)
+	err := c.cc.Invoke(ctx, GameService_GetMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetReplay(ctx context.Context, in *GetReplayRequest, opts ...grpc.CallOption) (*GetReplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReplayResponse)
+	err := c.cc.Invoke(ctx, GameService_GetReplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) StreamReplay(ctx context.Context, in *StreamReplayRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[1], GameService_StreamReplay_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamReplayRequest, GameUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_StreamReplayClient = grpc.ServerStreamingClient[GameUpdate]
+
+func (c *gameServiceClient) SpectateGame(ctx context.Context, in *SpectateGameRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[2], GameService_SpectateGame_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SpectateGameRequest, GameUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_SpectateGameClient = grpc.ServerStreamingClient[GameUpdate]
+
+func (c *gameServiceClient) GetActiveGames(ctx context.Context, in *GetActiveGamesRequest, opts ...grpc.CallOption) (*GetActiveGamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetActiveGamesResponse)
+	err := c.cc.Invoke(ctx, GameService_GetActiveGames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GameServiceServer is the server API for GameService service.
 // All implementations must embed UnimplementedGameServiceServer
 // for forward compatibility.
 //
 // 游戏服务
 type GameServiceServer interface {
+	// 按房间配置和玩家名单创建并开始游戏
+	CreateGame(context.Context, *CreateGameRequest) (*CreateGameResponse, error)
 	// 加入游戏
 	JoinGame(context.Context, *JoinGameRequest) (*JoinGameResponse, error)
 	// 离开游戏
@@ -127,6 +238,18 @@ type GameServiceServer interface {
 	GetGameState(context.Context, *GetGameStateRequest) (*GetGameStateResponse, error)
 	// 订阅游戏状态更新
 	SubscribeGameUpdates(*SubscribeGameUpdatesRequest, grpc.ServerStreamingServer[GameUpdate]) error
+	// 分页获取玩家的历史对局，最近的在前
+	GetMatchHistory(context.Context, *GetMatchHistoryRequest) (*GetMatchHistoryResponse, error)
+	// 获取单局对局记录
+	GetMatch(context.Context, *GetMatchRequest) (*GetMatchResponse, error)
+	// 获取对局回放数据（种子、开局状态和输入记录）
+	GetReplay(context.Context, *GetReplayRequest) (*GetReplayResponse, error)
+	// 重新模拟对局并按原始（或加速的）帧率推送回放帧
+	StreamReplay(*StreamReplayRequest, grpc.ServerStreamingServer[GameUpdate]) error
+	// 以观战者身份订阅游戏更新（不放置蛇），更新会延迟送达
+	SpectateGame(*SpectateGameRequest, grpc.ServerStreamingServer[GameUpdate]) error
+	// 查询玩家当前所在的进行中对局
+	GetActiveGames(context.Context, *GetActiveGamesRequest) (*GetActiveGamesResponse, error)
 	mustEmbedUnimplementedGameServiceServer()
 }
 
@@ -137,6 +260,9 @@ type GameServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedGameServiceServer struct{}
 
+func (UnimplementedGameServiceServer) CreateGame(context.Context, *CreateGameRequest) (*CreateGameResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateGame not implemented")
+}
 func (UnimplementedGameServiceServer) JoinGame(context.Context, *JoinGameRequest) (*JoinGameResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method JoinGame not implemented")
 }
@@ -152,6 +278,24 @@ func (UnimplementedGameServiceServer) GetGameState(context.Context, *GetGameStat
 func (UnimplementedGameServiceServer) SubscribeGameUpdates(*SubscribeGameUpdatesRequest, grpc.ServerStreamingServer[GameUpdate]) error {
 	return status.Error(codes.Unimplemented, "method SubscribeGameUpdates not implemented")
 }
+func (UnimplementedGameServiceServer) GetMatchHistory(context.Context, *GetMatchHistoryRequest) (*GetMatchHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMatchHistory not implemented")
+}
+func (UnimplementedGameServiceServer) GetMatch(context.Context, *GetMatchRequest) (*GetMatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMatch not implemented")
+}
+func (UnimplementedGameServiceServer) GetReplay(context.Context, *GetReplayRequest) (*GetReplayResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReplay not implemented")
+}
+func (UnimplementedGameServiceServer) StreamReplay(*StreamReplayRequest, grpc.ServerStreamingServer[GameUpdate]) error {
+	return status.Error(codes.Unimplemented, "method StreamReplay not implemented")
+}
+func (UnimplementedGameServiceServer) SpectateGame(*SpectateGameRequest, grpc.ServerStreamingServer[GameUpdate]) error {
+	return status.Error(codes.Unimplemented, "method SpectateGame not implemented")
+}
+func (UnimplementedGameServiceServer) GetActiveGames(context.Context, *GetActiveGamesRequest) (*GetActiveGamesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetActiveGames not implemented")
+}
 func (UnimplementedGameServiceServer) mustEmbedUnimplementedGameServiceServer() {}
 func (UnimplementedGameServiceServer) testEmbeddedByValue()                     {}
 
@@ -173,6 +317,24 @@ func RegisterGameServiceServer(s grpc.ServiceRegistrar, srv GameServiceServer) {
 	s.RegisterService(&GameService_ServiceDesc, srv)
 }
 
+func _GameService_CreateGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).CreateGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_CreateGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).CreateGame(ctx, req.(*CreateGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GameService_JoinGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(JoinGameRequest)
 	if err := dec(in); err != nil {
@@ -256,6 +418,100 @@ func _GameService_SubscribeGameUpdates_Handler(srv interface{}, stream grpc.Serv
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GameService_SubscribeGameUpdatesServer = grpc.ServerStreamingServer[GameUpdate]
 
+func _GameService_GetMatchHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMatchHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetMatchHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetMatchHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetMatchHistory(ctx, req.(*GetMatchHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetMatch(ctx, req.(*GetMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetReplay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetReplay(ctx, req.(*GetReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_StreamReplay_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamReplayRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameServiceServer).StreamReplay(m, &grpc.GenericServerStream[StreamReplayRequest, GameUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_StreamReplayServer = grpc.ServerStreamingServer[GameUpdate]
+
+func _GameService_SpectateGame_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SpectateGameRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameServiceServer).SpectateGame(m, &grpc.GenericServerStream[SpectateGameRequest, GameUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_SpectateGameServer = grpc.ServerStreamingServer[GameUpdate]
+
+func _GameService_GetActiveGames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActiveGamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetActiveGames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetActiveGames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetActiveGames(ctx, req.(*GetActiveGamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GameService_ServiceDesc is the grpc.ServiceDesc for GameService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -263,6 +519,10 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "game_service.GameService",
 	HandlerType: (*GameServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGame",
+			Handler:    _GameService_CreateGame_Handler,
+		},
 		{
 			MethodName: "JoinGame",
 			Handler:    _GameService_JoinGame_Handler,
@@ -279,6 +539,22 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetGameState",
 			Handler:    _GameService_GetGameState_Handler,
 		},
+		{
+			MethodName: "GetMatchHistory",
+			Handler:    _GameService_GetMatchHistory_Handler,
+		},
+		{
+			MethodName: "GetMatch",
+			Handler:    _GameService_GetMatch_Handler,
+		},
+		{
+			MethodName: "GetReplay",
+			Handler:    _GameService_GetReplay_Handler,
+		},
+		{
+			MethodName: "GetActiveGames",
+			Handler:    _GameService_GetActiveGames_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -286,6 +562,16 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GameService_SubscribeGameUpdates_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamReplay",
+			Handler:       _GameService_StreamReplay_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SpectateGame",
+			Handler:       _GameService_SpectateGame_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/game.proto",
 }
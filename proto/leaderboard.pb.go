@@ -374,6 +374,358 @@ func (x *GetUserRankResponse) GetTotalUsers() int32 {
 	return 0
 }
 
+type MatchPlacement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Placement     int32                  `protobuf:"varint,2,opt,name=placement,proto3" json:"placement,omitempty"` // 名次，1 为第一名，并列名次相同
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchPlacement) Reset() {
+	*x = MatchPlacement{}
+	mi := &file_proto_leaderboard_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchPlacement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchPlacement) ProtoMessage() {}
+
+func (x *MatchPlacement) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_leaderboard_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchPlacement.ProtoReflect.Descriptor instead.
+func (*MatchPlacement) Descriptor() ([]byte, []int) {
+	return file_proto_leaderboard_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MatchPlacement) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MatchPlacement) GetPlacement() int32 {
+	if x != nil {
+		return x.Placement
+	}
+	return 0
+}
+
+type RecordMatchResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Placements    []*MatchPlacement      `protobuf:"bytes,2,rep,name=placements,proto3" json:"placements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordMatchResultRequest) Reset() {
+	*x = RecordMatchResultRequest{}
+	mi := &file_proto_leaderboard_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordMatchResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordMatchResultRequest) ProtoMessage() {}
+
+func (x *RecordMatchResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_leaderboard_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordMatchResultRequest.ProtoReflect.Descriptor instead.
+func (*RecordMatchResultRequest) Descriptor() ([]byte, []int) {
+	return file_proto_leaderboard_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RecordMatchResultRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RecordMatchResultRequest) GetPlacements() []*MatchPlacement {
+	if x != nil {
+		return x.Placements
+	}
+	return nil
+}
+
+type RatingChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OldRating     int32                  `protobuf:"varint,2,opt,name=old_rating,json=oldRating,proto3" json:"old_rating,omitempty"`
+	NewRating     int32                  `protobuf:"varint,3,opt,name=new_rating,json=newRating,proto3" json:"new_rating,omitempty"`
+	Deviation     int32                  `protobuf:"varint,4,opt,name=deviation,proto3" json:"deviation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingChange) Reset() {
+	*x = RatingChange{}
+	mi := &file_proto_leaderboard_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingChange) ProtoMessage() {}
+
+func (x *RatingChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_leaderboard_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingChange.ProtoReflect.Descriptor instead.
+func (*RatingChange) Descriptor() ([]byte, []int) {
+	return file_proto_leaderboard_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RatingChange) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RatingChange) GetOldRating() int32 {
+	if x != nil {
+		return x.OldRating
+	}
+	return 0
+}
+
+func (x *RatingChange) GetNewRating() int32 {
+	if x != nil {
+		return x.NewRating
+	}
+	return 0
+}
+
+func (x *RatingChange) GetDeviation() int32 {
+	if x != nil {
+		return x.Deviation
+	}
+	return 0
+}
+
+type RecordMatchResultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Changes       []*RatingChange        `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordMatchResultResponse) Reset() {
+	*x = RecordMatchResultResponse{}
+	mi := &file_proto_leaderboard_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordMatchResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordMatchResultResponse) ProtoMessage() {}
+
+func (x *RecordMatchResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_leaderboard_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordMatchResultResponse.ProtoReflect.Descriptor instead.
+func (*RecordMatchResultResponse) Descriptor() ([]byte, []int) {
+	return file_proto_leaderboard_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RecordMatchResultResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RecordMatchResultResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RecordMatchResultResponse) GetChanges() []*RatingChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type GetRatingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatingRequest) Reset() {
+	*x = GetRatingRequest{}
+	mi := &file_proto_leaderboard_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatingRequest) ProtoMessage() {}
+
+func (x *GetRatingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_leaderboard_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatingRequest.ProtoReflect.Descriptor instead.
+func (*GetRatingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_leaderboard_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetRatingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetRatingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Rating        int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	Deviation     int32                  `protobuf:"varint,4,opt,name=deviation,proto3" json:"deviation,omitempty"` // 评分偏差，越小越可信
+	GamesPlayed   int32                  `protobuf:"varint,5,opt,name=games_played,json=gamesPlayed,proto3" json:"games_played,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatingResponse) Reset() {
+	*x = GetRatingResponse{}
+	mi := &file_proto_leaderboard_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatingResponse) ProtoMessage() {}
+
+func (x *GetRatingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_leaderboard_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatingResponse.ProtoReflect.Descriptor instead.
+func (*GetRatingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_leaderboard_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetRatingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetRatingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetRatingResponse) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *GetRatingResponse) GetDeviation() int32 {
+	if x != nil {
+		return x.Deviation
+	}
+	return 0
+}
+
+func (x *GetRatingResponse) GetGamesPlayed() int32 {
+	if x != nil {
+		return x.GamesPlayed
+	}
+	return 0
+}
+
 var File_proto_leaderboard_proto protoreflect.FileDescriptor
 
 const file_proto_leaderboard_proto_rawDesc = "" +
@@ -402,11 +754,40 @@ const file_proto_leaderboard_proto_rawDesc = "" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
 	"\x04rank\x18\x03 \x01(\x05R\x04rank\x12\x1f\n" +
 	"\vtotal_users\x18\x04 \x01(\x05R\n" +
-	"totalUsers2\xc3\x02\n" +
+	"totalUsers\"G\n" +
+	"\x0eMatchPlacement\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\tplacement\x18\x02 \x01(\x05R\tplacement\"z\n" +
+	"\x18RecordMatchResultRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12C\n" +
+	"\n" +
+	"placements\x18\x02 \x03(\v2#.leaderboard_service.MatchPlacementR\n" +
+	"placements\"\x83\x01\n" +
+	"\fRatingChange\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"old_rating\x18\x02 \x01(\x05R\toldRating\x12\x1d\n" +
+	"\n" +
+	"new_rating\x18\x03 \x01(\x05R\tnewRating\x12\x1c\n" +
+	"\tdeviation\x18\x04 \x01(\x05R\tdeviation\"\x8c\x01\n" +
+	"\x19RecordMatchResultResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\achanges\x18\x03 \x03(\v2!.leaderboard_service.RatingChangeR\achanges\"+\n" +
+	"\x10GetRatingRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xa0\x01\n" +
+	"\x11GetRatingResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06rating\x18\x03 \x01(\x05R\x06rating\x12\x1c\n" +
+	"\tdeviation\x18\x04 \x01(\x05R\tdeviation\x12!\n" +
+	"\fgames_played\x18\x05 \x01(\x05R\vgamesPlayed2\x93\x04\n" +
 	"\x12LeaderboardService\x12i\n" +
 	"\x0eGetLeaderboard\x12*.leaderboard_service.GetLeaderboardRequest\x1a+.leaderboard_service.GetLeaderboardResponse\x12`\n" +
 	"\vUpdateScore\x12'.leaderboard_service.UpdateScoreRequest\x1a(.leaderboard_service.UpdateScoreResponse\x12`\n" +
-	"\vGetUserRank\x12'.leaderboard_service.GetUserRankRequest\x1a(.leaderboard_service.GetUserRankResponseB\tZ\a./protob\x06proto3"
+	"\vGetUserRank\x12'.leaderboard_service.GetUserRankRequest\x1a(.leaderboard_service.GetUserRankResponse\x12r\n" +
+	"\x11RecordMatchResult\x12-.leaderboard_service.RecordMatchResultRequest\x1a..leaderboard_service.RecordMatchResultResponse\x12Z\n" +
+	"\tGetRating\x12%.leaderboard_service.GetRatingRequest\x1a&.leaderboard_service.GetRatingResponseB\tZ\a./protob\x06proto3"
 
 var (
 	file_proto_leaderboard_proto_rawDescOnce sync.Once
@@ -420,29 +801,41 @@ func file_proto_leaderboard_proto_rawDescGZIP() []byte {
 	return file_proto_leaderboard_proto_rawDescData
 }
 
-var file_proto_leaderboard_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_leaderboard_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_proto_leaderboard_proto_goTypes = []any{
-	(*GetLeaderboardRequest)(nil),  // 0: leaderboard_service.GetLeaderboardRequest
-	(*GetLeaderboardResponse)(nil), // 1: leaderboard_service.GetLeaderboardResponse
-	(*UpdateScoreRequest)(nil),     // 2: leaderboard_service.UpdateScoreRequest
-	(*UpdateScoreResponse)(nil),    // 3: leaderboard_service.UpdateScoreResponse
-	(*GetUserRankRequest)(nil),     // 4: leaderboard_service.GetUserRankRequest
-	(*GetUserRankResponse)(nil),    // 5: leaderboard_service.GetUserRankResponse
-	(*LeaderboardEntry)(nil),       // 6: common.LeaderboardEntry
+	(*GetLeaderboardRequest)(nil),     // 0: leaderboard_service.GetLeaderboardRequest
+	(*GetLeaderboardResponse)(nil),    // 1: leaderboard_service.GetLeaderboardResponse
+	(*UpdateScoreRequest)(nil),        // 2: leaderboard_service.UpdateScoreRequest
+	(*UpdateScoreResponse)(nil),       // 3: leaderboard_service.UpdateScoreResponse
+	(*GetUserRankRequest)(nil),        // 4: leaderboard_service.GetUserRankRequest
+	(*GetUserRankResponse)(nil),       // 5: leaderboard_service.GetUserRankResponse
+	(*MatchPlacement)(nil),            // 6: leaderboard_service.MatchPlacement
+	(*RecordMatchResultRequest)(nil),  // 7: leaderboard_service.RecordMatchResultRequest
+	(*RatingChange)(nil),              // 8: leaderboard_service.RatingChange
+	(*RecordMatchResultResponse)(nil), // 9: leaderboard_service.RecordMatchResultResponse
+	(*GetRatingRequest)(nil),          // 10: leaderboard_service.GetRatingRequest
+	(*GetRatingResponse)(nil),         // 11: leaderboard_service.GetRatingResponse
+	(*LeaderboardEntry)(nil),          // 12: common.LeaderboardEntry
 }
 var file_proto_leaderboard_proto_depIdxs = []int32{
-	6, // 0: leaderboard_service.GetLeaderboardResponse.entries:type_name -> common.LeaderboardEntry
-	0, // 1: leaderboard_service.LeaderboardService.GetLeaderboard:input_type -> leaderboard_service.GetLeaderboardRequest
-	2, // 2: leaderboard_service.LeaderboardService.UpdateScore:input_type -> leaderboard_service.UpdateScoreRequest
-	4, // 3: leaderboard_service.LeaderboardService.GetUserRank:input_type -> leaderboard_service.GetUserRankRequest
-	1, // 4: leaderboard_service.LeaderboardService.GetLeaderboard:output_type -> leaderboard_service.GetLeaderboardResponse
-	3, // 5: leaderboard_service.LeaderboardService.UpdateScore:output_type -> leaderboard_service.UpdateScoreResponse
-	5, // 6: leaderboard_service.LeaderboardService.GetUserRank:output_type -> leaderboard_service.GetUserRankResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	12, // 0: leaderboard_service.GetLeaderboardResponse.entries:type_name -> common.LeaderboardEntry
+	6,  // 1: leaderboard_service.RecordMatchResultRequest.placements:type_name -> leaderboard_service.MatchPlacement
+	8,  // 2: leaderboard_service.RecordMatchResultResponse.changes:type_name -> leaderboard_service.RatingChange
+	0,  // 3: leaderboard_service.LeaderboardService.GetLeaderboard:input_type -> leaderboard_service.GetLeaderboardRequest
+	2,  // 4: leaderboard_service.LeaderboardService.UpdateScore:input_type -> leaderboard_service.UpdateScoreRequest
+	4,  // 5: leaderboard_service.LeaderboardService.GetUserRank:input_type -> leaderboard_service.GetUserRankRequest
+	7,  // 6: leaderboard_service.LeaderboardService.RecordMatchResult:input_type -> leaderboard_service.RecordMatchResultRequest
+	10, // 7: leaderboard_service.LeaderboardService.GetRating:input_type -> leaderboard_service.GetRatingRequest
+	1,  // 8: leaderboard_service.LeaderboardService.GetLeaderboard:output_type -> leaderboard_service.GetLeaderboardResponse
+	3,  // 9: leaderboard_service.LeaderboardService.UpdateScore:output_type -> leaderboard_service.UpdateScoreResponse
+	5,  // 10: leaderboard_service.LeaderboardService.GetUserRank:output_type -> leaderboard_service.GetUserRankResponse
+	9,  // 11: leaderboard_service.LeaderboardService.RecordMatchResult:output_type -> leaderboard_service.RecordMatchResultResponse
+	11, // 12: leaderboard_service.LeaderboardService.GetRating:output_type -> leaderboard_service.GetRatingResponse
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_leaderboard_proto_init() }
@@ -457,7 +850,7 @@ func file_proto_leaderboard_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_leaderboard_proto_rawDesc), len(file_proto_leaderboard_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
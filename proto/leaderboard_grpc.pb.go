@@ -19,9 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LeaderboardService_GetLeaderboard_FullMethodName = "/leaderboard_service.LeaderboardService/GetLeaderboard"
-	LeaderboardService_UpdateScore_FullMethodName    = "/leaderboard_service.LeaderboardService/UpdateScore"
-	LeaderboardService_GetUserRank_FullMethodName    = "/leaderboard_service.LeaderboardService/GetUserRank"
+	LeaderboardService_GetLeaderboard_FullMethodName    = "/leaderboard_service.LeaderboardService/GetLeaderboard"
+	LeaderboardService_UpdateScore_FullMethodName       = "/leaderboard_service.LeaderboardService/UpdateScore"
+	LeaderboardService_GetUserRank_FullMethodName       = "/leaderboard_service.LeaderboardService/GetUserRank"
+	LeaderboardService_RecordMatchResult_FullMethodName = "/leaderboard_service.LeaderboardService/RecordMatchResult"
+	LeaderboardService_GetRating_FullMethodName         = "/leaderboard_service.LeaderboardService/GetRating"
 )
 
 // LeaderboardServiceClient is the client API for LeaderboardService service.
@@ -36,6 +38,10 @@ type LeaderboardServiceClient interface {
 	UpdateScore(ctx context.Context, in *UpdateScoreRequest, opts ...grpc.CallOption) (*UpdateScoreResponse, error)
 	// 获取用户排名
 	GetUserRank(ctx context.Context, in *GetUserRankRequest, opts ...grpc.CallOption) (*GetUserRankResponse, error)
+	// 按对局名次更新参赛玩家的评分（Glicko-2），同一对局只结算一次
+	RecordMatchResult(ctx context.Context, in *RecordMatchResultRequest, opts ...grpc.CallOption) (*RecordMatchResultResponse, error)
+	// 获取用户评分
+	GetRating(ctx context.Context, in *GetRatingRequest, opts ...grpc.CallOption) (*GetRatingResponse, error)
 }
 
 type leaderboardServiceClient struct {
@@ -76,6 +82,26 @@ func (c *leaderboardServiceClient) GetUserRank(ctx context.Context, in *GetUserR
 	return out, nil
 }
 
+func (c *leaderboardServiceClient) RecordMatchResult(ctx context.Context, in *RecordMatchResultRequest, opts ...grpc.CallOption) (*RecordMatchResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordMatchResultResponse)
+	err := c.cc.Invoke(ctx, LeaderboardService_RecordMatchResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardServiceClient) GetRating(ctx context.Context, in *GetRatingRequest, opts ...grpc.CallOption) (*GetRatingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRatingResponse)
+	err := c.cc.Invoke(ctx, LeaderboardService_GetRating_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LeaderboardServiceServer is the server API for LeaderboardService service.
 // All implementations must embed UnimplementedLeaderboardServiceServer
 // for forward compatibility.
@@ -88,6 +114,10 @@ type LeaderboardServiceServer interface {
 	UpdateScore(context.Context, *UpdateScoreRequest) (*UpdateScoreResponse, error)
 	// 获取用户排名
 	GetUserRank(context.Context, *GetUserRankRequest) (*GetUserRankResponse, error)
+	// 按对局名次更新参赛玩家的评分（Glicko-2），同一对局只结算一次
+	RecordMatchResult(context.Context, *RecordMatchResultRequest) (*RecordMatchResultResponse, error)
+	// 获取用户评分
+	GetRating(context.Context, *GetRatingRequest) (*GetRatingResponse, error)
 	mustEmbedUnimplementedLeaderboardServiceServer()
 }
 
@@ -107,6 +137,12 @@ func (UnimplementedLeaderboardServiceServer) UpdateScore(context.Context, *Updat
 func (UnimplementedLeaderboardServiceServer) GetUserRank(context.Context, *GetUserRankRequest) (*GetUserRankResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUserRank not implemented")
 }
+func (UnimplementedLeaderboardServiceServer) RecordMatchResult(context.Context, *RecordMatchResultRequest) (*RecordMatchResultResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordMatchResult not implemented")
+}
+func (UnimplementedLeaderboardServiceServer) GetRating(context.Context, *GetRatingRequest) (*GetRatingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRating not implemented")
+}
 func (UnimplementedLeaderboardServiceServer) mustEmbedUnimplementedLeaderboardServiceServer() {}
 func (UnimplementedLeaderboardServiceServer) testEmbeddedByValue()                            {}
 
@@ -182,6 +218,42 @@ func _LeaderboardService_GetUserRank_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LeaderboardService_RecordMatchResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordMatchResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).RecordMatchResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_RecordMatchResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).RecordMatchResult(ctx, req.(*RecordMatchResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeaderboardService_GetRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServiceServer).GetRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeaderboardService_GetRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServiceServer).GetRating(ctx, req.(*GetRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LeaderboardService_ServiceDesc is the grpc.ServiceDesc for LeaderboardService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -201,6 +273,14 @@ var LeaderboardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserRank",
 			Handler:    _LeaderboardService_GetUserRank_Handler,
 		},
+		{
+			MethodName: "RecordMatchResult",
+			Handler:    _LeaderboardService_RecordMatchResult_Handler,
+		},
+		{
+			MethodName: "GetRating",
+			Handler:    _LeaderboardService_GetRating_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/leaderboard.proto",
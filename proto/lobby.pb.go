@@ -200,6 +200,9 @@ type LoginResponse struct {
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Username      string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,5,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,6,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresIn     int64                  `protobuf:"varint,7,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` // 访问令牌的有效期（秒）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -262,6 +265,27 @@ func (x *LoginResponse) GetUsername() string {
 	return ""
 }
 
+func (x *LoginResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
 type GetUserProfileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -393,6 +417,7 @@ func (x *GetUserProfileResponse) GetGamesPlayed() int32 {
 type LogoutRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"` // 为空时吊销该用户的所有会话
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -434,6 +459,13 @@ func (x *LogoutRequest) GetUserId() string {
 	return ""
 }
 
+func (x *LogoutRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
 type LogoutResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -486,46 +518,950 @@ func (x *LogoutResponse) GetMessage() string {
 	return ""
 }
 
-var File_proto_lobby_proto protoreflect.FileDescriptor
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_lobby_proto_rawDesc = "" +
-	"\n" +
-	"\x11proto/lobby.proto\x12\rlobby_service\x1a\x12proto/common.proto\"_\n" +
-	"\x0fRegisterRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\"_\n" +
-	"\x10RegisterResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\"F\n" +
-	"\fLoginRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"x\n" +
-	"\rLoginResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
-	"\busername\x18\x04 \x01(\tR\busername\"0\n" +
-	"\x15GetUserProfileRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc4\x01\n" +
-	"\x16GetUserProfileResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12 \n" +
-	"\x04user\x18\x03 \x01(\v2\f.common.UserR\x04user\x12\x14\n" +
-	"\x05score\x18\x04 \x01(\x05R\x05score\x12\x1b\n" +
-	"\tgames_won\x18\x05 \x01(\x05R\bgamesWon\x12!\n" +
-	"\fgames_played\x18\x06 \x01(\x05R\vgamesPlayed\"(\n" +
-	"\rLogoutRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"D\n" +
-	"\x0eLogoutResponse\x12\x18\n" +
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_proto_lobby_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RefreshTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,4,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresIn     int64                  `protobuf:"varint,6,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` // 访问令牌的有效期（秒）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_proto_lobby_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RefreshTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RefreshTokenResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+type ValidateTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokenRequest) Reset() {
+	*x = ValidateTokenRequest{}
+	mi := &file_proto_lobby_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokenRequest) ProtoMessage() {}
+
+func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokenRequest.ProtoReflect.Descriptor instead.
+func (*ValidateTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ValidateTokenRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+type ValidateTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // 令牌有效
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokenResponse) Reset() {
+	*x = ValidateTokenResponse{}
+	mi := &file_proto_lobby_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokenResponse) ProtoMessage() {}
+
+func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokenResponse.ProtoReflect.Descriptor instead.
+func (*ValidateTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidateTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ValidateTokenResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidateTokenResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUsersByIdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersByIdsRequest) Reset() {
+	*x = GetUsersByIdsRequest{}
+	mi := &file_proto_lobby_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersByIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersByIdsRequest) ProtoMessage() {}
+
+func (x *GetUsersByIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersByIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersByIdsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetUsersByIdsRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type GetUsersByIdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Users         []*User                `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"` // 不含邮箱
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersByIdsResponse) Reset() {
+	*x = GetUsersByIdsResponse{}
+	mi := &file_proto_lobby_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersByIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersByIdsResponse) ProtoMessage() {}
+
+func (x *GetUsersByIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersByIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetUsersByIdsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetUsersByIdsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetUsersByIdsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetUsersByIdsResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type FindUserByUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindUserByUsernameRequest) Reset() {
+	*x = FindUserByUsernameRequest{}
+	mi := &file_proto_lobby_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindUserByUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindUserByUsernameRequest) ProtoMessage() {}
+
+func (x *FindUserByUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindUserByUsernameRequest.ProtoReflect.Descriptor instead.
+func (*FindUserByUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FindUserByUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type FindUserByUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"` // 不含邮箱
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindUserByUsernameResponse) Reset() {
+	*x = FindUserByUsernameResponse{}
+	mi := &file_proto_lobby_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindUserByUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindUserByUsernameResponse) ProtoMessage() {}
+
+func (x *FindUserByUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindUserByUsernameResponse.ProtoReflect.Descriptor instead.
+func (*FindUserByUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FindUserByUsernameResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *FindUserByUsernameResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FindUserByUsernameResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Idle          bool                   `protobuf:"varint,2,opt,name=idle,proto3" json:"idle,omitempty"` // 客户端一段时间无操作或页面不可见
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_proto_lobby_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *HeartbeatRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetIdle() bool {
+	if x != nil {
+		return x.Idle
+	}
+	return false
+}
+
+type HeartbeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Presence      *Presence              `protobuf:"bytes,3,opt,name=presence,proto3" json:"presence,omitempty"`
+	TtlSeconds    int64                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // 在此时间内需要再次上报心跳
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_proto_lobby_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *HeartbeatResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *HeartbeatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HeartbeatResponse) GetPresence() *Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return nil
+}
+
+func (x *HeartbeatResponse) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type UpdateActivityRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Activity string                 `protobuf:"bytes,2,opt,name=activity,proto3" json:"activity,omitempty"` // in_queue, in_room, in_game，为空表示清除当前活动
+	RoomId   string                 `protobuf:"bytes,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// 以下条件非空时，只有当前活动与之一致才更新，避免覆盖其他服务上报的活动
+	ExpectedActivity string `protobuf:"bytes,4,opt,name=expected_activity,json=expectedActivity,proto3" json:"expected_activity,omitempty"`
+	ExpectedRoomId   string `protobuf:"bytes,5,opt,name=expected_room_id,json=expectedRoomId,proto3" json:"expected_room_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateActivityRequest) Reset() {
+	*x = UpdateActivityRequest{}
+	mi := &file_proto_lobby_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateActivityRequest) ProtoMessage() {}
+
+func (x *UpdateActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateActivityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateActivityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateActivityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateActivityRequest) GetActivity() string {
+	if x != nil {
+		return x.Activity
+	}
+	return ""
+}
+
+func (x *UpdateActivityRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *UpdateActivityRequest) GetExpectedActivity() string {
+	if x != nil {
+		return x.ExpectedActivity
+	}
+	return ""
+}
+
+func (x *UpdateActivityRequest) GetExpectedRoomId() string {
+	if x != nil {
+		return x.ExpectedRoomId
+	}
+	return ""
+}
+
+type UpdateActivityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateActivityResponse) Reset() {
+	*x = UpdateActivityResponse{}
+	mi := &file_proto_lobby_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateActivityResponse) ProtoMessage() {}
+
+func (x *UpdateActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateActivityResponse.ProtoReflect.Descriptor instead.
+func (*UpdateActivityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateActivityResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateActivityResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceRequest) Reset() {
+	*x = GetPresenceRequest{}
+	mi := &file_proto_lobby_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceRequest) ProtoMessage() {}
+
+func (x *GetPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceRequest.ProtoReflect.Descriptor instead.
+func (*GetPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetPresenceRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type GetPresenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Presences     []*Presence            `protobuf:"bytes,3,rep,name=presences,proto3" json:"presences,omitempty"` // 与请求的顺序一致
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceResponse) Reset() {
+	*x = GetPresenceResponse{}
+	mi := &file_proto_lobby_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceResponse) ProtoMessage() {}
+
+func (x *GetPresenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceResponse.ProtoReflect.Descriptor instead.
+func (*GetPresenceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPresenceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetPresenceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetPresenceResponse) GetPresences() []*Presence {
+	if x != nil {
+		return x.Presences
+	}
+	return nil
+}
+
+type WatchPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPresenceRequest) Reset() {
+	*x = WatchPresenceRequest{}
+	mi := &file_proto_lobby_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPresenceRequest) ProtoMessage() {}
+
+func (x *WatchPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_lobby_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPresenceRequest.ProtoReflect.Descriptor instead.
+func (*WatchPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_lobby_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchPresenceRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+var File_proto_lobby_proto protoreflect.FileDescriptor
+
+const file_proto_lobby_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/lobby.proto\x12\rlobby_service\x1a\x12proto/common.proto\"_\n" +
+	"\x0fRegisterRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"_\n" +
+	"\x10RegisterResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"F\n" +
+	"\fLoginRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xdf\x01\n" +
+	"\rLoginResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12!\n" +
+	"\faccess_token\x18\x05 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x06 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\a \x01(\x03R\texpiresIn\"0\n" +
+	"\x15GetUserProfileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc4\x01\n" +
+	"\x16GetUserProfileResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12 \n" +
+	"\x04user\x18\x03 \x01(\v2\f.common.UserR\x04user\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x05R\x05score\x12\x1b\n" +
+	"\tgames_won\x18\x05 \x01(\x05R\bgamesWon\x12!\n" +
+	"\fgames_played\x18\x06 \x01(\x05R\vgamesPlayed\"K\n" +
+	"\rLogoutRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\"D\n" +
+	"\x0eLogoutResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\":\n" +
+	"\x13RefreshTokenRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\xca\x01\n" +
+	"\x14RefreshTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12!\n" +
+	"\faccess_token\x18\x04 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x06 \x01(\x03R\texpiresIn\"9\n" +
+	"\x14ValidateTokenRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"d\n" +
+	"\x15ValidateTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"1\n" +
+	"\x14GetUsersByIdsRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"o\n" +
+	"\x15GetUsersByIdsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
+	"\x05users\x18\x03 \x03(\v2\f.common.UserR\x05users\"7\n" +
+	"\x19FindUserByUsernameRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"r\n" +
+	"\x1aFindUserByUsernameResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12 \n" +
+	"\x04user\x18\x03 \x01(\v2\f.common.UserR\x04user\"?\n" +
+	"\x10HeartbeatRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04idle\x18\x02 \x01(\bR\x04idle\"\x96\x01\n" +
+	"\x11HeartbeatResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\bpresence\x18\x03 \x01(\v2\x10.common.PresenceR\bpresence\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x03R\n" +
+	"ttlSeconds\"\xbc\x01\n" +
+	"\x15UpdateActivityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bactivity\x18\x02 \x01(\tR\bactivity\x12\x17\n" +
+	"\aroom_id\x18\x03 \x01(\tR\x06roomId\x12+\n" +
+	"\x11expected_activity\x18\x04 \x01(\tR\x10expectedActivity\x12(\n" +
+	"\x10expected_room_id\x18\x05 \x01(\tR\x0eexpectedRoomId\"L\n" +
+	"\x16UpdateActivityResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"/\n" +
+	"\x12GetPresenceRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"y\n" +
+	"\x13GetPresenceResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xc5\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\tpresences\x18\x03 \x03(\v2\x10.common.PresenceR\tpresences\"1\n" +
+	"\x14WatchPresenceRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds2\x90\b\n" +
 	"\fLobbyService\x12K\n" +
 	"\bRegister\x12\x1e.lobby_service.RegisterRequest\x1a\x1f.lobby_service.RegisterResponse\x12B\n" +
 	"\x05Login\x12\x1b.lobby_service.LoginRequest\x1a\x1c.lobby_service.LoginResponse\x12]\n" +
 	"\x0eGetUserProfile\x12$.lobby_service.GetUserProfileRequest\x1a%.lobby_service.GetUserProfileResponse\x12E\n" +
-	"\x06Logout\x12\x1c.lobby_service.LogoutRequest\x1a\x1d.lobby_service.LogoutResponseB\tZ\a./protob\x06proto3"
+	"\x06Logout\x12\x1c.lobby_service.LogoutRequest\x1a\x1d.lobby_service.LogoutResponse\x12W\n" +
+	"\fRefreshToken\x12\".lobby_service.RefreshTokenRequest\x1a#.lobby_service.RefreshTokenResponse\x12Z\n" +
+	"\rValidateToken\x12#.lobby_service.ValidateTokenRequest\x1a$.lobby_service.ValidateTokenResponse\x12Z\n" +
+	"\rGetUsersByIds\x12#.lobby_service.GetUsersByIdsRequest\x1a$.lobby_service.GetUsersByIdsResponse\x12i\n" +
+	"\x12FindUserByUsername\x12(.lobby_service.FindUserByUsernameRequest\x1a).lobby_service.FindUserByUsernameResponse\x12N\n" +
+	"\tHeartbeat\x12\x1f.lobby_service.HeartbeatRequest\x1a .lobby_service.HeartbeatResponse\x12]\n" +
+	"\x0eUpdateActivity\x12$.lobby_service.UpdateActivityRequest\x1a%.lobby_service.UpdateActivityResponse\x12T\n" +
+	"\vGetPresence\x12!.lobby_service.GetPresenceRequest\x1a\".lobby_service.GetPresenceResponse\x12H\n" +
+	"\rWatchPresence\x12#.lobby_service.WatchPresenceRequest\x1a\x10.common.Presence0\x01B\tZ\a./protob\x06proto3"
 
 var (
 	file_proto_lobby_proto_rawDescOnce sync.Once
@@ -539,33 +1475,69 @@ func file_proto_lobby_proto_rawDescGZIP() []byte {
 	return file_proto_lobby_proto_rawDescData
 }
 
-var file_proto_lobby_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_lobby_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_proto_lobby_proto_goTypes = []any{
-	(*RegisterRequest)(nil),        // 0: lobby_service.RegisterRequest
-	(*RegisterResponse)(nil),       // 1: lobby_service.RegisterResponse
-	(*LoginRequest)(nil),           // 2: lobby_service.LoginRequest
-	(*LoginResponse)(nil),          // 3: lobby_service.LoginResponse
-	(*GetUserProfileRequest)(nil),  // 4: lobby_service.GetUserProfileRequest
-	(*GetUserProfileResponse)(nil), // 5: lobby_service.GetUserProfileResponse
-	(*LogoutRequest)(nil),          // 6: lobby_service.LogoutRequest
-	(*LogoutResponse)(nil),         // 7: lobby_service.LogoutResponse
-	(*User)(nil),                   // 8: common.User
+	(*RegisterRequest)(nil),            // 0: lobby_service.RegisterRequest
+	(*RegisterResponse)(nil),           // 1: lobby_service.RegisterResponse
+	(*LoginRequest)(nil),               // 2: lobby_service.LoginRequest
+	(*LoginResponse)(nil),              // 3: lobby_service.LoginResponse
+	(*GetUserProfileRequest)(nil),      // 4: lobby_service.GetUserProfileRequest
+	(*GetUserProfileResponse)(nil),     // 5: lobby_service.GetUserProfileResponse
+	(*LogoutRequest)(nil),              // 6: lobby_service.LogoutRequest
+	(*LogoutResponse)(nil),             // 7: lobby_service.LogoutResponse
+	(*RefreshTokenRequest)(nil),        // 8: lobby_service.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),       // 9: lobby_service.RefreshTokenResponse
+	(*ValidateTokenRequest)(nil),       // 10: lobby_service.ValidateTokenRequest
+	(*ValidateTokenResponse)(nil),      // 11: lobby_service.ValidateTokenResponse
+	(*GetUsersByIdsRequest)(nil),       // 12: lobby_service.GetUsersByIdsRequest
+	(*GetUsersByIdsResponse)(nil),      // 13: lobby_service.GetUsersByIdsResponse
+	(*FindUserByUsernameRequest)(nil),  // 14: lobby_service.FindUserByUsernameRequest
+	(*FindUserByUsernameResponse)(nil), // 15: lobby_service.FindUserByUsernameResponse
+	(*HeartbeatRequest)(nil),           // 16: lobby_service.HeartbeatRequest
+	(*HeartbeatResponse)(nil),          // 17: lobby_service.HeartbeatResponse
+	(*UpdateActivityRequest)(nil),      // 18: lobby_service.UpdateActivityRequest
+	(*UpdateActivityResponse)(nil),     // 19: lobby_service.UpdateActivityResponse
+	(*GetPresenceRequest)(nil),         // 20: lobby_service.GetPresenceRequest
+	(*GetPresenceResponse)(nil),        // 21: lobby_service.GetPresenceResponse
+	(*WatchPresenceRequest)(nil),       // 22: lobby_service.WatchPresenceRequest
+	(*User)(nil),                       // 23: common.User
+	(*Presence)(nil),                   // 24: common.Presence
 }
 var file_proto_lobby_proto_depIdxs = []int32{
-	8, // 0: lobby_service.GetUserProfileResponse.user:type_name -> common.User
-	0, // 1: lobby_service.LobbyService.Register:input_type -> lobby_service.RegisterRequest
-	2, // 2: lobby_service.LobbyService.Login:input_type -> lobby_service.LoginRequest
-	4, // 3: lobby_service.LobbyService.GetUserProfile:input_type -> lobby_service.GetUserProfileRequest
-	6, // 4: lobby_service.LobbyService.Logout:input_type -> lobby_service.LogoutRequest
-	1, // 5: lobby_service.LobbyService.Register:output_type -> lobby_service.RegisterResponse
-	3, // 6: lobby_service.LobbyService.Login:output_type -> lobby_service.LoginResponse
-	5, // 7: lobby_service.LobbyService.GetUserProfile:output_type -> lobby_service.GetUserProfileResponse
-	7, // 8: lobby_service.LobbyService.Logout:output_type -> lobby_service.LogoutResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	23, // 0: lobby_service.GetUserProfileResponse.user:type_name -> common.User
+	23, // 1: lobby_service.GetUsersByIdsResponse.users:type_name -> common.User
+	23, // 2: lobby_service.FindUserByUsernameResponse.user:type_name -> common.User
+	24, // 3: lobby_service.HeartbeatResponse.presence:type_name -> common.Presence
+	24, // 4: lobby_service.GetPresenceResponse.presences:type_name -> common.Presence
+	0,  // 5: lobby_service.LobbyService.Register:input_type -> lobby_service.RegisterRequest
+	2,  // 6: lobby_service.LobbyService.Login:input_type -> lobby_service.LoginRequest
+	4,  // 7: lobby_service.LobbyService.GetUserProfile:input_type -> lobby_service.GetUserProfileRequest
+	6,  // 8: lobby_service.LobbyService.Logout:input_type -> lobby_service.LogoutRequest
+	8,  // 9: lobby_service.LobbyService.RefreshToken:input_type -> lobby_service.RefreshTokenRequest
+	10, // 10: lobby_service.LobbyService.ValidateToken:input_type -> lobby_service.ValidateTokenRequest
+	12, // 11: lobby_service.LobbyService.GetUsersByIds:input_type -> lobby_service.GetUsersByIdsRequest
+	14, // 12: lobby_service.LobbyService.FindUserByUsername:input_type -> lobby_service.FindUserByUsernameRequest
+	16, // 13: lobby_service.LobbyService.Heartbeat:input_type -> lobby_service.HeartbeatRequest
+	18, // 14: lobby_service.LobbyService.UpdateActivity:input_type -> lobby_service.UpdateActivityRequest
+	20, // 15: lobby_service.LobbyService.GetPresence:input_type -> lobby_service.GetPresenceRequest
+	22, // 16: lobby_service.LobbyService.WatchPresence:input_type -> lobby_service.WatchPresenceRequest
+	1,  // 17: lobby_service.LobbyService.Register:output_type -> lobby_service.RegisterResponse
+	3,  // 18: lobby_service.LobbyService.Login:output_type -> lobby_service.LoginResponse
+	5,  // 19: lobby_service.LobbyService.GetUserProfile:output_type -> lobby_service.GetUserProfileResponse
+	7,  // 20: lobby_service.LobbyService.Logout:output_type -> lobby_service.LogoutResponse
+	9,  // 21: lobby_service.LobbyService.RefreshToken:output_type -> lobby_service.RefreshTokenResponse
+	11, // 22: lobby_service.LobbyService.ValidateToken:output_type -> lobby_service.ValidateTokenResponse
+	13, // 23: lobby_service.LobbyService.GetUsersByIds:output_type -> lobby_service.GetUsersByIdsResponse
+	15, // 24: lobby_service.LobbyService.FindUserByUsername:output_type -> lobby_service.FindUserByUsernameResponse
+	17, // 25: lobby_service.LobbyService.Heartbeat:output_type -> lobby_service.HeartbeatResponse
+	19, // 26: lobby_service.LobbyService.UpdateActivity:output_type -> lobby_service.UpdateActivityResponse
+	21, // 27: lobby_service.LobbyService.GetPresence:output_type -> lobby_service.GetPresenceResponse
+	24, // 28: lobby_service.LobbyService.WatchPresence:output_type -> common.Presence
+	17, // [17:29] is the sub-list for method output_type
+	5,  // [5:17] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_proto_lobby_proto_init() }
@@ -580,7 +1552,7 @@ func file_proto_lobby_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_lobby_proto_rawDesc), len(file_proto_lobby_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,8 +25,8 @@ const (
 type FindMatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Rating        int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // 已废弃：用户名由服务端从大厅服务读取
+	Rating        int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`    // 已废弃：评分由服务端从排行榜服务读取
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -422,6 +422,158 @@ func (x *GetOnlinePlayersResponse) GetPlayers() []*PlayerInfo {
 	return nil
 }
 
+type WatchMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // 已废弃：用户名由服务端从大厅服务读取
+	Rating        int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`    // 已废弃：评分由服务端从排行榜服务读取
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMatchRequest) Reset() {
+	*x = WatchMatchRequest{}
+	mi := &file_proto_matching_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMatchRequest) ProtoMessage() {}
+
+func (x *WatchMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matching_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchMatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matching_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchMatchRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *WatchMatchRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *WatchMatchRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+type MatchEvent struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Type                 string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                                                // queued, match_found, match_cancelled
+	QueuePosition        int32                  `protobuf:"varint,2,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`                        // 在排队玩家中的位置，从 1 开始
+	EstimatedWaitSeconds int32                  `protobuf:"varint,3,opt,name=estimated_wait_seconds,json=estimatedWaitSeconds,proto3" json:"estimated_wait_seconds,omitempty"` // 预计剩余等待时间，-1 表示暂无估计
+	WaitingPlayers       int32                  `protobuf:"varint,4,opt,name=waiting_players,json=waitingPlayers,proto3" json:"waiting_players,omitempty"`
+	RoomId               string                 `protobuf:"bytes,5,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Players              []*PlayerInfo          `protobuf:"bytes,6,rep,name=players,proto3" json:"players,omitempty"`
+	Message              string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *MatchEvent) Reset() {
+	*x = MatchEvent{}
+	mi := &file_proto_matching_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchEvent) ProtoMessage() {}
+
+func (x *MatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matching_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchEvent.ProtoReflect.Descriptor instead.
+func (*MatchEvent) Descriptor() ([]byte, []int) {
+	return file_proto_matching_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MatchEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MatchEvent) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *MatchEvent) GetEstimatedWaitSeconds() int32 {
+	if x != nil {
+		return x.EstimatedWaitSeconds
+	}
+	return 0
+}
+
+func (x *MatchEvent) GetWaitingPlayers() int32 {
+	if x != nil {
+		return x.WaitingPlayers
+	}
+	return 0
+}
+
+func (x *MatchEvent) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *MatchEvent) GetPlayers() []*PlayerInfo {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *MatchEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_proto_matching_proto protoreflect.FileDescriptor
 
 const file_proto_matching_proto_rawDesc = "" +
@@ -448,12 +600,27 @@ const file_proto_matching_proto_rawDesc = "" +
 	"\x18GetOnlinePlayersResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
-	"\aplayers\x18\x03 \x03(\v2\x12.common.PlayerInfoR\aplayers2\x9c\x03\n" +
+	"\aplayers\x18\x03 \x03(\v2\x12.common.PlayerInfoR\aplayers\"d\n" +
+	"\x11WatchMatchRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
+	"\x06rating\x18\x03 \x01(\x05R\x06rating\"\x87\x02\n" +
+	"\n" +
+	"MatchEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12%\n" +
+	"\x0equeue_position\x18\x02 \x01(\x05R\rqueuePosition\x124\n" +
+	"\x16estimated_wait_seconds\x18\x03 \x01(\x05R\x14estimatedWaitSeconds\x12'\n" +
+	"\x0fwaiting_players\x18\x04 \x01(\x05R\x0ewaitingPlayers\x12\x17\n" +
+	"\aroom_id\x18\x05 \x01(\tR\x06roomId\x12,\n" +
+	"\aplayers\x18\x06 \x03(\v2\x12.common.PlayerInfoR\aplayers\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage2\xef\x03\n" +
 	"\x0fMatchingService\x12T\n" +
 	"\tFindMatch\x12\".matching_service.FindMatchRequest\x1a#.matching_service.FindMatchResponse\x12Z\n" +
 	"\vCancelMatch\x12$.matching_service.CancelMatchRequest\x1a%.matching_service.CancelMatchResponse\x12l\n" +
 	"\x11GetWaitingPlayers\x12*.matching_service.GetWaitingPlayersRequest\x1a+.matching_service.GetWaitingPlayersResponse\x12i\n" +
-	"\x10GetOnlinePlayers\x12).matching_service.GetOnlinePlayersRequest\x1a*.matching_service.GetOnlinePlayersResponseB\tZ\a./protob\x06proto3"
+	"\x10GetOnlinePlayers\x12).matching_service.GetOnlinePlayersRequest\x1a*.matching_service.GetOnlinePlayersResponse\x12Q\n" +
+	"\n" +
+	"WatchMatch\x12#.matching_service.WatchMatchRequest\x1a\x1c.matching_service.MatchEvent0\x01B\tZ\a./protob\x06proto3"
 
 var (
 	file_proto_matching_proto_rawDescOnce sync.Once
@@ -467,7 +634,7 @@ func file_proto_matching_proto_rawDescGZIP() []byte {
 	return file_proto_matching_proto_rawDescData
 }
 
-var file_proto_matching_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_matching_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_proto_matching_proto_goTypes = []any{
 	(*FindMatchRequest)(nil),          // 0: matching_service.FindMatchRequest
 	(*FindMatchResponse)(nil),         // 1: matching_service.FindMatchResponse
@@ -477,24 +644,29 @@ var file_proto_matching_proto_goTypes = []any{
 	(*GetWaitingPlayersResponse)(nil), // 5: matching_service.GetWaitingPlayersResponse
 	(*GetOnlinePlayersRequest)(nil),   // 6: matching_service.GetOnlinePlayersRequest
 	(*GetOnlinePlayersResponse)(nil),  // 7: matching_service.GetOnlinePlayersResponse
-	(*PlayerInfo)(nil),                // 8: common.PlayerInfo
+	(*WatchMatchRequest)(nil),         // 8: matching_service.WatchMatchRequest
+	(*MatchEvent)(nil),                // 9: matching_service.MatchEvent
+	(*PlayerInfo)(nil),                // 10: common.PlayerInfo
 }
 var file_proto_matching_proto_depIdxs = []int32{
-	8, // 0: matching_service.FindMatchResponse.players:type_name -> common.PlayerInfo
-	8, // 1: matching_service.GetOnlinePlayersResponse.players:type_name -> common.PlayerInfo
-	0, // 2: matching_service.MatchingService.FindMatch:input_type -> matching_service.FindMatchRequest
-	2, // 3: matching_service.MatchingService.CancelMatch:input_type -> matching_service.CancelMatchRequest
-	4, // 4: matching_service.MatchingService.GetWaitingPlayers:input_type -> matching_service.GetWaitingPlayersRequest
-	6, // 5: matching_service.MatchingService.GetOnlinePlayers:input_type -> matching_service.GetOnlinePlayersRequest
-	1, // 6: matching_service.MatchingService.FindMatch:output_type -> matching_service.FindMatchResponse
-	3, // 7: matching_service.MatchingService.CancelMatch:output_type -> matching_service.CancelMatchResponse
-	5, // 8: matching_service.MatchingService.GetWaitingPlayers:output_type -> matching_service.GetWaitingPlayersResponse
-	7, // 9: matching_service.MatchingService.GetOnlinePlayers:output_type -> matching_service.GetOnlinePlayersResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	10, // 0: matching_service.FindMatchResponse.players:type_name -> common.PlayerInfo
+	10, // 1: matching_service.GetOnlinePlayersResponse.players:type_name -> common.PlayerInfo
+	10, // 2: matching_service.MatchEvent.players:type_name -> common.PlayerInfo
+	0,  // 3: matching_service.MatchingService.FindMatch:input_type -> matching_service.FindMatchRequest
+	2,  // 4: matching_service.MatchingService.CancelMatch:input_type -> matching_service.CancelMatchRequest
+	4,  // 5: matching_service.MatchingService.GetWaitingPlayers:input_type -> matching_service.GetWaitingPlayersRequest
+	6,  // 6: matching_service.MatchingService.GetOnlinePlayers:input_type -> matching_service.GetOnlinePlayersRequest
+	8,  // 7: matching_service.MatchingService.WatchMatch:input_type -> matching_service.WatchMatchRequest
+	1,  // 8: matching_service.MatchingService.FindMatch:output_type -> matching_service.FindMatchResponse
+	3,  // 9: matching_service.MatchingService.CancelMatch:output_type -> matching_service.CancelMatchResponse
+	5,  // 10: matching_service.MatchingService.GetWaitingPlayers:output_type -> matching_service.GetWaitingPlayersResponse
+	7,  // 11: matching_service.MatchingService.GetOnlinePlayers:output_type -> matching_service.GetOnlinePlayersResponse
+	9,  // 12: matching_service.MatchingService.WatchMatch:output_type -> matching_service.MatchEvent
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_matching_proto_init() }
@@ -509,7 +681,7 @@ func file_proto_matching_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_matching_proto_rawDesc), len(file_proto_matching_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,7 @@ const (
 	MatchingService_CancelMatch_FullMethodName       = "/matching_service.MatchingService/CancelMatch"
 	MatchingService_GetWaitingPlayers_FullMethodName = "/matching_service.MatchingService/GetWaitingPlayers"
 	MatchingService_GetOnlinePlayers_FullMethodName  = "/matching_service.MatchingService/GetOnlinePlayers"
+	MatchingService_WatchMatch_FullMethodName        = "/matching_service.MatchingService/WatchMatch"
 )
 
 // MatchingServiceClient is the client API for MatchingService service.
@@ -39,6 +40,8 @@ type MatchingServiceClient interface {
 	GetWaitingPlayers(ctx context.Context, in *GetWaitingPlayersRequest, opts ...grpc.CallOption) (*GetWaitingPlayersResponse, error)
 	// 获取在线玩家
 	GetOnlinePlayers(ctx context.Context, in *GetOnlinePlayersRequest, opts ...grpc.CallOption) (*GetOnlinePlayersResponse, error)
+	// 加入匹配队列并订阅匹配进度，匹配成功或取消后流结束
+	WatchMatch(ctx context.Context, in *WatchMatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchEvent], error)
 }
 
 type matchingServiceClient struct {
@@ -89,6 +92,25 @@ func (c *matchingServiceClient) GetOnlinePlayers(ctx context.Context, in *GetOnl
 	return out, nil
 }
 
+func (c *matchingServiceClient) WatchMatch(ctx context.Context, in *WatchMatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchingService_ServiceDesc.Streams[0], MatchingService_WatchMatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMatchRequest, MatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchingService_WatchMatchClient = grpc.ServerStreamingClient[MatchEvent]
+
 // MatchingServiceServer is the server API for MatchingService service.
 // All implementations must embed UnimplementedMatchingServiceServer
 // for forward compatibility.
@@ -103,6 +125,8 @@ type MatchingServiceServer interface {
 	GetWaitingPlayers(context.Context, *GetWaitingPlayersRequest) (*GetWaitingPlayersResponse, error)
 	// 获取在线玩家
 	GetOnlinePlayers(context.Context, *GetOnlinePlayersRequest) (*GetOnlinePlayersResponse, error)
+	// 加入匹配队列并订阅匹配进度，匹配成功或取消后流结束
+	WatchMatch(*WatchMatchRequest, grpc.ServerStreamingServer[MatchEvent]) error
 	mustEmbedUnimplementedMatchingServiceServer()
 }
 
@@ -125,6 +149,9 @@ func (UnimplementedMatchingServiceServer) GetWaitingPlayers(context.Context, *Ge
 func (UnimplementedMatchingServiceServer) GetOnlinePlayers(context.Context, *GetOnlinePlayersRequest) (*GetOnlinePlayersResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetOnlinePlayers not implemented")
 }
+func (UnimplementedMatchingServiceServer) WatchMatch(*WatchMatchRequest, grpc.ServerStreamingServer[MatchEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchMatch not implemented")
+}
 func (UnimplementedMatchingServiceServer) mustEmbedUnimplementedMatchingServiceServer() {}
 func (UnimplementedMatchingServiceServer) testEmbeddedByValue()                         {}
 
@@ -218,6 +245,17 @@ func _MatchingService_GetOnlinePlayers_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchingService_WatchMatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchingServiceServer).WatchMatch(m, &grpc.GenericServerStream[WatchMatchRequest, MatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchingService_WatchMatchServer = grpc.ServerStreamingServer[MatchEvent]
+
 // MatchingService_ServiceDesc is the grpc.ServiceDesc for MatchingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -242,6 +280,12 @@ var MatchingService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _MatchingService_GetOnlinePlayers_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMatch",
+			Handler:       _MatchingService_WatchMatch_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "proto/matching.proto",
 }
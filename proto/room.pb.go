@@ -27,6 +27,7 @@ type CreateRoomRequest struct {
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	RoomName      string                 `protobuf:"bytes,2,opt,name=room_name,json=roomName,proto3" json:"room_name,omitempty"`
 	MaxPlayers    int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
+	Options       *GameOptions           `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,6 +83,13 @@ func (x *CreateRoomRequest) GetMaxPlayers() int32 {
 	return 0
 }
 
+func (x *CreateRoomRequest) GetOptions() *GameOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
 type CreateRoomResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -686,16 +694,465 @@ func (x *StartGameResponse) GetMessage() string {
 	return ""
 }
 
+type SubscribeRoomMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRoomMessagesRequest) Reset() {
+	*x = SubscribeRoomMessagesRequest{}
+	mi := &file_proto_room_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRoomMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRoomMessagesRequest) ProtoMessage() {}
+
+func (x *SubscribeRoomMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRoomMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRoomMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SubscribeRoomMessagesRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *SubscribeRoomMessagesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ReportGameResultRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RoomId         string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	WinnerPlayerId string                 `protobuf:"bytes,2,opt,name=winner_player_id,json=winnerPlayerId,proto3" json:"winner_player_id,omitempty"` // 平局为空
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReportGameResultRequest) Reset() {
+	*x = ReportGameResultRequest{}
+	mi := &file_proto_room_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportGameResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportGameResultRequest) ProtoMessage() {}
+
+func (x *ReportGameResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportGameResultRequest.ProtoReflect.Descriptor instead.
+func (*ReportGameResultRequest) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReportGameResultRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *ReportGameResultRequest) GetWinnerPlayerId() string {
+	if x != nil {
+		return x.WinnerPlayerId
+	}
+	return ""
+}
+
+type ReportGameResultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportGameResultResponse) Reset() {
+	*x = ReportGameResultResponse{}
+	mi := &file_proto_room_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportGameResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportGameResultResponse) ProtoMessage() {}
+
+func (x *ReportGameResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportGameResultResponse.ProtoReflect.Descriptor instead.
+func (*ReportGameResultResponse) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReportGameResultResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReportGameResultResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RematchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RematchRequest) Reset() {
+	*x = RematchRequest{}
+	mi := &file_proto_room_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RematchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RematchRequest) ProtoMessage() {}
+
+func (x *RematchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RematchRequest.ProtoReflect.Descriptor instead.
+func (*RematchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RematchRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RematchRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RematchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RematchResponse) Reset() {
+	*x = RematchResponse{}
+	mi := &file_proto_room_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RematchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RematchResponse) ProtoMessage() {}
+
+func (x *RematchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RematchResponse.ProtoReflect.Descriptor instead.
+func (*RematchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RematchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RematchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomRequest) Reset() {
+	*x = GetRoomRequest{}
+	mi := &file_proto_room_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomRequest) ProtoMessage() {}
+
+func (x *GetRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomRequest.ProtoReflect.Descriptor instead.
+func (*GetRoomRequest) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type RoomInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatorId     string                 `protobuf:"bytes,3,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	Players       []string               `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
+	MaxPlayers    int32                  `protobuf:"varint,5,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // waiting, playing, finished
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomInfo) Reset() {
+	*x = RoomInfo{}
+	mi := &file_proto_room_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomInfo) ProtoMessage() {}
+
+func (x *RoomInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomInfo.ProtoReflect.Descriptor instead.
+func (*RoomInfo) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RoomInfo) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetCreatorId() string {
+	if x != nil {
+		return x.CreatorId
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetPlayers() []string {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *RoomInfo) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Room          *RoomInfo              `protobuf:"bytes,3,opt,name=room,proto3" json:"room,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomResponse) Reset() {
+	*x = GetRoomResponse{}
+	mi := &file_proto_room_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomResponse) ProtoMessage() {}
+
+func (x *GetRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_room_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomResponse.ProtoReflect.Descriptor instead.
+func (*GetRoomResponse) Descriptor() ([]byte, []int) {
+	return file_proto_room_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetRoomResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetRoomResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetRoomResponse) GetRoom() *RoomInfo {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
 var File_proto_room_proto protoreflect.FileDescriptor
 
 const file_proto_room_proto_rawDesc = "" +
 	"\n" +
-	"\x10proto/room.proto\x12\froom_service\x1a\x12proto/common.proto\"j\n" +
+	"\x10proto/room.proto\x12\froom_service\x1a\x12proto/common.proto\"\x99\x01\n" +
 	"\x11CreateRoomRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
 	"\troom_name\x18\x02 \x01(\tR\broomName\x12\x1f\n" +
 	"\vmax_players\x18\x03 \x01(\x05R\n" +
-	"maxPlayers\"a\n" +
+	"maxPlayers\x12-\n" +
+	"\aoptions\x18\x04 \x01(\v2\x13.common.GameOptionsR\aoptions\"a\n" +
 	"\x12CreateRoomResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
@@ -732,7 +1189,37 @@ const file_proto_room_proto_rawDesc = "" +
 	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"G\n" +
 	"\x11StartGameResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xf9\x03\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"P\n" +
+	"\x1cSubscribeRoomMessagesRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\\\n" +
+	"\x17ReportGameResultRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12(\n" +
+	"\x10winner_player_id\x18\x02 \x01(\tR\x0ewinnerPlayerId\"N\n" +
+	"\x18ReportGameResultResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"B\n" +
+	"\x0eRematchRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"E\n" +
+	"\x0fRematchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\")\n" +
+	"\x0eGetRoomRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"\xa9\x01\n" +
+	"\bRoomInfo\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\x03 \x01(\tR\tcreatorId\x12\x18\n" +
+	"\aplayers\x18\x04 \x03(\tR\aplayers\x12\x1f\n" +
+	"\vmax_players\x18\x05 \x01(\x05R\n" +
+	"maxPlayers\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\"q\n" +
+	"\x0fGetRoomResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\x04room\x18\x03 \x01(\v2\x16.room_service.RoomInfoR\x04room2\xc4\x06\n" +
 	"\vRoomService\x12O\n" +
 	"\n" +
 	"CreateRoom\x12\x1f.room_service.CreateRoomRequest\x1a .room_service.CreateRoomResponse\x12I\n" +
@@ -740,7 +1227,11 @@ const file_proto_room_proto_rawDesc = "" +
 	"\tLeaveRoom\x12\x1e.room_service.LeaveRoomRequest\x1a\x1f.room_service.LeaveRoomResponse\x12R\n" +
 	"\vSendMessage\x12 .room_service.SendMessageRequest\x1a!.room_service.SendMessageResponse\x12^\n" +
 	"\x0fGetRoomMessages\x12$.room_service.GetRoomMessagesRequest\x1a%.room_service.GetRoomMessagesResponse\x12L\n" +
-	"\tStartGame\x12\x1e.room_service.StartGameRequest\x1a\x1f.room_service.StartGameResponseB\tZ\a./protob\x06proto3"
+	"\tStartGame\x12\x1e.room_service.StartGameRequest\x1a\x1f.room_service.StartGameResponse\x12V\n" +
+	"\x15SubscribeRoomMessages\x12*.room_service.SubscribeRoomMessagesRequest\x1a\x0f.common.Message0\x01\x12a\n" +
+	"\x10ReportGameResult\x12%.room_service.ReportGameResultRequest\x1a&.room_service.ReportGameResultResponse\x12F\n" +
+	"\aRematch\x12\x1c.room_service.RematchRequest\x1a\x1d.room_service.RematchResponse\x12F\n" +
+	"\aGetRoom\x12\x1c.room_service.GetRoomRequest\x1a\x1d.room_service.GetRoomResponseB\tZ\a./protob\x06proto3"
 
 var (
 	file_proto_room_proto_rawDescOnce sync.Once
@@ -754,41 +1245,60 @@ func file_proto_room_proto_rawDescGZIP() []byte {
 	return file_proto_room_proto_rawDescData
 }
 
-var file_proto_room_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_room_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_proto_room_proto_goTypes = []any{
-	(*CreateRoomRequest)(nil),       // 0: room_service.CreateRoomRequest
-	(*CreateRoomResponse)(nil),      // 1: room_service.CreateRoomResponse
-	(*JoinRoomRequest)(nil),         // 2: room_service.JoinRoomRequest
-	(*JoinRoomResponse)(nil),        // 3: room_service.JoinRoomResponse
-	(*LeaveRoomRequest)(nil),        // 4: room_service.LeaveRoomRequest
-	(*LeaveRoomResponse)(nil),       // 5: room_service.LeaveRoomResponse
-	(*SendMessageRequest)(nil),      // 6: room_service.SendMessageRequest
-	(*SendMessageResponse)(nil),     // 7: room_service.SendMessageResponse
-	(*GetRoomMessagesRequest)(nil),  // 8: room_service.GetRoomMessagesRequest
-	(*GetRoomMessagesResponse)(nil), // 9: room_service.GetRoomMessagesResponse
-	(*StartGameRequest)(nil),        // 10: room_service.StartGameRequest
-	(*StartGameResponse)(nil),       // 11: room_service.StartGameResponse
-	(*Message)(nil),                 // 12: common.Message
+	(*CreateRoomRequest)(nil),            // 0: room_service.CreateRoomRequest
+	(*CreateRoomResponse)(nil),           // 1: room_service.CreateRoomResponse
+	(*JoinRoomRequest)(nil),              // 2: room_service.JoinRoomRequest
+	(*JoinRoomResponse)(nil),             // 3: room_service.JoinRoomResponse
+	(*LeaveRoomRequest)(nil),             // 4: room_service.LeaveRoomRequest
+	(*LeaveRoomResponse)(nil),            // 5: room_service.LeaveRoomResponse
+	(*SendMessageRequest)(nil),           // 6: room_service.SendMessageRequest
+	(*SendMessageResponse)(nil),          // 7: room_service.SendMessageResponse
+	(*GetRoomMessagesRequest)(nil),       // 8: room_service.GetRoomMessagesRequest
+	(*GetRoomMessagesResponse)(nil),      // 9: room_service.GetRoomMessagesResponse
+	(*StartGameRequest)(nil),             // 10: room_service.StartGameRequest
+	(*StartGameResponse)(nil),            // 11: room_service.StartGameResponse
+	(*SubscribeRoomMessagesRequest)(nil), // 12: room_service.SubscribeRoomMessagesRequest
+	(*ReportGameResultRequest)(nil),      // 13: room_service.ReportGameResultRequest
+	(*ReportGameResultResponse)(nil),     // 14: room_service.ReportGameResultResponse
+	(*RematchRequest)(nil),               // 15: room_service.RematchRequest
+	(*RematchResponse)(nil),              // 16: room_service.RematchResponse
+	(*GetRoomRequest)(nil),               // 17: room_service.GetRoomRequest
+	(*RoomInfo)(nil),                     // 18: room_service.RoomInfo
+	(*GetRoomResponse)(nil),              // 19: room_service.GetRoomResponse
+	(*GameOptions)(nil),                  // 20: common.GameOptions
+	(*Message)(nil),                      // 21: common.Message
 }
 var file_proto_room_proto_depIdxs = []int32{
-	12, // 0: room_service.GetRoomMessagesResponse.messages:type_name -> common.Message
-	0,  // 1: room_service.RoomService.CreateRoom:input_type -> room_service.CreateRoomRequest
-	2,  // 2: room_service.RoomService.JoinRoom:input_type -> room_service.JoinRoomRequest
-	4,  // 3: room_service.RoomService.LeaveRoom:input_type -> room_service.LeaveRoomRequest
-	6,  // 4: room_service.RoomService.SendMessage:input_type -> room_service.SendMessageRequest
-	8,  // 5: room_service.RoomService.GetRoomMessages:input_type -> room_service.GetRoomMessagesRequest
-	10, // 6: room_service.RoomService.StartGame:input_type -> room_service.StartGameRequest
-	1,  // 7: room_service.RoomService.CreateRoom:output_type -> room_service.CreateRoomResponse
-	3,  // 8: room_service.RoomService.JoinRoom:output_type -> room_service.JoinRoomResponse
-	5,  // 9: room_service.RoomService.LeaveRoom:output_type -> room_service.LeaveRoomResponse
-	7,  // 10: room_service.RoomService.SendMessage:output_type -> room_service.SendMessageResponse
-	9,  // 11: room_service.RoomService.GetRoomMessages:output_type -> room_service.GetRoomMessagesResponse
-	11, // 12: room_service.RoomService.StartGame:output_type -> room_service.StartGameResponse
-	7,  // [7:13] is the sub-list for method output_type
-	1,  // [1:7] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	20, // 0: room_service.CreateRoomRequest.options:type_name -> common.GameOptions
+	21, // 1: room_service.GetRoomMessagesResponse.messages:type_name -> common.Message
+	18, // 2: room_service.GetRoomResponse.room:type_name -> room_service.RoomInfo
+	0,  // 3: room_service.RoomService.CreateRoom:input_type -> room_service.CreateRoomRequest
+	2,  // 4: room_service.RoomService.JoinRoom:input_type -> room_service.JoinRoomRequest
+	4,  // 5: room_service.RoomService.LeaveRoom:input_type -> room_service.LeaveRoomRequest
+	6,  // 6: room_service.RoomService.SendMessage:input_type -> room_service.SendMessageRequest
+	8,  // 7: room_service.RoomService.GetRoomMessages:input_type -> room_service.GetRoomMessagesRequest
+	10, // 8: room_service.RoomService.StartGame:input_type -> room_service.StartGameRequest
+	12, // 9: room_service.RoomService.SubscribeRoomMessages:input_type -> room_service.SubscribeRoomMessagesRequest
+	13, // 10: room_service.RoomService.ReportGameResult:input_type -> room_service.ReportGameResultRequest
+	15, // 11: room_service.RoomService.Rematch:input_type -> room_service.RematchRequest
+	17, // 12: room_service.RoomService.GetRoom:input_type -> room_service.GetRoomRequest
+	1,  // 13: room_service.RoomService.CreateRoom:output_type -> room_service.CreateRoomResponse
+	3,  // 14: room_service.RoomService.JoinRoom:output_type -> room_service.JoinRoomResponse
+	5,  // 15: room_service.RoomService.LeaveRoom:output_type -> room_service.LeaveRoomResponse
+	7,  // 16: room_service.RoomService.SendMessage:output_type -> room_service.SendMessageResponse
+	9,  // 17: room_service.RoomService.GetRoomMessages:output_type -> room_service.GetRoomMessagesResponse
+	11, // 18: room_service.RoomService.StartGame:output_type -> room_service.StartGameResponse
+	21, // 19: room_service.RoomService.SubscribeRoomMessages:output_type -> common.Message
+	14, // 20: room_service.RoomService.ReportGameResult:output_type -> room_service.ReportGameResultResponse
+	16, // 21: room_service.RoomService.Rematch:output_type -> room_service.RematchResponse
+	19, // 22: room_service.RoomService.GetRoom:output_type -> room_service.GetRoomResponse
+	13, // [13:23] is the sub-list for method output_type
+	3,  // [3:13] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_room_proto_init() }
@@ -803,7 +1313,7 @@ func file_proto_room_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_room_proto_rawDesc), len(file_proto_room_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RoomService_CreateRoom_FullMethodName      = "/room_service.RoomService/CreateRoom"
-	RoomService_JoinRoom_FullMethodName        = "/room_service.RoomService/JoinRoom"
-	RoomService_LeaveRoom_FullMethodName       = "/room_service.RoomService/LeaveRoom"
-	RoomService_SendMessage_FullMethodName     = "/room_service.RoomService/SendMessage"
-	RoomService_GetRoomMessages_FullMethodName = "/room_service.RoomService/GetRoomMessages"
-	RoomService_StartGame_FullMethodName       = "/room_service.RoomService/StartGame"
+	RoomService_CreateRoom_FullMethodName            = "/room_service.RoomService/CreateRoom"
+	RoomService_JoinRoom_FullMethodName              = "/room_service.RoomService/JoinRoom"
+	RoomService_LeaveRoom_FullMethodName             = "/room_service.RoomService/LeaveRoom"
+	RoomService_SendMessage_FullMethodName           = "/room_service.RoomService/SendMessage"
+	RoomService_GetRoomMessages_FullMethodName       = "/room_service.RoomService/GetRoomMessages"
+	RoomService_StartGame_FullMethodName             = "/room_service.RoomService/StartGame"
+	RoomService_SubscribeRoomMessages_FullMethodName = "/room_service.RoomService/SubscribeRoomMessages"
+	RoomService_ReportGameResult_FullMethodName      = "/room_service.RoomService/ReportGameResult"
+	RoomService_Rematch_FullMethodName               = "/room_service.RoomService/Rematch"
+	RoomService_GetRoom_FullMethodName               = "/room_service.RoomService/GetRoom"
 )
 
 // RoomServiceClient is the client API for RoomService service.
@@ -45,6 +49,14 @@ type RoomServiceClient interface {
 	GetRoomMessages(ctx context.Context, in *GetRoomMessagesRequest, opts ...grpc.CallOption) (*GetRoomMessagesResponse, error)
 	// 开始游戏
 	StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*StartGameResponse, error)
+	// 订阅房间消息
+	SubscribeRoomMessages(ctx context.Context, in *SubscribeRoomMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error)
+	// 游戏服务上报对局结果
+	ReportGameResult(ctx context.Context, in *ReportGameResultRequest, opts ...grpc.CallOption) (*ReportGameResultResponse, error)
+	// 对局结束后回到等待状态，准备再来一局
+	Rematch(ctx context.Context, in *RematchRequest, opts ...grpc.CallOption) (*RematchResponse, error)
+	// 获取房间信息
+	GetRoom(ctx context.Context, in *GetRoomRequest, opts ...grpc.CallOption) (*GetRoomResponse, error)
 }
 
 type roomServiceClient struct {
@@ -115,6 +127,55 @@ func (c *roomServiceClient) StartGame(ctx context.Context, in *StartGameRequest,
 	return out, nil
 }
 
+func (c *roomServiceClient) SubscribeRoomMessages(ctx context.Context, in *SubscribeRoomMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Message], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoomService_ServiceDesc.Streams[0], RoomService_SubscribeRoomMessages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRoomMessagesRequest, Message]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomService_SubscribeRoomMessagesClient = grpc.ServerStreamingClient[Message]
+
+func (c *roomServiceClient) ReportGameResult(ctx context.Context, in *ReportGameResultRequest, opts ...grpc.CallOption) (*ReportGameResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportGameResultResponse)
+	err := c.cc.Invoke(ctx, RoomService_ReportGameResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) Rematch(ctx context.Context, in *RematchRequest, opts ...grpc.CallOption) (*RematchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RematchResponse)
+	err := c.cc.Invoke(ctx, RoomService_Rematch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) GetRoom(ctx context.Context, in *GetRoomRequest, opts ...grpc.CallOption) (*GetRoomResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomResponse)
+	err := c.cc.Invoke(ctx, RoomService_GetRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoomServiceServer is the server API for RoomService service.
 // All implementations must embed UnimplementedRoomServiceServer
 // for forward compatibility.
@@ -133,6 +194,14 @@ type RoomServiceServer interface {
 	GetRoomMessages(context.Context, *GetRoomMessagesRequest) (*GetRoomMessagesResponse, error)
 	// 开始游戏
 	StartGame(context.Context, *StartGameRequest) (*StartGameResponse, error)
+	// 订阅房间消息
+	SubscribeRoomMessages(*SubscribeRoomMessagesRequest, grpc.ServerStreamingServer[Message]) error
+	// 游戏服务上报对局结果
+	ReportGameResult(context.Context, *ReportGameResultRequest) (*ReportGameResultResponse, error)
+	// 对局结束后回到等待状态，准备再来一局
+	Rematch(context.Context, *RematchRequest) (*RematchResponse, error)
+	// 获取房间信息
+	GetRoom(context.Context, *GetRoomRequest) (*GetRoomResponse, error)
 	mustEmbedUnimplementedRoomServiceServer()
 }
 
@@ -161,6 +230,18 @@ func (UnimplementedRoomServiceServer) GetRoomMessages(context.Context, *GetRoomM
 func (UnimplementedRoomServiceServer) StartGame(context.Context, *StartGameRequest) (*StartGameResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method StartGame not implemented")
 }
+func (UnimplementedRoomServiceServer) SubscribeRoomMessages(*SubscribeRoomMessagesRequest, grpc.ServerStreamingServer[Message]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeRoomMessages not implemented")
+}
+func (UnimplementedRoomServiceServer) ReportGameResult(context.Context, *ReportGameResultRequest) (*ReportGameResultResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportGameResult not implemented")
+}
+func (UnimplementedRoomServiceServer) Rematch(context.Context, *RematchRequest) (*RematchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Rematch not implemented")
+}
+func (UnimplementedRoomServiceServer) GetRoom(context.Context, *GetRoomRequest) (*GetRoomResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRoom not implemented")
+}
 func (UnimplementedRoomServiceServer) mustEmbedUnimplementedRoomServiceServer() {}
 func (UnimplementedRoomServiceServer) testEmbeddedByValue()                     {}
 
@@ -290,6 +371,71 @@ func _RoomService_StartGame_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RoomService_SubscribeRoomMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRoomMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoomServiceServer).SubscribeRoomMessages(m, &grpc.GenericServerStream[SubscribeRoomMessagesRequest, Message]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomService_SubscribeRoomMessagesServer = grpc.ServerStreamingServer[Message]
+
+func _RoomService_ReportGameResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportGameResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).ReportGameResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_ReportGameResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).ReportGameResult(ctx, req.(*ReportGameResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_Rematch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RematchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).Rematch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_Rematch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).Rematch(ctx, req.(*RematchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_GetRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).GetRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_GetRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).GetRoom(ctx, req.(*GetRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RoomService_ServiceDesc is the grpc.ServiceDesc for RoomService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -321,7 +467,25 @@ var RoomService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StartGame",
 			Handler:    _RoomService_StartGame_Handler,
 		},
+		{
+			MethodName: "ReportGameResult",
+			Handler:    _RoomService_ReportGameResult_Handler,
+		},
+		{
+			MethodName: "Rematch",
+			Handler:    _RoomService_Rematch_Handler,
+		},
+		{
+			MethodName: "GetRoom",
+			Handler:    _RoomService_GetRoom_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeRoomMessages",
+			Handler:       _RoomService_SubscribeRoomMessages_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/room.proto",
 }
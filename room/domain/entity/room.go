@@ -12,11 +12,24 @@ type Room struct {
 	Players     []string   `json:"players"`  // 玩家ID列表
 	MaxPlayers  int        `json:"max_players"`
 	Status      string     `json:"status"`   // waiting, playing, finished
+	Options     GameOptions `json:"options"` // 开始游戏时交给游戏服务
 	CreatedAt   time.Time  `json:"created_at"`
 	Messages    []*Message `json:"messages"`
 	mutex       sync.RWMutex
 }
 
+// GameOptions 房间的游戏配置，零值字段由游戏服务使用默认值
+type GameOptions struct {
+	BoardWidth       int32  `json:"board_width"`
+	BoardHeight      int32  `json:"board_height"`
+	FoodCount        int32  `json:"food_count"`
+	TickIntervalMs   int32  `json:"tick_interval_ms"`
+	WallMode         string `json:"wall_mode"` // solid, wrap
+	WallEnabled      bool   `json:"wall_enabled"`
+	MaxScore         int32  `json:"max_score"`
+	TimeLimitSeconds int32  `json:"time_limit_seconds"`
+}
+
 type Message struct {
 	ID           string    `json:"id"`
 	RoomID       string    `json:"room_id"`
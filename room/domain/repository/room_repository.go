@@ -2,14 +2,26 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"snake-game/room/domain/entity"
 )
 
+// AddPlayer 无法加入房间的原因
+var (
+	ErrRoomNotFound    = errors.New("room not found")
+	ErrRoomNotJoinable = errors.New("cannot join room that is not in waiting state")
+	ErrRoomFull        = errors.New("room is full")
+	ErrPlayerInRoom    = errors.New("player already in room")
+)
+
 type RoomRepository interface {
 	CreateRoom(ctx context.Context, room *entity.Room) error
 	GetRoom(ctx context.Context, roomID string) (*entity.Room, error)
 	UpdateRoom(ctx context.Context, room *entity.Room) error
 	DeleteRoom(ctx context.Context, roomID string) error
+	// UpdateStatus 仅当房间处于 from 状态时切换到 to，返回是否切换成功
+	UpdateStatus(ctx context.Context, roomID, from, to string) (bool, error)
+	// AddPlayer 在同一次加锁中检查房间状态、容量并加入玩家，不满足条件时返回上面的错误
 	AddPlayer(ctx context.Context, roomID, playerID string) error
 	RemovePlayer(ctx context.Context, roomID, playerID string) error
 	AddMessage(ctx context.Context, roomID string, message *entity.Message) error
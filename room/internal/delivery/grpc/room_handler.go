@@ -3,6 +3,11 @@ package grpc
 import (
 	"context"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"snake-game/auth"
+	"snake-game/room/domain/entity"
 	"snake-game/room/internal/usecase"
 	pb "snake-game/proto"
 )
@@ -20,7 +25,21 @@ func NewRoomHandler(usecase *usecase.RoomUsecase) *RoomHandler {
 
 // CreateRoom 创建房间
 func (h *RoomHandler) CreateRoom(ctx context.Context, req *pb.CreateRoomRequest) (*pb.CreateRoomResponse, error) {
-	roomID, err := h.usecase.CreateRoom(ctx, req.UserId, req.RoomName, req.MaxPlayers)
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	options := entity.GameOptions{
+		BoardWidth:       req.Options.GetBoardWidth(),
+		BoardHeight:      req.Options.GetBoardHeight(),
+		FoodCount:        req.Options.GetFoodCount(),
+		TickIntervalMs:   req.Options.GetTickIntervalMs(),
+		WallMode:         req.Options.GetWallMode(),
+		WallEnabled:      req.Options.GetWallEnabled(),
+		MaxScore:         req.Options.GetMaxScore(),
+		TimeLimitSeconds: req.Options.GetTimeLimitSeconds(),
+	}
+	roomID, err := h.usecase.CreateRoom(ctx, req.UserId, req.RoomName, req.MaxPlayers, options)
 	if err != nil {
 		return &pb.CreateRoomResponse{
 			Success: false,
@@ -37,6 +56,10 @@ func (h *RoomHandler) CreateRoom(ctx context.Context, req *pb.CreateRoomRequest)
 
 // JoinRoom 加入房间
 func (h *RoomHandler) JoinRoom(ctx context.Context, req *pb.JoinRoomRequest) (*pb.JoinRoomResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.JoinRoom(ctx, req.RoomId, req.UserId)
 	if err != nil {
 		return &pb.JoinRoomResponse{
@@ -53,6 +76,10 @@ func (h *RoomHandler) JoinRoom(ctx context.Context, req *pb.JoinRoomRequest) (*p
 
 // LeaveRoom 离开房间
 func (h *RoomHandler) LeaveRoom(ctx context.Context, req *pb.LeaveRoomRequest) (*pb.LeaveRoomResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.LeaveRoom(ctx, req.RoomId, req.UserId)
 	if err != nil {
 		return &pb.LeaveRoomResponse{
@@ -69,6 +96,10 @@ func (h *RoomHandler) LeaveRoom(ctx context.Context, req *pb.LeaveRoomRequest) (
 
 // SendMessage 发送消息
 func (h *RoomHandler) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.SendMessageResponse, error) {
+	if err := auth.Authorize(ctx, req.SenderId); err != nil {
+		return nil, err
+	}
+
 	err := h.usecase.SendMessage(ctx, req.RoomId, req.SenderId, req.SenderId, req.Content, req.Type)
 	if err != nil {
 		return &pb.SendMessageResponse{
@@ -85,7 +116,12 @@ func (h *RoomHandler) SendMessage(ctx context.Context, req *pb.SendMessageReques
 
 // GetRoomMessages 获取房间消息
 func (h *RoomHandler) GetRoomMessages(ctx context.Context, req *pb.GetRoomMessagesRequest) (*pb.GetRoomMessagesResponse, error) {
-	messages, err := h.usecase.GetRoomMessages(ctx, req.RoomId, req.Limit)
+	viewerID, err := auth.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := h.usecase.GetRoomMessages(ctx, req.RoomId, viewerID, req.Limit)
 	if err != nil {
 		return &pb.GetRoomMessagesResponse{
 			Success: false,
@@ -96,15 +132,7 @@ func (h *RoomHandler) GetRoomMessages(ctx context.Context, req *pb.GetRoomMessag
 	// 转换消息格式
 	pbMessages := make([]*pb.Message, len(messages))
 	for i, msg := range messages {
-		pbMessages[i] = &pb.Message{
-			Id:             msg.ID,
-			RoomId:         msg.RoomID,
-			SenderId:       msg.SenderID,
-			SenderUsername: msg.SenderName,
-			Content:        msg.Content,
-			Type:           msg.Type,
-			CreatedAt:      msg.CreatedAt.Unix(),
-		}
+		pbMessages[i] = toPbMessage(msg)
 	}
 
 	return &pb.GetRoomMessagesResponse{
@@ -116,7 +144,12 @@ func (h *RoomHandler) GetRoomMessages(ctx context.Context, req *pb.GetRoomMessag
 
 // StartGame 开始游戏
 func (h *RoomHandler) StartGame(ctx context.Context, req *pb.StartGameRequest) (*pb.StartGameResponse, error) {
-	err := h.usecase.StartGame(ctx, req.RoomId)
+	callerID, err := auth.CallerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.usecase.StartGame(ctx, req.RoomId, callerID)
 	if err != nil {
 		return &pb.StartGameResponse{
 			Success: false,
@@ -128,4 +161,114 @@ func (h *RoomHandler) StartGame(ctx context.Context, req *pb.StartGameRequest) (
 		Success: true,
 		Message: "Game started successfully",
 	}, nil
+}
+
+// ReportGameResult 接收游戏服务上报的对局结果
+func (h *RoomHandler) ReportGameResult(ctx context.Context, req *pb.ReportGameResultRequest) (*pb.ReportGameResultResponse, error) {
+	if err := auth.RequireInternal(ctx); err != nil {
+		return nil, err
+	}
+
+	err := h.usecase.ReportGameResult(ctx, req.RoomId, req.WinnerPlayerId)
+	if err != nil {
+		return &pb.ReportGameResultResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ReportGameResultResponse{
+		Success: true,
+		Message: "Game result recorded",
+	}, nil
+}
+
+// Rematch 再来一局
+func (h *RoomHandler) Rematch(ctx context.Context, req *pb.RematchRequest) (*pb.RematchResponse, error) {
+	if err := auth.Authorize(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	err := h.usecase.Rematch(ctx, req.RoomId, req.UserId)
+	if err != nil {
+		return &pb.RematchResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.RematchResponse{
+		Success: true,
+		Message: "Room is ready for a rematch",
+	}, nil
+}
+
+// GetRoom 获取房间信息
+func (h *RoomHandler) GetRoom(ctx context.Context, req *pb.GetRoomRequest) (*pb.GetRoomResponse, error) {
+	room, err := h.usecase.GetRoom(ctx, req.RoomId)
+	if err != nil {
+		return &pb.GetRoomResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetRoomResponse{
+		Success: true,
+		Message: "Room retrieved successfully",
+		Room: &pb.RoomInfo{
+			RoomId:     room.ID,
+			Name:       room.Name,
+			CreatorId:  room.CreatorID,
+			Players:    append([]string(nil), room.Players...),
+			MaxPlayers: int32(room.MaxPlayers),
+			Status:     room.Status,
+		},
+	}, nil
+}
+
+// SubscribeRoomMessages 订阅房间消息
+func (h *RoomHandler) SubscribeRoomMessages(req *pb.SubscribeRoomMessagesRequest, stream pb.RoomService_SubscribeRoomMessagesServer) error {
+	viewerID, err := auth.CallerID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	messages, cancel, err := h.usecase.SubscribeRoomMessages(stream.Context(), req.RoomId)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				// 房间已删除或订阅者落后过多
+				return nil
+			}
+			// 屏蔽了订阅者的用户发送的消息不推送给订阅者
+			if !h.usecase.VisibleTo(stream.Context(), msg, viewerID) {
+				continue
+			}
+			if err := stream.Send(toPbMessage(msg)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toPbMessage 转换消息实体
+func toPbMessage(msg *entity.Message) *pb.Message {
+	return &pb.Message{
+		Id:             msg.ID,
+		RoomId:         msg.RoomID,
+		SenderId:       msg.SenderID,
+		SenderUsername: msg.SenderName,
+		Content:        msg.Content,
+		Type:           msg.Type,
+		CreatedAt:      msg.CreatedAt.Unix(),
+	}
 }
\ No newline at end of file
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"snake-game/room/domain/entity"
+	"snake-game/room/domain/repository"
 )
 
 type roomMemoryRepository struct {
@@ -54,25 +55,42 @@ func (r *roomMemoryRepository) DeleteRoom(ctx context.Context, roomID string) er
 	return nil
 }
 
+func (r *roomMemoryRepository) UpdateStatus(ctx context.Context, roomID, from, to string) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	
+	room, exists := r.rooms[roomID]
+	if !exists || room.Status != from {
+		return false, nil
+	}
+	
+	room.Status = to
+	return true, nil
+}
+
 func (r *roomMemoryRepository) AddPlayer(ctx context.Context, roomID, playerID string) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 	
 	room, exists := r.rooms[roomID]
 	if !exists {
-		return nil
+		return repository.ErrRoomNotFound
+	}
+	
+	if room.Status != "waiting" {
+		return repository.ErrRoomNotJoinable
 	}
 	
 	// 检查玩家是否已经在房间中
 	for _, pid := range room.Players {
 		if pid == playerID {
-			return nil // 玩家已在房间中
+			return repository.ErrPlayerInRoom
 		}
 	}
 	
 	// 检查房间是否已满
 	if len(room.Players) >= room.MaxPlayers {
-		return nil // 房间已满
+		return repository.ErrRoomFull
 	}
 	
 	room.Players = append(room.Players, playerID)
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	pb "snake-game/proto"
+	"snake-game/room/domain/entity"
+)
+
+const (
+	// blockCacheTTL 屏蔽关系的缓存时间，新的屏蔽最多延迟该时间生效
+	blockCacheTTL = 30 * time.Second
+	// maxBlockCacheEntries 缓存条目上限，超过时清理过期条目
+	maxBlockCacheEntries = 10000
+)
+
+type blockCacheEntry struct {
+	blockers  map[string]bool // 屏蔽了该用户的人
+	fetchedAt time.Time
+}
+
+// blockCache 按用户缓存屏蔽了他的人，避免每条消息都查询好友服务
+type blockCache struct {
+	entries map[string]*blockCacheEntry
+	mutex   sync.Mutex
+}
+
+func newBlockCache() *blockCache {
+	return &blockCache{
+		entries: make(map[string]*blockCacheEntry),
+	}
+}
+
+// VisibleTo 消息对 viewerID 是否可见。屏蔽者发送的消息对被屏蔽的用户隐藏，
+// 系统消息和服务自身的调用（viewerID 为空）不受影响
+func (uc *RoomUsecase) VisibleTo(ctx context.Context, message *entity.Message, viewerID string) bool {
+	if viewerID == "" || message.Type == "system" {
+		return true
+	}
+	return !uc.blockersOf(ctx, viewerID)[message.SenderID]
+}
+
+// blockersOf 返回屏蔽了 userID 的用户。查询失败时沿用旧结果，没有旧结果时不做过滤
+func (uc *RoomUsecase) blockersOf(ctx context.Context, userID string) map[string]bool {
+	cache := uc.blocks
+	now := time.Now()
+
+	cache.mutex.Lock()
+	entry, ok := cache.entries[userID]
+	cache.mutex.Unlock()
+	if ok && now.Sub(entry.fetchedAt) < blockCacheTTL {
+		return entry.blockers
+	}
+
+	resp, err := uc.friendsClient.GetBlockRelations(ctx, &pb.GetBlockRelationsRequest{UserId: userID})
+	if err == nil && !resp.Success {
+		err = errors.New(resp.Message)
+	}
+	blockers := make(map[string]bool)
+	if err != nil {
+		log.Printf("Failed to get block relations of %s: %v", userID, err)
+		if ok {
+			blockers = entry.blockers
+		}
+	} else {
+		for _, blockerID := range resp.BlockedByUserIds {
+			blockers[blockerID] = true
+		}
+	}
+
+	// 失败的结果也缓存，好友服务不可用时不会被每条消息重复请求
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if len(cache.entries) >= maxBlockCacheEntries {
+		for id, e := range cache.entries {
+			if now.Sub(e.fetchedAt) >= blockCacheTTL {
+				delete(cache.entries, id)
+			}
+		}
+	}
+	cache.entries[userID] = &blockCacheEntry{blockers: blockers, fetchedAt: now}
+	return blockers
+}
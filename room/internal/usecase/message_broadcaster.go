@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"sync"
+
+	"snake-game/room/domain/entity"
+)
+
+// 每个订阅者的消息缓冲区大小
+const subscriberBufferSize = 64
+
+// messageSubscriber 房间消息的订阅者
+type messageSubscriber struct {
+	messages chan *entity.Message
+}
+
+// messageBroadcaster 按房间向订阅者扇出聊天消息，发布不会被慢消费者阻塞
+type messageBroadcaster struct {
+	rooms map[string]map[*messageSubscriber]struct{}
+	mutex sync.Mutex
+}
+
+func newMessageBroadcaster() *messageBroadcaster {
+	return &messageBroadcaster{
+		rooms: make(map[string]map[*messageSubscriber]struct{}),
+	}
+}
+
+// subscribe 注册房间订阅者
+func (b *messageBroadcaster) subscribe(roomID string) *messageSubscriber {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	sub := &messageSubscriber{
+		messages: make(chan *entity.Message, subscriberBufferSize),
+	}
+	if b.rooms[roomID] == nil {
+		b.rooms[roomID] = make(map[*messageSubscriber]struct{})
+	}
+	b.rooms[roomID][sub] = struct{}{}
+	return sub
+}
+
+// unsubscribe 移除订阅者并关闭其通道，可重复调用
+func (b *messageBroadcaster) unsubscribe(roomID string, sub *messageSubscriber) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	subs, exists := b.rooms[roomID]
+	if !exists {
+		return
+	}
+	if _, exists := subs[sub]; !exists {
+		return
+	}
+	delete(subs, sub)
+	close(sub.messages)
+	if len(subs) == 0 {
+		delete(b.rooms, roomID)
+	}
+}
+
+// publish 投递消息；订阅者缓冲区已满说明其已严重落后，直接断开让客户端重新订阅
+func (b *messageBroadcaster) publish(roomID string, message *entity.Message) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	subs := b.rooms[roomID]
+	for sub := range subs {
+		select {
+		case sub.messages <- message:
+		default:
+			delete(subs, sub)
+			close(sub.messages)
+		}
+	}
+	if len(subs) == 0 {
+		delete(b.rooms, roomID)
+	}
+}
+
+// closeRoom 关闭房间的所有订阅（房间被删除时调用）
+func (b *messageBroadcaster) closeRoom(roomID string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for sub := range b.rooms[roomID] {
+		close(sub.messages)
+	}
+	delete(b.rooms, roomID)
+}
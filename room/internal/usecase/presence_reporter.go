@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	pb "snake-game/proto"
+)
+
+// 用户活动，与大厅服务的在线状态一致
+const (
+	activityInRoom = "in_room"
+	activityInGame = "in_game"
+)
+
+const (
+	// activityReportTimeout 上报单个用户活动的超时
+	activityReportTimeout = 3 * time.Second
+	// activityQueueSize 待上报活动的队列长度，队列满时丢弃新的上报
+	activityQueueSize = 1024
+)
+
+// activityReport 一条待上报的用户活动
+type activityReport struct {
+	userID         string
+	activity       string
+	roomID         string
+	expectedRoomID string
+}
+
+// reportActivity 向大厅服务上报玩家的活动，不阻塞房间操作，失败只记录日志。
+// expectedRoomID 非空时只有玩家当前仍在该房间才更新，避免覆盖玩家进入其他房间后的状态
+func (uc *RoomUsecase) reportActivity(userIDs []string, activity, roomID, expectedRoomID string) {
+	for _, userID := range userIDs {
+		select {
+		case uc.activities <- activityReport{userID, activity, roomID, expectedRoomID}:
+		default:
+			log.Printf("Activity queue is full, dropping report of %s", userID)
+		}
+	}
+}
+
+// runActivityReporter 按顺序逐条上报，保证同一玩家先加入后离开的活动不会乱序
+func (uc *RoomUsecase) runActivityReporter() {
+	for report := range uc.activities {
+		ctx, cancel := context.WithTimeout(context.Background(), activityReportTimeout)
+		resp, err := uc.lobbyClient.UpdateActivity(ctx, &pb.UpdateActivityRequest{
+			UserId:         report.userID,
+			Activity:       report.activity,
+			RoomId:         report.roomID,
+			ExpectedRoomId: report.expectedRoomID,
+		})
+		cancel()
+		if err == nil && !resp.Success {
+			err = errors.New(resp.Message)
+		}
+		if err != nil {
+			log.Printf("Failed to report activity of %s: %v", report.userID, err)
+		}
+	}
+}
@@ -10,32 +10,65 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"snake-game/auth"
 	"snake-game/room/domain/entity"
 	"snake-game/room/domain/repository"
 	pb "snake-game/proto"
 )
 
+// defaultMessageLimit 未指定条数时返回的历史消息数
+const defaultMessageLimit = 50
+
 type RoomUsecase struct {
 	roomRepo repository.RoomRepository
 	gameClient pb.GameServiceClient
+	friendsClient pb.FriendsServiceClient
+	lobbyClient pb.LobbyServiceClient
+	broadcaster *messageBroadcaster // 房间消息的实时推送
+	blocks *blockCache // 聊天消息按屏蔽关系过滤
+	activities chan activityReport // 待上报给大厅服务的玩家活动
 }
 
 func NewRoomUsecase(roomRepo repository.RoomRepository) *RoomUsecase {
 	// 连接到游戏服务
-	conn, err := grpc.Dial("localhost:50055", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial("localhost:50055", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
 	if err != nil {
 		return nil
 	}
 
 	gameClient := pb.NewGameServiceClient(conn)
 
-	return &RoomUsecase{
+	// 连接到好友服务，查询聊天的屏蔽关系
+	friendsConn, err := grpc.Dial("localhost:50056", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
+	if err != nil {
+		return nil
+	}
+
+	// 连接到大厅服务，上报玩家所在的房间和对局
+	lobbyConn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()), auth.WithInternalCredentials())
+	if err != nil {
+		return nil
+	}
+
+	uc := &RoomUsecase{
 		roomRepo: roomRepo,
 		gameClient: gameClient,
+		friendsClient: pb.NewFriendsServiceClient(friendsConn),
+		lobbyClient: pb.NewLobbyServiceClient(lobbyConn),
+		broadcaster: newMessageBroadcaster(),
+		blocks: newBlockCache(),
+		activities: make(chan activityReport, activityQueueSize),
 	}
+	go uc.runActivityReporter()
+	return uc
 }
 
-func (uc *RoomUsecase) CreateRoom(ctx context.Context, userID, roomName string, maxPlayers int32) (string, error) {
+func (uc *RoomUsecase) CreateRoom(ctx context.Context, userID, roomName string, maxPlayers int32, options entity.GameOptions) (string, error) {
+	// 开始游戏时游戏服务会再次校验
+	if err := validateGameOptions(options); err != nil {
+		return "", err
+	}
+
 	// 生成房间ID
 	roomID := fmt.Sprintf("room_%d_%s", time.Now().Unix(), generateRandomString(6))
 
@@ -46,6 +79,7 @@ func (uc *RoomUsecase) CreateRoom(ctx context.Context, userID, roomName string,
 		Players:    []string{userID},
 		MaxPlayers: int(maxPlayers),
 		Status:     "waiting",
+		Options:    options,
 		CreatedAt:  time.Now(),
 		Messages:   []*entity.Message{},
 	}
@@ -65,37 +99,16 @@ func (uc *RoomUsecase) CreateRoom(ctx context.Context, userID, roomName string,
 		Type:       "system",
 		CreatedAt:  time.Now(),
 	}
-	uc.roomRepo.AddMessage(ctx, roomID, systemMsg)
+	uc.addMessage(ctx, roomID, systemMsg)
+	uc.reportActivity([]string{userID}, activityInRoom, roomID, "")
 
 	return roomID, nil
 }
 
 func (uc *RoomUsecase) JoinRoom(ctx context.Context, roomID, userID string) error {
-	room, err := uc.roomRepo.GetRoom(ctx, roomID)
-	if err != nil || room == nil {
-		return errors.New("room not found")
-	}
-
-	if room.Status != "waiting" {
-		return errors.New("cannot join room that is not in waiting state")
-	}
-
-	// 检查房间是否已满
-	if len(room.Players) >= room.MaxPlayers {
-		return errors.New("room is full")
-	}
-
-	// 检查玩家是否已在房间中
-	for _, pid := range room.Players {
-		if pid == userID {
-			return errors.New("player already in room")
-		}
-	}
-
-	// 添加玩家到房间
-	err = uc.roomRepo.AddPlayer(ctx, roomID, userID)
-	if err != nil {
-		return errors.New("failed to add player to room")
+	// 房间状态和容量由仓库在加入时一并检查，并发加入不会超出人数
+	if err := uc.roomRepo.AddPlayer(ctx, roomID, userID); err != nil {
+		return err
 	}
 
 	// 发送系统消息
@@ -108,7 +121,8 @@ func (uc *RoomUsecase) JoinRoom(ctx context.Context, roomID, userID string) erro
 		Type:       "system",
 		CreatedAt:  time.Now(),
 	}
-	uc.roomRepo.AddMessage(ctx, roomID, systemMsg)
+	uc.addMessage(ctx, roomID, systemMsg)
+	uc.reportActivity([]string{userID}, activityInRoom, roomID, "")
 
 	return nil
 }
@@ -135,7 +149,8 @@ func (uc *RoomUsecase) LeaveRoom(ctx context.Context, roomID, userID string) err
 		Type:       "system",
 		CreatedAt:  time.Now(),
 	}
-	uc.roomRepo.AddMessage(ctx, roomID, systemMsg)
+	uc.addMessage(ctx, roomID, systemMsg)
+	uc.reportActivity([]string{userID}, "", "", roomID)
 
 	// 如果房主离开且房间还有其他玩家，指定新的房主或解散房间
 	if room.CreatorID == userID {
@@ -144,11 +159,11 @@ func (uc *RoomUsecase) LeaveRoom(ctx context.Context, roomID, userID string) err
 			room.CreatorID = room.Players[0]
 		} else {
 			// 没有玩家了，删除房间
-			uc.roomRepo.DeleteRoom(ctx, roomID)
+			uc.deleteRoom(ctx, roomID)
 		}
 	} else if len(room.Players) == 0 {
 		// 如果房间里没有玩家了，删除房间
-		uc.roomRepo.DeleteRoom(ctx, roomID)
+		uc.deleteRoom(ctx, roomID)
 	} else {
 		uc.roomRepo.UpdateRoom(ctx, room)
 	}
@@ -172,7 +187,7 @@ func (uc *RoomUsecase) SendMessage(ctx context.Context, roomID, senderID, sender
 		CreatedAt:  time.Now(),
 	}
 
-	err = uc.roomRepo.AddMessage(ctx, roomID, message)
+	err = uc.addMessage(ctx, roomID, message)
 	if err != nil {
 		return errors.New("failed to send message")
 	}
@@ -180,21 +195,38 @@ func (uc *RoomUsecase) SendMessage(ctx context.Context, roomID, senderID, sender
 	return nil
 }
 
-func (uc *RoomUsecase) GetRoomMessages(ctx context.Context, roomID string, limit int32) ([]*entity.Message, error) {
+// GetRoomMessages 获取房间的历史消息，已过滤掉对 viewerID 隐藏的消息
+func (uc *RoomUsecase) GetRoomMessages(ctx context.Context, roomID, viewerID string, limit int32) ([]*entity.Message, error) {
+	if limit <= 0 {
+		limit = defaultMessageLimit
+	}
+
 	messages, err := uc.roomRepo.GetMessages(ctx, roomID, limit)
 	if err != nil {
 		return nil, errors.New("failed to get messages")
 	}
 
-	return messages, nil
+	visible := make([]*entity.Message, 0, len(messages))
+	for _, message := range messages {
+		if uc.VisibleTo(ctx, message, viewerID) {
+			visible = append(visible, message)
+		}
+	}
+	return visible, nil
 }
 
-func (uc *RoomUsecase) StartGame(ctx context.Context, roomID string) error {
+// StartGame 在游戏服务中创建包含全部房间玩家的对局，任何一步失败都会回到等待状态
+func (uc *RoomUsecase) StartGame(ctx context.Context, roomID, userID string) error {
 	room, err := uc.roomRepo.GetRoom(ctx, roomID)
 	if err != nil || room == nil {
 		return errors.New("room not found")
 	}
 
+	// 只有房主可以开始游戏，userID 为空表示服务内部发起
+	if userID != "" && room.CreatorID != userID {
+		return errors.New("only the room creator can start the game")
+	}
+
 	if room.Status != "waiting" {
 		return errors.New("game already started or finished")
 	}
@@ -203,19 +235,199 @@ func (uc *RoomUsecase) StartGame(ctx context.Context, roomID string) error {
 		return errors.New("not enough players to start game")
 	}
 
-	// 更新房间状态
-	room.Status = "playing"
-	err = uc.roomRepo.UpdateRoom(ctx, room)
+	// 先切换状态，防止并发的开始请求重复创建游戏
+	switched, err := uc.roomRepo.UpdateStatus(ctx, roomID, "waiting", "playing")
 	if err != nil {
 		return errors.New("failed to update room status")
 	}
+	if !switched {
+		return errors.New("game already started or finished")
+	}
 
-	// 通知游戏服务开始游戏
-	// 注意：这里我们只是更新房间状态，实际的游戏逻辑由游戏服务处理
+	// 按房间配置和玩家名单在游戏服务中创建游戏
+	resp, err := uc.gameClient.CreateGame(ctx, &pb.CreateGameRequest{
+		RoomId:    roomID,
+		Options:   toPbGameOptions(room.Options),
+		PlayerIds: append([]string(nil), room.Players...),
+	})
+	if err != nil || !resp.Success {
+		// 回滚到等待状态
+		uc.roomRepo.UpdateStatus(ctx, roomID, "playing", "waiting")
+		if err != nil {
+			return errors.New("failed to create game")
+		}
+		return errors.New(resp.Message)
+	}
+
+	systemMsg := &entity.Message{
+		ID:         fmt.Sprintf("msg_%d", time.Now().Unix()),
+		RoomID:     roomID,
+		SenderID:   "system",
+		SenderName: "System",
+		Content:    "游戏开始",
+		Type:       "system",
+		CreatedAt:  time.Now(),
+	}
+	uc.addMessage(ctx, roomID, systemMsg)
+	uc.reportActivity(room.Players, activityInGame, roomID, roomID)
 
 	return nil
 }
 
+// ReportGameResult 游戏服务上报对局结束，房间进入结束状态
+func (uc *RoomUsecase) ReportGameResult(ctx context.Context, roomID, winnerID string) error {
+	switched, err := uc.roomRepo.UpdateStatus(ctx, roomID, "playing", "finished")
+	if err != nil {
+		return errors.New("failed to update room status")
+	}
+	if !switched {
+		return errors.New("room not found or not playing")
+	}
+
+	content := "游戏结束，平局"
+	if winnerID != "" {
+		content = fmt.Sprintf("游戏结束，%s 获胜", winnerID)
+	}
+	systemMsg := &entity.Message{
+		ID:         fmt.Sprintf("msg_%d", time.Now().Unix()),
+		RoomID:     roomID,
+		SenderID:   "system",
+		SenderName: "System",
+		Content:    content,
+		Type:       "system",
+		CreatedAt:  time.Now(),
+	}
+	uc.addMessage(ctx, roomID, systemMsg)
+
+	// 对局结束后玩家回到房间
+	if room, err := uc.roomRepo.GetRoom(ctx, roomID); err == nil && room != nil {
+		uc.reportActivity(room.Players, activityInRoom, roomID, roomID)
+	}
+
+	return nil
+}
+
+// Rematch 房主在对局结束后让房间回到等待状态，可以再次开始游戏
+func (uc *RoomUsecase) Rematch(ctx context.Context, roomID, userID string) error {
+	room, err := uc.roomRepo.GetRoom(ctx, roomID)
+	if err != nil || room == nil {
+		return errors.New("room not found")
+	}
+
+	if room.CreatorID != userID {
+		return errors.New("only the room owner can start a rematch")
+	}
+
+	switched, err := uc.roomRepo.UpdateStatus(ctx, roomID, "finished", "waiting")
+	if err != nil {
+		return errors.New("failed to update room status")
+	}
+	if !switched {
+		return errors.New("game is not finished")
+	}
+
+	systemMsg := &entity.Message{
+		ID:         fmt.Sprintf("msg_%d", time.Now().Unix()),
+		RoomID:     roomID,
+		SenderID:   "system",
+		SenderName: "System",
+		Content:    "房主发起了再来一局",
+		Type:       "system",
+		CreatedAt:  time.Now(),
+	}
+	uc.addMessage(ctx, roomID, systemMsg)
+
+	return nil
+}
+
+// GetRoom 获取房间信息
+func (uc *RoomUsecase) GetRoom(ctx context.Context, roomID string) (*entity.Room, error) {
+	room, err := uc.roomRepo.GetRoom(ctx, roomID)
+	if err != nil || room == nil {
+		return nil, errors.New("room not found")
+	}
+	return room, nil
+}
+
+// SubscribeRoomMessages 订阅房间的新消息，返回的取消函数必须在订阅方退出时调用
+func (uc *RoomUsecase) SubscribeRoomMessages(ctx context.Context, roomID string) (<-chan *entity.Message, func(), error) {
+	room, err := uc.roomRepo.GetRoom(ctx, roomID)
+	if err != nil || room == nil {
+		return nil, nil, errors.New("room not found")
+	}
+
+	sub := uc.broadcaster.subscribe(roomID)
+	cancel := func() {
+		uc.broadcaster.unsubscribe(roomID, sub)
+	}
+	return sub.messages, cancel, nil
+}
+
+// addMessage 保存消息并推送给房间订阅者
+func (uc *RoomUsecase) addMessage(ctx context.Context, roomID string, message *entity.Message) error {
+	if err := uc.roomRepo.AddMessage(ctx, roomID, message); err != nil {
+		return err
+	}
+	uc.broadcaster.publish(roomID, message)
+	return nil
+}
+
+// deleteRoom 删除房间并结束其消息订阅
+func (uc *RoomUsecase) deleteRoom(ctx context.Context, roomID string) {
+	uc.roomRepo.DeleteRoom(ctx, roomID)
+	uc.broadcaster.closeRoom(roomID)
+}
+
+// 游戏配置的取值范围，与游戏服务 normalizeOptions 的校验保持一致
+const (
+	minBoardSize      = 10
+	maxBoardSize      = 60
+	maxFoodCount      = 50
+	minTickIntervalMs = 50
+	maxTickIntervalMs = 1000
+	maxTimeLimitSecs  = 3600
+)
+
+// validateGameOptions 按游戏服务的取值范围检查配置，创建房间时就拒绝无法开始游戏的配置。
+// 零值表示使用游戏服务的默认值
+func validateGameOptions(options entity.GameOptions) error {
+	if options.BoardWidth < 0 || options.BoardHeight < 0 || options.FoodCount < 0 ||
+		options.TickIntervalMs < 0 || options.MaxScore < 0 || options.TimeLimitSeconds < 0 {
+		return errors.New("game options must not be negative")
+	}
+	if (options.BoardWidth != 0 && (options.BoardWidth < minBoardSize || options.BoardWidth > maxBoardSize)) ||
+		(options.BoardHeight != 0 && (options.BoardHeight < minBoardSize || options.BoardHeight > maxBoardSize)) {
+		return fmt.Errorf("board size must be between %d and %d", minBoardSize, maxBoardSize)
+	}
+	if options.FoodCount > maxFoodCount {
+		return fmt.Errorf("food count must be between 1 and %d", maxFoodCount)
+	}
+	if options.TickIntervalMs != 0 && (options.TickIntervalMs < minTickIntervalMs || options.TickIntervalMs > maxTickIntervalMs) {
+		return fmt.Errorf("tick interval must be between %dms and %dms", minTickIntervalMs, maxTickIntervalMs)
+	}
+	if options.TimeLimitSeconds > maxTimeLimitSecs {
+		return fmt.Errorf("time limit must be between 0 and %ds", maxTimeLimitSecs)
+	}
+	if options.WallMode != "" && options.WallMode != "solid" && options.WallMode != "wrap" {
+		return errors.New("wall mode must be solid or wrap")
+	}
+	return nil
+}
+
+// toPbGameOptions 转换游戏配置
+func toPbGameOptions(options entity.GameOptions) *pb.GameOptions {
+	return &pb.GameOptions{
+		BoardWidth:       options.BoardWidth,
+		BoardHeight:      options.BoardHeight,
+		FoodCount:        options.FoodCount,
+		TickIntervalMs:   options.TickIntervalMs,
+		WallMode:         options.WallMode,
+		WallEnabled:      options.WallEnabled,
+		MaxScore:         options.MaxScore,
+		TimeLimitSeconds: options.TimeLimitSeconds,
+	}
+}
+
 // 辅助函数：生成随机字符串
 func generateRandomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
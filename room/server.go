@@ -5,6 +5,8 @@ import (
 	"net"
 
 	"google.golang.org/grpc"
+	"snake-game/auth"
+	"snake-game/healthcheck"
 	grpc_handler "snake-game/room/internal/delivery/grpc"
 	"snake-game/room/internal/repository"
 	"snake-game/room/internal/usecase"
@@ -27,9 +29,13 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	// 识别调用方，handler 据此校验操作权限
+	s := grpc.NewServer(auth.ServerOptions(auth.NewLobbyValidator())...)
 	pb.RegisterRoomServiceServer(s, roomHandler)
 
+	// 标准健康检查，网关据此判断服务是否可用
+	healthcheck.Register(s, "room_service.RoomService")
+
 	log.Println("Room service is running on :50053")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
@@ -42,6 +42,11 @@ fi
 # 设置 MongoDB 连接字符串
 export MONGODB_URI="mongodb://localhost:27017"
 
+# 服务间调用的内部令牌，未设置时为本次启动随机生成
+if [ -z "$INTERNAL_AUTH_TOKEN" ]; then
+    export INTERNAL_AUTH_TOKEN=$(head -c 32 /dev/urandom | od -An -tx1 | tr -d ' \n')
+fi
+
 # 启动服务
 echo "Starting Gateway Service on port 8080..."
 ./bin/gateway_server &